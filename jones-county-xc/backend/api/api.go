@@ -0,0 +1,272 @@
+// Package api is the JSON HTTP API: one handler method per route, registered
+// with method-aware ServeMux patterns so that a wrong method gets a 405 with
+// an Allow header rather than falling through to some other handler.
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/metrics"
+	"jones-county-xc/backend/store"
+)
+
+// Config holds the settings the handlers need from the deployment.
+type Config struct {
+	// AllowedOrigins are the cross-origin sites, the frontend, allowed to
+	// call the API with the session cookie.
+	AllowedOrigins []string
+	// RequestTimeout, when set, is the deadline on each request's context,
+	// so that a slow database gives up rather than holding the request.
+	RequestTimeout time.Duration
+	// Metrics, when set, records every request and is served at
+	// GET /metrics.
+	Metrics *metrics.Metrics
+	// ReadyChecks are what GET /api/health/ready tests; with none the
+	// server is always ready.
+	ReadyChecks []Check
+	// AccessLog, when set, gets a line for each request answered.
+	AccessLog *slog.Logger
+}
+
+// Server serves the routes below from a store.Store.
+type Server struct {
+	store   store.Store
+	config  Config
+	logger  *slog.Logger
+	handler http.Handler
+}
+
+// NewServer returns every route, wrapped in the logging, CORS and session
+// middleware. Writes declare the permission they need with auth.Require;
+// reads of public pages stay open to everyone.
+func NewServer(store store.Store, config Config, logger *slog.Logger) http.Handler {
+	s := &Server{store: store, config: config, logger: logger}
+	s.handler = s.routes()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", s.root)
+	mux.HandleFunc("GET /api/health", s.live)
+	mux.HandleFunc("GET /api/health/live", s.live)
+	mux.HandleFunc("GET /api/health/ready", s.ready)
+	mux.HandleFunc("GET /api/hello", s.hello)
+	if s.config.Metrics != nil {
+		mux.Handle("GET /metrics", s.config.Metrics.Handler())
+	}
+
+	mux.HandleFunc("POST /api/auth/login", s.login)
+	mux.HandleFunc("POST /api/auth/logout", s.logout)
+	mux.HandleFunc("GET /api/auth/me", s.me)
+
+	mux.HandleFunc("GET /api/users", auth.Require(auth.PermManageUsers, s.listUsers))
+	mux.HandleFunc("POST /api/users", auth.Require(auth.PermManageUsers, s.createUser))
+	mux.HandleFunc("DELETE /api/users/{id}", auth.Require(auth.PermManageUsers, s.deleteUser))
+
+	mux.HandleFunc("GET /api/teams", s.listTeams)
+	mux.HandleFunc("POST /api/teams", auth.Require(auth.PermManageTeams, s.createTeam))
+	mux.HandleFunc("GET /api/teams/{id}", s.getTeam)
+	mux.HandleFunc("PUT /api/teams/{id}", auth.Require(auth.PermManageTeams, s.updateTeam))
+	mux.HandleFunc("DELETE /api/teams/{id}", auth.Require(auth.PermManageTeams, s.deleteTeam))
+
+	mux.HandleFunc("GET /api/seasons", s.listSeasons)
+	mux.HandleFunc("POST /api/seasons", auth.Require(auth.PermManageAthletes, s.createSeason))
+	mux.HandleFunc("GET /api/seasons/{year}", s.getSeason)
+	mux.HandleFunc("PUT /api/seasons/{year}/roster/{athleteId}", auth.Require(auth.PermManageAthletes, s.addToRoster))
+	mux.HandleFunc("DELETE /api/seasons/{year}/roster/{athleteId}", auth.Require(auth.PermManageAthletes, s.removeFromRoster))
+
+	mux.HandleFunc("GET /api/athletes", s.listAthletes)
+	mux.HandleFunc("POST /api/athletes", auth.Require(auth.PermManageAthletes, s.createAthlete))
+	mux.HandleFunc("GET /api/athletes/fastest", s.fastestAthletes)
+	mux.HandleFunc("GET /api/athletes/history", auth.Require(auth.PermViewHistory, s.athleteHistory))
+	mux.HandleFunc("GET /api/athletes/{id}", s.getAthlete)
+	mux.HandleFunc("PUT /api/athletes/{id}", auth.Require(auth.PermManageAthletes, s.updateAthlete))
+	mux.HandleFunc("DELETE /api/athletes/{id}", auth.Require(auth.PermManageAthletes, s.deleteAthlete))
+	mux.HandleFunc("GET /api/athletes/{id}/progression", auth.Require(auth.PermViewHistory, s.athleteProgression))
+
+	mux.HandleFunc("GET /api/meets", s.listMeets)
+	mux.HandleFunc("POST /api/meets", auth.Require(auth.PermManageMeets, s.createMeet))
+	mux.HandleFunc("GET /api/meets/{id}", s.getMeet)
+	mux.HandleFunc("PUT /api/meets/{id}", auth.Require(auth.PermManageMeets, s.updateMeet))
+	mux.HandleFunc("DELETE /api/meets/{id}", auth.Require(auth.PermManageMeets, s.deleteMeet))
+	mux.HandleFunc("GET /api/meets/{id}/team-scores", s.meetTeamScores)
+	mux.HandleFunc("GET /api/meets/{id}/races", s.listMeetRaces)
+	mux.HandleFunc("POST /api/meets/{id}/races", auth.Require(auth.PermManageMeets, s.createRace))
+
+	mux.HandleFunc("GET /api/courses", s.listCourses)
+	mux.HandleFunc("POST /api/courses", auth.Require(auth.PermManageMeets, s.createCourse))
+	mux.HandleFunc("GET /api/courses/{id}", s.getCourse)
+	mux.HandleFunc("PUT /api/courses/{id}", auth.Require(auth.PermManageMeets, s.updateCourse))
+	mux.HandleFunc("DELETE /api/courses/{id}", auth.Require(auth.PermManageMeets, s.deleteCourse))
+	mux.HandleFunc("GET /api/courses/{id}/records", s.courseRecords)
+
+	mux.HandleFunc("GET /api/races/{id}", s.getRace)
+	mux.HandleFunc("PUT /api/races/{id}", auth.Require(auth.PermManageMeets, s.updateRace))
+	mux.HandleFunc("DELETE /api/races/{id}", auth.Require(auth.PermManageMeets, s.deleteRace))
+	mux.HandleFunc("GET /api/races/{id}/results", s.listRaceResults)
+	mux.HandleFunc("POST /api/races/{id}/results", auth.Require(auth.PermEnterResults, s.replaceRaceResults))
+	mux.HandleFunc("GET /api/races/{id}/team-scores", s.raceTeamScoresHandler)
+
+	mux.HandleFunc("GET /api/results", s.listResults)
+	mux.HandleFunc("POST /api/results", auth.Require(auth.PermEnterResults, s.createResult))
+	mux.HandleFunc("GET /api/results/fastest", s.fastestTimes)
+	mux.HandleFunc("GET /api/results/latest", s.latestResults)
+	mux.HandleFunc("GET /api/results/meet/{id}", s.listMeetResults)
+	mux.HandleFunc("POST /api/results/meet/{id}", auth.Require(auth.PermEnterResults, s.replaceMeetResults))
+	mux.HandleFunc("GET /api/results/{id}", s.getResult)
+	mux.HandleFunc("PUT /api/results/{id}", auth.Require(auth.PermEnterResults, s.updateResult))
+	mux.HandleFunc("DELETE /api/results/{id}", auth.Require(auth.PermEnterResults, s.deleteResult))
+
+	return s.logRequests(s.measure(mux, s.cors(s.deadline(s.authenticate(jsonMuxErrors(mux))))))
+}
+
+// cors lets the frontend call the API, answering preflight
+// requests before they reach the router. A response names only the
+// request's own origin, and only when it is an allowed one.
+func (s *Server) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); slices.Contains(s.config.AllowedOrigins, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deadline gives each request's context the configured timeout. Every
+// query runs with that context, so it is cancelled when the timeout passes
+// or the client goes away.
+func (s *Server) deadline(next http.Handler) http.Handler {
+	if s.config.RequestTimeout <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), s.config.RequestTimeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticate attaches the logged-in user, if any, to the request context.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok, err := s.sessionUser(r)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		if ok {
+			r = r.WithContext(auth.WithUser(r.Context(), user))
+			if info := requestInfoFrom(r.Context()); info != nil {
+				info.user = user.Username
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// loadUser builds the request identity for a stored user, including the
+// athletes an athlete or parent account is linked to.
+func (s *Server) loadUser(ctx context.Context, u store.User) (auth.User, error) {
+	user := auth.User{ID: int(u.ID), Username: u.Username, Role: auth.Role(u.Role), AthleteIDs: []int{}}
+	if user.Can(auth.PermViewAnyAthlete) {
+		return user, nil
+	}
+	ids, err := s.store.LinkedAthleteIDs(ctx, u.ID)
+	if err != nil {
+		return auth.User{}, err
+	}
+	for _, athleteID := range ids {
+		user.AthleteIDs = append(user.AthleteIDs, int(athleteID))
+	}
+	return user, nil
+}
+
+// sessionUser looks up the user behind the request's session cookie.
+func (s *Server) sessionUser(r *http.Request) (auth.User, bool, error) {
+	token, ok := auth.SessionToken(r)
+	if !ok {
+		return auth.User{}, false, nil
+	}
+	row, err := s.store.SessionUser(r.Context(), auth.HashToken(token), time.Now())
+	if err == sql.ErrNoRows {
+		return auth.User{}, false, nil
+	}
+	if err != nil {
+		return auth.User{}, false, err
+	}
+	user, err := s.loadUser(r.Context(), row)
+	if err != nil {
+		return auth.User{}, false, err
+	}
+	return user, true, nil
+}
+
+// pathID parses the {id} in the route as a database id, writing a 400 that
+// names what kind of id it is and returning false when it is not one.
+func pathID(w http.ResponseWriter, r *http.Request, what string) (int32, bool) {
+	return pathInt32(w, r, "id", what)
+}
+
+// pathInt32 is pathID for a wildcard with another name.
+func pathInt32(w http.ResponseWriter, r *http.Request, name, what string) (int32, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 32)
+	if err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidID, "invalid "+what+" id")
+		return 0, false
+	}
+	return int32(id), true
+}
+
+func (s *Server) root(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte("<h1>Jones County XC API</h1><p>Endpoints:</p><ul><li><a href='/api/health/ready'>/api/health/ready</a></li><li><a href='/api/hello'>/api/hello</a></li><li><a href='/api/athletes'>/api/athletes</a></li><li><a href='/api/meets'>/api/meets</a></li><li><a href='/api/results'>/api/results</a></li></ul>"))
+}
+
+// Handle GET /api/hello — example endpoint
+func (s *Server) hello(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Hello from Jones County XC backend!",
+	})
+}
+
+// missingIDs returns the ids in want that are not in have.
+func missingIDs(want, have []int32) []int32 {
+	found := make(map[int32]bool, len(have))
+	for _, id := range have {
+		found[id] = true
+	}
+	var missing []int32
+	for _, id := range want {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/store"
+	"jones-county-xc/backend/store/storetest"
+)
+
+// apiTest is one request against a server on each kind of store.
+type apiTest struct {
+	name         string
+	method, path string
+	body         string
+	// role logs the request in with an account of that role; athlete and
+	// parent accounts are linked to athlete linkedAthleteID. Empty means
+	// anonymous.
+	role auth.Role
+	// setup adds what the request needs to the store, before the account
+	// is made.
+	setup func(t *testing.T, st store.Store)
+	// fail, when set, names a store method that fails with errFake during
+	// the request.
+	fail string
+	// status is the response code wanted, and want a string the body must
+	// contain.
+	status int
+	want   string
+	// check, if set, looks at the store after the request.
+	check func(t *testing.T, st store.Store)
+}
+
+// linkedAthleteID is Sam, the athlete every athlete and parent account in
+// the tests is linked to.
+const linkedAthleteID = 1
+
+// runAPITests runs every test on a fresh store of each kind.
+func runAPITests(t *testing.T, tests []apiTest) {
+	t.Helper()
+	storetest.Run(t, func(t *testing.T, newStore func(t *testing.T) store.Store) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				st := newStore(t)
+				if tt.setup != nil {
+					tt.setup(t, st)
+				}
+				var cookie *http.Cookie
+				if tt.role != "" {
+					cookie = sessionFor(t, st, tt.role)
+				}
+				if tt.fail == "" {
+					tt.run(t, newTestServer(st), cookie)
+				} else {
+					failing := newFailingStore(st, tt.fail, errFake)
+					tt.run(t, newTestServer(failing), cookie)
+					if !*failing.failed {
+						t.Errorf("the request never called %s", tt.fail)
+					}
+				}
+				if tt.check != nil {
+					tt.check(t, st)
+				}
+			})
+		}
+	})
+}
+
+// run sends the test's request to handler, with cookie when it is not nil,
+// and checks the response.
+func (tt apiTest) run(t *testing.T, handler http.Handler, cookie *http.Cookie) {
+	t.Helper()
+	var body io.Reader
+	if tt.body != "" {
+		body = strings.NewReader(tt.body)
+	}
+	req := httptest.NewRequest(tt.method, tt.path, body)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != tt.status {
+		t.Fatalf("%s %s: status = %d, want %d; body %q", tt.method, tt.path, rec.Code, tt.status, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), tt.want) {
+		t.Errorf("%s %s: body %q does not contain %q", tt.method, tt.path, rec.Body.String(), tt.want)
+	}
+}
+
+// seed runs each of fixtures in turn, for a test that needs several.
+func seed(fixtures ...func(t *testing.T, st store.Store)) func(t *testing.T, st store.Store) {
+	return func(t *testing.T, st store.Store) {
+		t.Helper()
+		for _, fixture := range fixtures {
+			fixture(t, st)
+		}
+	}
+}
+
+// inserted fails the test unless a create went in as row want, so that
+// fixtures can be referred to by their ids.
+func inserted(t *testing.T, want int32, id int32, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != want {
+		t.Fatalf("inserted row %d, want %d; add the fixtures in order", id, want)
+	}
+}
+
+// sessionFor adds an account with role to st and returns the cookie of a
+// session it is logged in with. Athletes and parents are linked to Sam,
+// who is added for them if the test has not already.
+func sessionFor(t *testing.T, st store.Store, role auth.Role) *http.Cookie {
+	t.Helper()
+	ctx := context.Background()
+	id, err := st.CreateUser(ctx, store.User{Username: "tester", PasswordHash: "-", Role: string(role)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role == auth.RoleAthlete || role == auth.RoleParent {
+		if _, err := st.Athlete(ctx, linkedAthleteID); err == sql.ErrNoRows {
+			sam(t, st)
+		}
+		if err := st.LinkAthlete(ctx, id, linkedAthleteID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	token, hash, err := auth.NewSessionToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSession(ctx, hash, id, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	return &http.Cookie{Name: auth.CookieName, Value: token}
+}
+
+func newTestServer(store store.Store) http.Handler {
+	return NewServer(store, Config{AllowedOrigins: []string{"http://localhost:5173"}}, discardLogger())
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// TestWriteEndpointRoles sends each write endpoint through the server as
+// nobody and as each role: anonymous requests need a login, the roles
+// allowed reach the handler, and the rest are forbidden.
+func TestWriteEndpointRoles(t *testing.T) {
+	var (
+		admin = []auth.Role{auth.RoleAdmin}
+		staff = []auth.Role{auth.RoleAdmin, auth.RoleCoach}
+	)
+	endpoints := []struct {
+		method, path string
+		allowed      []auth.Role
+	}{
+		{"POST", "/api/athletes", admin},
+		{"PUT", "/api/athletes/1", admin},
+		{"DELETE", "/api/athletes/1", admin},
+		{"POST", "/api/seasons", admin},
+		{"PUT", "/api/seasons/2025/roster/1", admin},
+		{"DELETE", "/api/seasons/2025/roster/1", admin},
+		{"POST", "/api/meets", admin},
+		{"PUT", "/api/meets/1", admin},
+		{"DELETE", "/api/meets/1", admin},
+		{"POST", "/api/meets/1/races", admin},
+		{"PUT", "/api/races/1", admin},
+		{"DELETE", "/api/races/1", admin},
+		{"POST", "/api/courses", admin},
+		{"PUT", "/api/courses/1", admin},
+		{"DELETE", "/api/courses/1", admin},
+		{"POST", "/api/teams", staff},
+		{"PUT", "/api/teams/1", staff},
+		{"DELETE", "/api/teams/1", staff},
+		{"POST", "/api/results", staff},
+		{"PUT", "/api/results/1", staff},
+		{"DELETE", "/api/results/1", staff},
+		{"POST", "/api/results/meet/1", staff},
+		{"POST", "/api/races/1/results", staff},
+		{"GET", "/api/users", admin},
+		{"POST", "/api/users", admin},
+		{"DELETE", "/api/users/2", admin},
+	}
+	storetest.Run(t, func(t *testing.T, newStore func(t *testing.T) store.Store) {
+		send := func(method, path string, role auth.Role) int {
+			st := newStore(t)
+			req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+			if role != "" {
+				req.AddCookie(sessionFor(t, st, role))
+			}
+			rec := httptest.NewRecorder()
+			newTestServer(st).ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		for _, ep := range endpoints {
+			if got := send(ep.method, ep.path, ""); got != http.StatusUnauthorized {
+				t.Errorf("%s %s anonymous: status = %d, want 401", ep.method, ep.path, got)
+			}
+			for _, role := range auth.Roles {
+				got := send(ep.method, ep.path, role)
+				switch {
+				case slices.Contains(ep.allowed, role) && (got == http.StatusUnauthorized || got == http.StatusForbidden):
+					t.Errorf("%s %s as %s: status = %d, want the handler's answer", ep.method, ep.path, role, got)
+				case !slices.Contains(ep.allowed, role) && got != http.StatusForbidden:
+					t.Errorf("%s %s as %s: status = %d, want 403", ep.method, ep.path, role, got)
+				}
+			}
+		}
+	})
+}
+
+func TestWrongMethodIsNotAllowed(t *testing.T) {
+	tests := []struct {
+		method, path, allow string
+	}{
+		{"POST", "/api/athletes/1", "DELETE, GET, HEAD, PUT"},
+		{"DELETE", "/api/athletes", "GET, HEAD, POST"},
+		{"PUT", "/api/races/1/team-scores", "GET, HEAD"},
+		{"GET", "/api/auth/login", "POST"},
+	}
+	handler := newTestServer(store.NewMemory())
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s %s: status = %d, want 405", tt.method, tt.path, rec.Code)
+		}
+		if got := rec.Header().Get("Allow"); got != tt.allow {
+			t.Errorf("%s %s: Allow = %q, want %q", tt.method, tt.path, got, tt.allow)
+		}
+	}
+}
+
+func TestUnknownPathIsNotFound(t *testing.T) {
+	handler := newTestServer(store.NewMemory())
+	for _, path := range []string{"/nope", "/api/meets/1/nope", "/api/courses/1/records/2"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s: status = %d, want 404", path, rec.Code)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	handler := newTestServer(store.NewMemory())
+	for origin, want := range map[string]string{
+		"http://localhost:5173": "http://localhost:5173",
+		"https://evil.example":  "",
+		"":                      "",
+	} {
+		req := httptest.NewRequest("OPTIONS", "/api/athletes/1", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("preflight from %q: status = %d, want 200", origin, rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != want {
+			t.Errorf("preflight from %q: Access-Control-Allow-Origin = %q, want %q", origin, got, want)
+		}
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	handler := NewServer(store.NewMemory(), Config{RequestTimeout: time.Nanosecond}, discardLogger())
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/athletes", nil))
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), `"code":"timeout"`) {
+		t.Errorf("status = %d, body %q; want the query to give up at the deadline", rec.Code, rec.Body.String())
+	}
+}
+
+func TestBasics(t *testing.T) {
+	runAPITests(t, []apiTest{
+		{name: "root", method: "GET", path: "/", status: 200, want: "Jones County XC API"},
+		{name: "health", method: "GET", path: "/api/health", status: 200, want: `"status":"ok"`},
+		{name: "hello", method: "GET", path: "/api/hello", status: 200, want: "Hello from Jones County XC backend!"},
+		{
+			name: "session lookup fails", method: "GET", path: "/api/health", role: auth.RoleAdmin,
+			fail: "SessionUser", status: 500, want: `"code":"internal_error"`,
+		},
+	})
+}
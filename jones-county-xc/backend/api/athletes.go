@@ -0,0 +1,490 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/records"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+)
+
+// Handle GET /api/athletes — our roster for the season, or another
+// school's with ?team={id}
+func (s *Server) listAthletes(w http.ResponseWriter, r *http.Request) {
+	// Default to our own roster; ?team={id} lists another school's
+	var teamParam int
+	if team := r.URL.Query().Get("team"); team != "" {
+		n, err := strconv.ParseInt(team, 10, 32)
+		if err != nil || n <= 0 {
+			apierror.Write(w, http.StatusBadRequest, apierror.InvalidID, "invalid team id")
+			return
+		}
+		teamParam = int(n)
+	}
+	teamID, err := s.teamOrHome(r.Context(), teamParam)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.TeamNotFound, "team not found")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+
+	// A season lists its roster with the grade recorded for it;
+	// ?season=all lists everyone who has ever been on the team
+	athletes := []Athlete{}
+	if scope.ID == 0 {
+		rows, err := s.store.TeamAthletes(r.Context(), teamID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		for _, row := range rows {
+			athletes = append(athletes, newAthlete(row, season.Grade(int(row.GraduationYear), scope.Year)))
+		}
+	} else {
+		rows, err := s.store.TeamRoster(r.Context(), teamID, scope.ID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		for _, row := range rows {
+			athletes = append(athletes, newAthlete(row, int(row.Grade)))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(athletes)
+}
+
+// Handle POST /api/athletes — create new athlete and put them on the
+// current season's roster
+func (s *Server) createAthlete(w http.ResponseWriter, r *http.Request) {
+	var body athleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	current := season.YearOf(time.Now())
+	gradYear, pr, err := body.validate(current)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	grade := season.Grade(gradYear, current)
+	if !season.InHighSchool(grade) {
+		s.writeError(w, r, apierror.Invalid("graduationYear", "new athletes must be in grades 9-12 this season"))
+		return
+	}
+	teamID, err := s.teamOrHome(r.Context(), body.TeamID)
+	if err == sql.ErrNoRows {
+		s.writeError(w, r, apierror.Invalid("teamId", "teamId does not match a team"))
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	currentSeason, err := ensureSeason(r.Context(), tx, current)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	id, err := tx.CreateAthlete(r.Context(), store.Athlete{
+		TeamID:         teamID,
+		Name:           body.Name,
+		GraduationYear: int32(gradYear),
+		ManualPR:       pr,
+	})
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.PutOnRoster(r.Context(), store.RosterEntry{
+		SeasonID:  currentSeason.ID,
+		AthleteID: id,
+		Grade:     int32(grade),
+	}); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(Athlete{
+		ID:             int(id),
+		TeamID:         int(teamID),
+		Name:           body.Name,
+		Grade:          grade,
+		GraduationYear: gradYear,
+		PersonalRecord: pr,
+	})
+}
+
+// Handle GET /api/athletes/{id} — one athlete with every personal record
+func (s *Server) getAthlete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "athlete")
+	if !ok {
+		return
+	}
+	row, err := s.store.Athlete(r.Context(), id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.AthleteNotFound, "athlete not found")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	recRows, err := s.store.PersonalRecords(r.Context(), id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	prs := make([]PersonalRecord, len(recRows))
+	for i, rec := range recRows {
+		prs[i] = PersonalRecord{
+			Distance:   int(rec.DistanceM),
+			CourseID:   intPtr(rec.CourseID),
+			CourseName: rec.CourseName,
+			Time:       rec.Time,
+			ResultID:   int(rec.ResultID),
+			MeetID:     int(rec.MeetID),
+			MeetName:   rec.MeetName,
+			Date:       rec.Date.Format("2006-01-02"),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	athlete := newAthlete(row, season.Grade(int(row.GraduationYear), season.YearOf(time.Now())))
+	athlete.PersonalRecords = prs
+	json.NewEncoder(w).Encode(athlete)
+}
+
+// Handle PUT /api/athletes/{id} — update athlete, and their grade on the
+// current roster if they are on it
+func (s *Server) updateAthlete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "athlete")
+	if !ok {
+		return
+	}
+	var body athleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	current := season.YearOf(time.Now())
+	gradYear, pr, err := body.validate(current)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	grade := season.Grade(gradYear, current)
+	teamID, err := s.teamOrHome(r.Context(), body.TeamID)
+	if err == sql.ErrNoRows {
+		s.writeError(w, r, apierror.Invalid("teamId", "teamId does not match a team"))
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.UpdateAthlete(r.Context(), store.Athlete{
+		ID:             id,
+		TeamID:         teamID,
+		Name:           body.Name,
+		GraduationYear: int32(gradYear),
+		ManualPR:       pr,
+	})
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	err = refreshRecords(r.Context(), tx, id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.AthleteNotFound, "athlete not found")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	updated, err := tx.Athlete(r.Context(), id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if season.InHighSchool(grade) {
+		currentSeason, err := ensureSeason(r.Context(), tx, current)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		if err := tx.SetRosterGrade(r.Context(), store.RosterEntry{
+			SeasonID:  currentSeason.ID,
+			AthleteID: id,
+			Grade:     int32(grade),
+		}); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newAthlete(updated, grade))
+}
+
+// Handle DELETE /api/athletes/{id} — delete athlete
+func (s *Server) deleteAthlete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "athlete")
+	if !ok {
+		return
+	}
+	found, err := s.store.DeleteAthlete(r.Context(), id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if !found {
+		apierror.Write(w, http.StatusNotFound, apierror.AthleteNotFound, "athlete not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// Handle GET /api/athletes/{id}/progression — the athlete's races grouped
+// by season and distance, with running bests for charting
+func (s *Server) athleteProgression(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "athlete")
+	if !ok {
+		return
+	}
+	if user, _ := auth.UserFromContext(r.Context()); !user.CanViewAthlete(int(id)) {
+		apierror.Write(w, http.StatusForbidden, apierror.Forbidden, "your account does not have permission to do that")
+		return
+	}
+	if _, err := s.store.Athlete(r.Context(), id); err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.AthleteNotFound, "athlete not found")
+		return
+	} else if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	rows, err := s.store.AthleteRaces(r.Context(), id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	// Times come both formatted and in milliseconds so a chart can
+	// plot them without parsing. Delta and ImprovementPct are
+	// against the previous race in the series, and null on the
+	// first; a negative delta is faster
+	type ProgressionRace struct {
+		ResultID         int                `json:"resultId"`
+		MeetID           int                `json:"meetId"`
+		MeetName         string             `json:"meetName"`
+		Date             string             `json:"date"`
+		RaceID           int                `json:"raceId"`
+		Time             racetime.Duration  `json:"time"`
+		TimeMs           int64              `json:"timeMs"`
+		Place            int                `json:"place"`
+		IsPR             bool               `json:"isPR"`
+		SeasonBest       racetime.Duration  `json:"seasonBest"`
+		SeasonBestMs     int64              `json:"seasonBestMs"`
+		PersonalRecord   racetime.Duration  `json:"personalRecord"`
+		PersonalRecordMs int64              `json:"personalRecordMs"`
+		Delta            *racetime.Duration `json:"delta"`
+		DeltaMs          *int64             `json:"deltaMs"`
+		ImprovementPct   *float64           `json:"improvementPercent"`
+	}
+	type ProgressionSeries struct {
+		Season   int               `json:"season"`
+		Distance int               `json:"distanceMeters"`
+		Races    []ProgressionRace `json:"races"`
+	}
+
+	// Each race is keyed by its row index to find the row again
+	races := make([]records.Race, len(rows))
+	for i, row := range rows {
+		races[i] = records.Race{
+			Result: records.Result{
+				ID:       i,
+				Distance: int(row.DistanceM),
+				CourseID: int(row.CourseID),
+				Time:     row.Time,
+			},
+			Season: season.YearOf(row.Date),
+		}
+	}
+	progression := []ProgressionSeries{}
+	for _, sp := range records.Progression(races) {
+		series := ProgressionSeries{Season: sp.Season, Distance: sp.Distance}
+		for _, p := range sp.Points {
+			row := rows[p.ID]
+			race := ProgressionRace{
+				ResultID:         int(row.ResultID),
+				MeetID:           int(row.MeetID),
+				MeetName:         row.MeetName,
+				Date:             row.Date.Format("2006-01-02"),
+				RaceID:           int(row.RaceID),
+				Time:             p.Time,
+				TimeMs:           p.Time.Milliseconds(),
+				Place:            int(row.Place),
+				IsPR:             row.IsPR,
+				SeasonBest:       p.SeasonBest,
+				SeasonBestMs:     p.SeasonBest.Milliseconds(),
+				PersonalRecord:   p.PR,
+				PersonalRecordMs: p.PR.Milliseconds(),
+			}
+			if !p.First {
+				delta, deltaMs, pct := p.Delta, p.Delta.Milliseconds(), p.Improvement
+				race.Delta, race.DeltaMs, race.ImprovementPct = &delta, &deltaMs, &pct
+			}
+			series.Races = append(series.Races, race)
+		}
+		progression = append(progression, series)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(progression)
+}
+
+// Handle GET /api/athletes/fastest — top 5 personal records on the
+// season's roster, or ever for ?season=all
+func (s *Server) fastestAthletes(w http.ResponseWriter, r *http.Request) {
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+	var rows []store.Athlete
+	var err error
+	if scope.ID == 0 {
+		rows, err = s.store.FastestAthletes(r.Context())
+	} else {
+		rows, err = s.store.FastestAthletesInSeason(r.Context(), scope.ID)
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	athletes := make([]Athlete, len(rows))
+	for i, row := range rows {
+		athletes[i] = newAthlete(row, season.Grade(int(row.GraduationYear), scope.Year))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(athletes)
+}
+
+// Handle GET /api/athletes/history?id={id} — an athlete's complete race
+// history. Staff see everyone; athletes and parents only their linked
+// athletes
+func (s *Server) athleteHistory(w http.ResponseWriter, r *http.Request) {
+	athleteID := r.URL.Query().Get("id")
+	if athleteID == "" {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidParameter, "missing id query parameter")
+		return
+	}
+	id, err := strconv.ParseInt(athleteID, 10, 32)
+	if err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidID, "invalid athlete id")
+		return
+	}
+	if user, _ := auth.UserFromContext(r.Context()); !user.CanViewAthlete(int(id)) {
+		apierror.Write(w, http.StatusForbidden, apierror.Forbidden, "your account does not have permission to do that")
+		return
+	}
+
+	type RaceHistory struct {
+		ResultID   int               `json:"resultId"`
+		MeetName   string            `json:"meetName"`
+		Date       string            `json:"date"`
+		RaceID     int               `json:"raceId"`
+		Gender     string            `json:"gender"`
+		Division   string            `json:"division"`
+		Distance   int               `json:"distanceMeters"`
+		Time       racetime.Duration `json:"time"`
+		Place      int               `json:"place"`
+		IsPR       bool              `json:"isPR"`
+		IsCoursePR bool              `json:"isCoursePR"`
+		Splits     []Split           `json:"splits,omitempty"`
+	}
+
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+	rows, err := s.store.AthleteRacesBetween(r.Context(), int32(id), scope.Start, scope.End)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	history := make([]RaceHistory, len(rows))
+	for i, row := range rows {
+		history[i] = RaceHistory{
+			ResultID:   int(row.ResultID),
+			MeetName:   row.MeetName,
+			Date:       row.Date.Format("2006-01-02"),
+			RaceID:     int(row.RaceID),
+			Gender:     string(row.Gender),
+			Division:   string(row.Division),
+			Distance:   int(row.DistanceM),
+			Time:       row.Time,
+			Place:      int(row.Place),
+			IsPR:       row.IsPR,
+			IsCoursePR: row.IsCoursePR,
+		}
+	}
+
+	splitRows, err := s.store.AthleteSplits(r.Context(), int32(id))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	splits := splitsByResult(splitRows)
+	for i := range history {
+		history[i].Splits = splits[history[i].ResultID]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+)
+
+// juniors is the graduation year of this season's eleventh graders.
+var juniors = season.GraduationYear(11, season.YearOf(time.Now()))
+
+// sam adds Sam Runner, a junior on the home team, as athlete 1, and puts
+// him on the current season's roster if the season has been started.
+func sam(t *testing.T, st store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	home, err := st.HomeTeam(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := st.CreateAthlete(ctx, store.Athlete{TeamID: home.ID, Name: "Sam Runner", GraduationYear: int32(juniors)})
+	inserted(t, 1, id, err)
+	current, err := st.Season(ctx, int32(season.YearOf(time.Now())))
+	if err == sql.ErrNoRows {
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.PutOnRoster(ctx, store.RosterEntry{SeasonID: current.ID, AthleteID: 1, Grade: 11}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// samsOpener adds a slower race for Sam on the first day of the season,
+// ten seconds behind his finish at the Invitational, as result 2.
+func samsOpener(t *testing.T, st store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	start, _ := season.Bounds(season.YearOf(time.Now()))
+	id, err := st.CreateMeet(ctx, store.Meet{Name: "Opener", Date: start, Location: "Gray"})
+	inserted(t, 2, id, err)
+	id, err = st.CreateRace(ctx, store.Race{MeetID: 2, Gender: store.GenderGirls, Division: store.DivisionVarsity, DistanceM: 5000})
+	inserted(t, 2, id, err)
+	id, err = st.CreateResult(ctx, store.Result{
+		AthleteID: nullInt32(1), TeamID: 1, RaceID: 2, Time: samsTime + 10000, Place: 3,
+	})
+	inserted(t, 2, id, err)
+	if err := refreshRecords(ctx, st, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAthleteEndpoints(t *testing.T) {
+	samsRaces := seed(sam, parkCourse, invitational, girlsVarsity, samsFinish)
+
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/athletes", setup: seed(thisSeason, sam), status: 200, want: `"grade":11`},
+		{name: "list every season", method: "GET", path: "/api/athletes?season=all", setup: sam, status: 200, want: `"name":"Sam Runner"`},
+		{name: "list with a bad team", method: "GET", path: "/api/athletes?team=x", status: 400, want: "invalid team id"},
+		{name: "list a missing team", method: "GET", path: "/api/athletes?team=9", status: 404, want: "team not found"},
+		{name: "list with a bad season", method: "GET", path: "/api/athletes?season=soon", setup: sam, status: 400, want: "season must be a year or all"},
+		{
+			name: "list when the store is down", method: "GET", path: "/api/athletes",
+			setup: seed(thisSeason, sam), fail: "TeamRoster", status: 500,
+		},
+		{
+			name: "create", method: "POST", path: "/api/athletes", role: auth.RoleAdmin,
+			body:   `{"name":"New Runner","grade":9,"personalRecord":"21:05"}`,
+			status: 201, want: `"personalRecord":"21:05"`,
+			check: func(t *testing.T, st store.Store) {
+				current, err := st.Season(context.Background(), int32(season.YearOf(time.Now())))
+				if err != nil {
+					t.Fatalf("the current season was not started: %v", err)
+				}
+				roster, err := st.Roster(context.Background(), current.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(roster) != 1 || roster[0].AthleteID != 1 || roster[0].Grade != 9 {
+					t.Errorf("roster = %+v, want athlete 1 in grade 9", roster)
+				}
+			},
+		},
+		{name: "create when not logged in", method: "POST", path: "/api/athletes", body: `{"name":"X","grade":9}`, status: 401},
+		{name: "create as a coach", method: "POST", path: "/api/athletes", role: auth.RoleCoach, body: `{"name":"X","grade":9}`, status: 403},
+		{name: "create without a name", method: "POST", path: "/api/athletes", role: auth.RoleAdmin, body: `{"grade":9}`, status: 400, want: "name is required"},
+		{name: "create out of high school", method: "POST", path: "/api/athletes", role: auth.RoleAdmin, body: `{"name":"X","grade":7}`, status: 400},
+		{
+			name: "create on a missing team", method: "POST", path: "/api/athletes", role: auth.RoleAdmin,
+			body: `{"name":"X","grade":9,"teamId":9}`, status: 400, want: "teamId does not match a team",
+		},
+		{
+			name: "create when the transaction fails", method: "POST", path: "/api/athletes", role: auth.RoleAdmin,
+			body: `{"name":"X","grade":9}`, fail: "Commit", status: 500,
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Athlete(context.Background(), 1); err != sql.ErrNoRows {
+					t.Errorf("athlete lookup after a failed commit = %v, want no athlete", err)
+				}
+			},
+		},
+		{
+			name: "get", method: "GET", path: "/api/athletes/1", setup: samsRaces,
+			status: 200, want: `"personalRecords":[{"distanceMeters":5000,"courseId":null`,
+		},
+		{name: "get a missing athlete", method: "GET", path: "/api/athletes/1", status: 404, want: "athlete not found"},
+		{name: "get with a bad id", method: "GET", path: "/api/athletes/me", status: 400, want: "invalid athlete id"},
+		{
+			name: "update", method: "PUT", path: "/api/athletes/1", role: auth.RoleAdmin,
+			body:  fmt.Sprintf(`{"name":"Samuel Runner","graduationYear":%d,"personalRecord":"17:00"}`, juniors),
+			setup: samsRaces, status: 200, want: `"personalRecord":"17:00"`,
+			check: func(t *testing.T, st store.Store) {
+				if got, err := st.Athlete(context.Background(), 1); err != nil || got.Name != "Samuel Runner" {
+					t.Errorf("athlete 1 = %+v, %v; want him renamed", got, err)
+				}
+			},
+		},
+		{
+			name: "update a missing athlete", method: "PUT", path: "/api/athletes/1", role: auth.RoleAdmin,
+			body: fmt.Sprintf(`{"name":"Sam Runner","graduationYear":%d}`, juniors), status: 404,
+		},
+		{
+			name: "update with a bad PR", method: "PUT", path: "/api/athletes/1", role: auth.RoleAdmin,
+			body: fmt.Sprintf(`{"name":"Sam Runner","graduationYear":%d,"personalRecord":"fast"}`, juniors), setup: sam, status: 400,
+		},
+		{
+			name: "delete", method: "DELETE", path: "/api/athletes/1", role: auth.RoleAdmin, setup: sam, status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Athlete(context.Background(), 1); err != sql.ErrNoRows {
+					t.Errorf("athlete lookup after delete = %v, want no athlete", err)
+				}
+			},
+		},
+		{name: "delete a missing athlete", method: "DELETE", path: "/api/athletes/1", role: auth.RoleAdmin, status: 404, want: `"code":"athlete_not_found"`},
+		{
+			name: "delete when the store is down", method: "DELETE", path: "/api/athletes/1", role: auth.RoleAdmin,
+			setup: sam, fail: "DeleteAthlete", status: 500,
+		},
+		{
+			name: "fastest", method: "GET", path: "/api/athletes/fastest",
+			setup: seed(thisSeason, samsRaces), status: 200, want: `"personalRecord":"18:30"`,
+		},
+		{name: "fastest ever", method: "GET", path: "/api/athletes/fastest?season=all", setup: samsRaces, status: 200, want: `"name":"Sam Runner"`},
+		{name: "fastest in a missing season", method: "GET", path: "/api/athletes/fastest?season=1999", status: 404},
+		{
+			name: "history", method: "GET", path: "/api/athletes/history?id=1", role: auth.RoleParent,
+			setup: seed(thisSeason, samsRaces), status: 200, want: `"isPR":true`,
+		},
+		{
+			name: "history with a split's pace", method: "GET", path: "/api/athletes/history?id=1", role: auth.RoleParent,
+			setup: samsRaces, status: 200, want: `"pacePerMile":"5:50.1"`,
+		},
+		{name: "history of someone else's child", method: "GET", path: "/api/athletes/history?id=8", role: auth.RoleParent, status: 403},
+		{name: "history when not logged in", method: "GET", path: "/api/athletes/history?id=1", status: 401},
+		{name: "history without an id", method: "GET", path: "/api/athletes/history", role: auth.RoleCoach, status: 400, want: "missing id"},
+		{name: "history with a bad id", method: "GET", path: "/api/athletes/history?id=x", role: auth.RoleCoach, status: 400, want: "invalid athlete id"},
+		{
+			name: "progression", method: "GET", path: "/api/athletes/1/progression", role: auth.RoleAthlete,
+			setup: seed(samsRaces, samsOpener), status: 200, want: `"deltaMs":-10000`,
+		},
+		{name: "progression of another athlete", method: "GET", path: "/api/athletes/8/progression", role: auth.RoleAthlete, status: 403},
+		{name: "progression of a missing athlete", method: "GET", path: "/api/athletes/1/progression", role: auth.RoleCoach, status: 404},
+	})
+}
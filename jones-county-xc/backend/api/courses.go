@@ -0,0 +1,198 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+)
+
+// courseFromPath looks up the course named by the route's {id}, writing a
+// 400 or 404 and returning false when there is none.
+func (s *Server) courseFromPath(w http.ResponseWriter, r *http.Request) (store.Course, bool) {
+	id, ok := pathID(w, r, "course")
+	if !ok {
+		return store.Course{}, false
+	}
+	row, err := s.store.Course(r.Context(), id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.CourseNotFound, "course not found")
+		return store.Course{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return store.Course{}, false
+	}
+	return row, true
+}
+
+// Handle GET /api/courses — where meets are run
+func (s *Server) listCourses(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.store.Courses(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	courses := make([]Course, len(rows))
+	for i, row := range rows {
+		courses[i] = newCourse(row)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(courses)
+}
+
+// Handle POST /api/courses — add a course
+func (s *Server) createCourse(w http.ResponseWriter, r *http.Request) {
+	var body courseRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	if err := body.validate(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	id, err := s.store.CreateCourse(r.Context(), body.course(0))
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "a course with that name already exists")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newCourse(body.course(id)))
+}
+
+// Handle GET /api/courses/{id}
+func (s *Server) getCourse(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.courseFromPath(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newCourse(row))
+}
+
+// Handle PUT /api/courses/{id} — update a course
+func (s *Server) updateCourse(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.courseFromPath(w, r)
+	if !ok {
+		return
+	}
+	var body courseRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	if err := body.validate(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	err := s.store.UpdateCourse(r.Context(), body.course(row.ID))
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "a course with that name already exists")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newCourse(body.course(row.ID)))
+}
+
+// Handle DELETE /api/courses/{id} — only courses no meet or race is run on
+func (s *Server) deleteCourse(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.courseFromPath(w, r)
+	if !ok {
+		return
+	}
+	used, err := s.store.CourseInUse(r.Context(), row.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if used {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "course still has meets or races")
+		return
+	}
+	err = s.store.DeleteCourse(r.Context(), row.ID)
+	if store.Violates(err, store.ErrReferenced) {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "course still has meets or races")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// Handle GET /api/courses/{id}/records — the fastest times ever run on the
+// course, for each gender and race distance
+func (s *Server) courseRecords(w http.ResponseWriter, r *http.Request) {
+	course, ok := s.courseFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	const recordsPerList = 10
+
+	type CourseRecord struct {
+		AthleteID  *int              `json:"athleteId"`
+		RunnerName string            `json:"runnerName"`
+		TeamName   string            `json:"teamName"`
+		MeetName   string            `json:"meetName"`
+		Date       string            `json:"date"`
+		RaceID     int               `json:"raceId"`
+		Time       racetime.Duration `json:"time"`
+		Place      int               `json:"place"`
+	}
+	type RecordList struct {
+		Gender   string         `json:"gender"`
+		Distance int            `json:"distanceMeters"`
+		Records  []CourseRecord `json:"records"`
+	}
+
+	rows, err := s.store.CourseResults(r.Context(), course.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	// Rows come grouped by gender and distance, fastest first
+	lists := []RecordList{}
+	for _, row := range rows {
+		n := len(lists)
+		if n == 0 || lists[n-1].Gender != string(row.Gender) || lists[n-1].Distance != int(row.DistanceM) {
+			lists = append(lists, RecordList{Gender: string(row.Gender), Distance: int(row.DistanceM)})
+			n++
+		}
+		if len(lists[n-1].Records) == recordsPerList {
+			continue
+		}
+		lists[n-1].Records = append(lists[n-1].Records, CourseRecord{
+			AthleteID:  intPtr(row.AthleteID),
+			RunnerName: row.RunnerName,
+			TeamName:   row.TeamName,
+			MeetName:   row.MeetName,
+			Date:       row.Date.Format("2006-01-02"),
+			RaceID:     int(row.RaceID),
+			Time:       row.Time,
+			Place:      int(row.Place),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"course":  newCourse(course),
+		"records": lists,
+	})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+)
+
+// parkCourse adds City Park, a 5K on grass, as course 1.
+func parkCourse(t *testing.T, st store.Store) {
+	t.Helper()
+	id, err := st.CreateCourse(context.Background(), store.Course{
+		Name:      "City Park",
+		DistanceM: 5000,
+		Surface:   "grass",
+	})
+	inserted(t, 1, id, err)
+}
+
+func TestCourseEndpoints(t *testing.T) {
+	const course = `{"name":"City Park","distanceMeters":5000}`
+	// hillLoop is a second course, course 2
+	hillLoop := func(t *testing.T, st store.Store) {
+		t.Helper()
+		id, err := st.CreateCourse(context.Background(), store.Course{Name: "Hill Loop", DistanceM: 4000})
+		inserted(t, 2, id, err)
+	}
+
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/courses", setup: parkCourse, status: 200, want: `"name":"City Park"`},
+		{name: "list when the store is down", method: "GET", path: "/api/courses", fail: "Courses", status: 500},
+		{
+			name: "create", method: "POST", path: "/api/courses", role: auth.RoleAdmin, body: course, status: 201, want: `"distanceMeters":5000`,
+			check: func(t *testing.T, st store.Store) {
+				if got, err := st.Course(context.Background(), 1); err != nil || got.Name != "City Park" {
+					t.Errorf("course 1 = %+v, %v; want City Park", got, err)
+				}
+			},
+		},
+		{name: "create as a coach", method: "POST", path: "/api/courses", role: auth.RoleCoach, body: course, status: 403},
+		{
+			name: "create with a bad distance", method: "POST", path: "/api/courses", role: auth.RoleAdmin,
+			body: `{"name":"City Park","distanceMeters":5}`, status: 400, want: "distanceMeters must be",
+		},
+		{name: "create a duplicate", method: "POST", path: "/api/courses", role: auth.RoleAdmin, body: course, setup: parkCourse, status: 409},
+		{name: "get", method: "GET", path: "/api/courses/1", setup: parkCourse, status: 200, want: `"surface":"grass"`},
+		{name: "get a missing course", method: "GET", path: "/api/courses/1", status: 404, want: "course not found"},
+		{name: "get with a bad id", method: "GET", path: "/api/courses/park", status: 400, want: "invalid course id"},
+		{
+			name: "update", method: "PUT", path: "/api/courses/1", role: auth.RoleAdmin,
+			body: `{"name":"City Park","distanceMeters":4800}`, setup: parkCourse, status: 200, want: `"distanceMeters":4800`,
+			check: func(t *testing.T, st store.Store) {
+				if got, err := st.Course(context.Background(), 1); err != nil || got.DistanceM != 4800 {
+					t.Errorf("course 1 = %+v, %v; want it 4800 m", got, err)
+				}
+			},
+		},
+		{name: "update a missing course", method: "PUT", path: "/api/courses/1", role: auth.RoleAdmin, body: course, status: 404},
+		{name: "update to a taken name", method: "PUT", path: "/api/courses/2", role: auth.RoleAdmin, body: course, setup: seed(parkCourse, hillLoop), status: 409},
+		{
+			name: "delete", method: "DELETE", path: "/api/courses/1", role: auth.RoleAdmin, setup: parkCourse, status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Course(context.Background(), 1); err != sql.ErrNoRows {
+					t.Errorf("course lookup after delete = %v, want no course", err)
+				}
+			},
+		},
+		{
+			name: "delete a course in use", method: "DELETE", path: "/api/courses/1", role: auth.RoleAdmin,
+			setup: seed(parkCourse, invitational), status: 409, want: "still has meets or races",
+		},
+		{
+			name: "records", method: "GET", path: "/api/courses/1/records",
+			setup: func(t *testing.T, st store.Store) {
+				seed(rivals, sam, parkCourse, invitational, girlsVarsity, samsFinish, boysVarsity)(t, st)
+				id, err := st.CreateResult(context.Background(), store.Result{
+					TeamID: 2, RunnerName: "Other Runner", RaceID: 2, Time: racetime.Duration(990000), Place: 1,
+				})
+				inserted(t, 2, id, err)
+			},
+			status: 200, want: `{"gender":"boys","distanceMeters":5000,"records":[{"athleteId":null,"runnerName":"Other Runner"`,
+		},
+		{name: "records of a missing course", method: "GET", path: "/api/courses/1/records", status: 404},
+		{name: "records when the store is down", method: "GET", path: "/api/courses/1/records", setup: parkCourse, fail: "CourseResults", status: 500},
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/store"
+)
+
+// writeError answers a request that err stopped, with the JSON error body
+// from package apierror.
+//
+// An *apierror.Error, or the apierror.Fields a validation returns, is sent
+// as it is. Database errors a request can bring about get their own codes:
+// a row that is not there is a 404, a duplicate key a 409 duplicate, and a
+// delete that a foreign key blocks a 409 in_use. Anything else is the
+// server's fault. It is logged with the request and its ID, and the client is told
+// only that something went wrong, never what the database said.
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var apiErr *apierror.Error
+	var fields apierror.Fields
+	switch {
+	case errors.As(err, &apiErr):
+		apierror.WriteError(w, apiErr)
+	case errors.As(err, &fields):
+		apierror.WriteError(w, fields.APIError())
+	case errors.Is(err, sql.ErrNoRows):
+		apierror.Write(w, http.StatusNotFound, apierror.NotFound, "not found")
+	case store.Violates(err, store.ErrDuplicate):
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "that is already recorded")
+	case store.Violates(err, store.ErrReferenced):
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "other records still refer to it")
+	case store.Violates(err, store.ErrNoReferencedRow):
+		apierror.Write(w, http.StatusNotFound, apierror.ReferentNotFound, "something the request refers to does not exist")
+	case errors.Is(err, context.Canceled):
+		// The client went away, so nobody will read this
+		apierror.Write(w, http.StatusServiceUnavailable, apierror.Timeout, "the request was cancelled")
+	case errors.Is(err, context.DeadlineExceeded):
+		s.requestLogger(r).Warn("request timed out", "method", r.Method, "path", r.URL.Path, "error", err)
+		apierror.Write(w, http.StatusServiceUnavailable, apierror.Timeout, "the server took too long to answer; try again")
+	default:
+		s.requestLogger(r).Error("request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+		apierror.Write(w, http.StatusInternalServerError, apierror.Internal, "something went wrong on the server")
+	}
+}
+
+// jsonMuxErrors sends the router's own 404 and 405 answers, for a path no
+// route has and a method the path does not take, as JSON errors like every
+// other. The Allow header on a 405 is kept.
+func jsonMuxErrors(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern == "" {
+			w = &muxErrorWriter{ResponseWriter: w}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// muxErrorWriter replaces a plain-text 404 or 405 with the JSON one.
+type muxErrorWriter struct {
+	http.ResponseWriter
+	replaced bool
+}
+
+func (m *muxErrorWriter) WriteHeader(status int) {
+	switch status {
+	case http.StatusNotFound:
+		apierror.Write(m.ResponseWriter, status, apierror.NotFound, "no such endpoint")
+	case http.StatusMethodNotAllowed:
+		apierror.Write(m.ResponseWriter, status, apierror.MethodNotAllowed, "the endpoint does not take that method")
+	default:
+		m.ResponseWriter.WriteHeader(status)
+		return
+	}
+	m.replaced = true
+}
+
+func (m *muxErrorWriter) Write(b []byte) (int, error) {
+	if m.replaced {
+		return len(b), nil
+	}
+	return m.ResponseWriter.Write(b)
+}
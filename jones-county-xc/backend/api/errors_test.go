@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/store"
+	"jones-county-xc/backend/store/storetest"
+)
+
+// errorBody is the JSON of an error response.
+type errorBody struct {
+	Error apierror.Error `json:"error"`
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) apierror.Error {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q is not a JSON error: %v", rec.Body.String(), err)
+	}
+	return body.Error
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+		logged bool
+	}{
+		{apierror.New(http.StatusConflict, apierror.Conflict, "no"), http.StatusConflict, apierror.Conflict, false},
+		{apierror.Invalid("name", "name is required"), http.StatusBadRequest, apierror.ValidationFailed, false},
+		{sql.ErrNoRows, http.StatusNotFound, apierror.NotFound, false},
+		{fmt.Errorf("loading: %w", sql.ErrNoRows), http.StatusNotFound, apierror.NotFound, false},
+		{store.ErrDuplicate, http.StatusConflict, apierror.Duplicate, false},
+		{&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'x' for key 'teams.name'"}, http.StatusConflict, apierror.Duplicate, false},
+		{&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"}, http.StatusConflict, apierror.InUse, false},
+		{store.ErrNoReferencedRow, http.StatusNotFound, apierror.ReferentNotFound, false},
+		{context.Canceled, http.StatusServiceUnavailable, apierror.Timeout, false},
+		{context.DeadlineExceeded, http.StatusServiceUnavailable, apierror.Timeout, true},
+		{&mysql.MySQLError{Number: 1054, Message: "Unknown column 'secret_column'"}, http.StatusInternalServerError, apierror.Internal, true},
+	}
+	for _, tt := range tests {
+		var logs bytes.Buffer
+		s := &Server{logger: slog.New(slog.NewTextHandler(&logs, nil))}
+		rec := httptest.NewRecorder()
+		s.writeError(rec, httptest.NewRequest("GET", "/api/teams", nil), tt.err)
+
+		got := decodeError(t, rec)
+		if rec.Code != tt.status || got.Code != tt.code {
+			t.Errorf("%v: %d %s, want %d %s", tt.err, rec.Code, got.Code, tt.status, tt.code)
+		}
+		if logged := logs.Len() > 0; logged != tt.logged {
+			t.Errorf("%v: logged %q, want logged %v", tt.err, logs.String(), tt.logged)
+		}
+		if tt.status >= 500 && strings.Contains(rec.Body.String(), tt.err.Error()) {
+			t.Errorf("%v: the response %q gives the error away", tt.err, rec.Body.String())
+		}
+	}
+}
+
+func TestErrorResponses(t *testing.T) {
+	tests := []struct {
+		name         string
+		method, path string
+		body         string
+		role         auth.Role
+		status       int
+		want         apierror.Error
+	}{
+		{
+			name: "not found", method: "GET", path: "/api/athletes/9",
+			status: 404, want: apierror.Error{Code: apierror.AthleteNotFound, Message: "athlete not found"},
+		},
+		{
+			name: "bad id", method: "GET", path: "/api/meets/x",
+			status: 400, want: apierror.Error{Code: apierror.InvalidID, Message: "invalid meet id"},
+		},
+		{
+			name: "not logged in", method: "POST", path: "/api/athletes", body: `{}`,
+			status: 401, want: apierror.Error{Code: apierror.LoginRequired, Message: "login required"},
+		},
+		{
+			name: "wrong role", method: "POST", path: "/api/users", body: `{}`, role: auth.RoleCoach,
+			status: 403, want: apierror.Error{Code: apierror.Forbidden, Message: "your account does not have permission to do that"},
+		},
+		{
+			name: "no route", method: "GET", path: "/api/nope",
+			status: 404, want: apierror.Error{Code: apierror.NotFound, Message: "no such endpoint"},
+		},
+		{
+			name: "wrong method", method: "PATCH", path: "/api/athletes",
+			status: 405, want: apierror.Error{Code: apierror.MethodNotAllowed, Message: "the endpoint does not take that method"},
+		},
+		{
+			name: "athlete fields", method: "POST", path: "/api/athletes", role: auth.RoleAdmin,
+			body:   `{"name":" ","grade":9,"personalRecord":"fast"}`,
+			status: 400,
+			want: apierror.Error{
+				Code:    apierror.ValidationFailed,
+				Message: "name is required and must be at most 255 characters; personalRecord must look like mm:ss, mm:ss.f or h:mm:ss",
+				Fields: map[string]string{
+					"name":           "name is required and must be at most 255 characters",
+					"personalRecord": "personalRecord must look like mm:ss, mm:ss.f or h:mm:ss",
+				},
+			},
+		},
+		{
+			name: "meet fields", method: "POST", path: "/api/meets", role: auth.RoleAdmin,
+			body:   `{"name":"County","date":"10/18","courseId":-1}`,
+			status: 400,
+			want: apierror.Error{
+				Code:    apierror.ValidationFailed,
+				Message: "courseId must be positive; date is required and must be YYYY-MM-DD; location is required and must be at most 255 characters",
+				Fields: map[string]string{
+					"courseId": "courseId must be positive",
+					"date":     "date is required and must be YYYY-MM-DD",
+					"location": "location is required and must be at most 255 characters",
+				},
+			},
+		},
+		{
+			name: "finish list fields", method: "POST", path: "/api/results/meet/1", role: auth.RoleCoach,
+			body: `{"results":[
+				{"athleteId":1,"time":"18:00","place":1,"splits":[{"distanceMeters":1600,"elapsed":"19:00"}]},
+				{"athleteId":2,"time":"soon","place":1}
+			]}`,
+			status: 400,
+			want: apierror.Error{
+				Code:    apierror.ValidationFailed,
+				Message: "results[0]: splits[0]: elapsed must be less than the finishing time; results[1]: place 1 appears more than once; results[1]: time must look like mm:ss, mm:ss.f or h:mm:ss",
+				Fields: map[string]string{
+					"results[0].splits[0].elapsed": "results[0]: splits[0]: elapsed must be less than the finishing time",
+					"results[1].place":             "results[1]: place 1 appears more than once",
+					"results[1].time":              "results[1]: time must look like mm:ss, mm:ss.f or h:mm:ss",
+				},
+			},
+		},
+		{
+			name: "unknown reference", method: "POST", path: "/api/results", role: auth.RoleCoach,
+			body:   `{"athleteId":1,"raceId":4,"time":"18:00","place":1}`,
+			status: 400,
+			want: apierror.Error{
+				Code:    apierror.ValidationFailed,
+				Message: "raceId does not match a race",
+				Fields:  map[string]string{"raceId": "raceId does not match a race"},
+			},
+		},
+	}
+	expect := func(t *testing.T, rec *httptest.ResponseRecorder, status int, want apierror.Error) {
+		t.Helper()
+		if rec.Code != status {
+			t.Fatalf("status = %d, want %d; body %q", rec.Code, status, rec.Body.String())
+		}
+		if got := decodeError(t, rec); !reflect.DeepEqual(got, want) {
+			t.Errorf("error = %+v, want %+v", got, want)
+		}
+	}
+	storetest.Run(t, func(t *testing.T, newStore func(t *testing.T) store.Store) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				st := newStore(t)
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+				if tt.role != "" {
+					req.AddCookie(sessionFor(t, st, tt.role))
+				}
+				rec := httptest.NewRecorder()
+				newTestServer(st).ServeHTTP(rec, req)
+				expect(t, rec, tt.status, tt.want)
+			})
+		}
+	})
+}
+
+func TestInternalErrorsAreLogged(t *testing.T) {
+	var logs bytes.Buffer
+	failing := newFailingStore(store.NewMemory(), "Teams", errors.New("dial tcp 10.0.0.5:3306: connection refused"))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/teams", nil)
+	req.Header.Set("X-Request-ID", "abc123")
+	NewServer(failing, Config{}, slog.New(slog.NewTextHandler(&logs, nil))).ServeHTTP(rec, req)
+
+	if got := decodeError(t, rec); rec.Code != 500 || got.Code != apierror.Internal {
+		t.Errorf("response = %d %+v, want 500 internal_error", rec.Code, got)
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.5") {
+		t.Errorf("response %q gives away the database address", rec.Body.String())
+	}
+	for _, want := range []string{"level=ERROR", "request_id=abc123", "path=/api/teams", `error="dial tcp 10.0.0.5:3306`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log %q does not record %s", logs.String(), want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"jones-county-xc/backend/store"
+)
+
+var errFake = errors.New("store is down")
+
+// failingStore is a real store whose method named fail returns err instead,
+// for testing what the handlers do when the database goes away in the
+// middle of a request. Every other call goes through to the store. Only the
+// methods the tests break are overridden; failed is set once one has been
+// called, so a test cannot name a method the handler never reaches.
+type failingStore struct {
+	store.Store
+	fail   string
+	err    error
+	failed *bool
+}
+
+func newFailingStore(st store.Store, fail string, err error) failingStore {
+	return failingStore{Store: st, fail: fail, err: err, failed: new(bool)}
+}
+
+// breaks reports whether the method name should fail, noting that it did.
+func (s failingStore) breaks(name string) bool {
+	if s.fail != name {
+		return false
+	}
+	*s.failed = true
+	return true
+}
+
+func (s failingStore) BeginTx(ctx context.Context) (store.Tx, error) {
+	if s.breaks("BeginTx") {
+		return nil, s.err
+	}
+	tx, err := s.Store.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return failingTx{Tx: tx, store: s}, nil
+}
+
+// failingTx fails its Commit for a failingStore that breaks "Commit".
+type failingTx struct {
+	store.Tx
+	store failingStore
+}
+
+func (tx failingTx) Commit() error {
+	if tx.store.breaks("Commit") {
+		return tx.store.err
+	}
+	return tx.Tx.Commit()
+}
+
+func (s failingStore) SessionUser(ctx context.Context, tokenHash string, now time.Time) (store.User, error) {
+	if s.breaks("SessionUser") {
+		return store.User{}, s.err
+	}
+	return s.Store.SessionUser(ctx, tokenHash, now)
+}
+
+func (s failingStore) UserByUsername(ctx context.Context, username string) (store.User, error) {
+	if s.breaks("UserByUsername") {
+		return store.User{}, s.err
+	}
+	return s.Store.UserByUsername(ctx, username)
+}
+
+func (s failingStore) CreateSession(ctx context.Context, tokenHash string, userID int32, expires time.Time) error {
+	if s.breaks("CreateSession") {
+		return s.err
+	}
+	return s.Store.CreateSession(ctx, tokenHash, userID, expires)
+}
+
+func (s failingStore) DeleteSession(ctx context.Context, tokenHash string) error {
+	if s.breaks("DeleteSession") {
+		return s.err
+	}
+	return s.Store.DeleteSession(ctx, tokenHash)
+}
+
+func (s failingStore) Users(ctx context.Context) ([]store.User, error) {
+	if s.breaks("Users") {
+		return nil, s.err
+	}
+	return s.Store.Users(ctx)
+}
+
+func (s failingStore) DeleteUser(ctx context.Context, id int32) (bool, error) {
+	if s.breaks("DeleteUser") {
+		return false, s.err
+	}
+	return s.Store.DeleteUser(ctx, id)
+}
+
+func (s failingStore) Teams(ctx context.Context) ([]store.Team, error) {
+	if s.breaks("Teams") {
+		return nil, s.err
+	}
+	return s.Store.Teams(ctx)
+}
+
+func (s failingStore) Team(ctx context.Context, id int32) (store.Team, error) {
+	if s.breaks("Team") {
+		return store.Team{}, s.err
+	}
+	return s.Store.Team(ctx, id)
+}
+
+func (s failingStore) TeamRoster(ctx context.Context, teamID, seasonID int32) ([]store.Athlete, error) {
+	if s.breaks("TeamRoster") {
+		return nil, s.err
+	}
+	return s.Store.TeamRoster(ctx, teamID, seasonID)
+}
+
+func (s failingStore) DeleteAthlete(ctx context.Context, id int32) (bool, error) {
+	if s.breaks("DeleteAthlete") {
+		return false, s.err
+	}
+	return s.Store.DeleteAthlete(ctx, id)
+}
+
+func (s failingStore) Seasons(ctx context.Context) ([]store.Season, error) {
+	if s.breaks("Seasons") {
+		return nil, s.err
+	}
+	return s.Store.Seasons(ctx)
+}
+
+func (s failingStore) TakeOffRoster(ctx context.Context, seasonID, athleteID int32) (bool, error) {
+	if s.breaks("TakeOffRoster") {
+		return false, s.err
+	}
+	return s.Store.TakeOffRoster(ctx, seasonID, athleteID)
+}
+
+func (s failingStore) Courses(ctx context.Context) ([]store.Course, error) {
+	if s.breaks("Courses") {
+		return nil, s.err
+	}
+	return s.Store.Courses(ctx)
+}
+
+func (s failingStore) CourseResults(ctx context.Context, courseID int32) ([]store.CourseResult, error) {
+	if s.breaks("CourseResults") {
+		return nil, s.err
+	}
+	return s.Store.CourseResults(ctx, courseID)
+}
+
+func (s failingStore) Meets(ctx context.Context, start, end time.Time) ([]store.Meet, error) {
+	if s.breaks("Meets") {
+		return nil, s.err
+	}
+	return s.Store.Meets(ctx, start, end)
+}
+
+func (s failingStore) ResultsBetween(ctx context.Context, start, end time.Time) ([]store.Result, error) {
+	if s.breaks("ResultsBetween") {
+		return nil, s.err
+	}
+	return s.Store.ResultsBetween(ctx, start, end)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// checkTimeout is how long a readiness check may take before it counts
+// as failed.
+const checkTimeout = 2 * time.Second
+
+// A Check is something the server needs in order to answer requests, such
+// as its database, tested by GET /api/health/ready.
+type Check struct {
+	Name string
+	// Run returns nil when the dependency is usable.
+	Run func(ctx context.Context) error
+}
+
+// checkResult is one check in the readiness response.
+type checkResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Handle GET /api/health/live — the server is up and answering. It checks
+// nothing else, so that systemd or a monitor restarts the process only
+// when the process itself is stuck. GET /api/health is the same.
+func (s *Server) live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"status": "ok",
+	})
+}
+
+// Handle GET /api/health/ready — whether the server can serve requests:
+// runs every configured check at once, each with checkTimeout, and answers
+// 200 when all pass and 503 when any fails, with each check's status and
+// latency. The response is public, so a failure only says "unavailable" or
+// that it timed out; what went wrong is in the log.
+func (s *Server) ready(w http.ResponseWriter, r *http.Request) {
+	results := make(map[string]checkResult, len(s.config.ReadyChecks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range s.config.ReadyChecks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+			defer cancel()
+			start := time.Now()
+			err := check.Run(ctx)
+			result := checkResult{Status: "ok", LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+			if err != nil {
+				result.Status = "failing"
+				result.Error = "unavailable"
+				if errors.Is(err, context.DeadlineExceeded) {
+					result.Error = "timed out after " + checkTimeout.String()
+				}
+				s.requestLogger(r).Warn("readiness check failed", "check", check.Name, "error", err)
+			}
+			mu.Lock()
+			results[check.Name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	for _, result := range results {
+		if result.Status != "ok" {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]any{
+		"status": status,
+		"checks": results,
+	})
+}
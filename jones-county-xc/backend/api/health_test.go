@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jones-county-xc/backend/store"
+)
+
+func TestLive(t *testing.T) {
+	for _, path := range []string{"/api/health", "/api/health/live"} {
+		rec := httptest.NewRecorder()
+		handler := NewServer(store.NewMemory(), Config{ReadyChecks: []Check{{"database", func(context.Context) error { return errFake }}}}, discardLogger())
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != 200 || rec.Body.String() != "{\"status\":\"ok\"}\n" {
+			t.Errorf("GET %s = %d %q, want 200 ok whatever the database", path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestReady(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	tests := []struct {
+		name   string
+		checks []Check
+		status int
+		want   map[string]string // check name to status and error
+	}{
+		{"no checks", nil, 200, map[string]string{}},
+		{
+			"all pass",
+			[]Check{{"database", ok}, {"migrations", ok}},
+			200, map[string]string{"database": "ok", "migrations": "ok"},
+		},
+		{
+			"one fails",
+			[]Check{{"database", ok}, {"migrations", func(context.Context) error {
+				return errors.New("dial tcp 10.0.0.5:3306: connect: connection refused")
+			}}},
+			503, map[string]string{"database": "ok", "migrations": "failing: unavailable"},
+		},
+		{
+			"times out",
+			[]Check{{"database", func(context.Context) error { return context.DeadlineExceeded }}},
+			503, map[string]string{"database": "failing: timed out after 2s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewServer(store.NewMemory(), Config{ReadyChecks: tt.checks}, discardLogger()).
+				ServeHTTP(rec, httptest.NewRequest("GET", "/api/health/ready", nil))
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			var body struct {
+				Status string                 `json:"status"`
+				Checks map[string]checkResult `json:"checks"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if want := map[int]string{200: "ok", 503: "unavailable"}[tt.status]; body.Status != want {
+				t.Errorf("status = %q, want %q", body.Status, want)
+			}
+			got := map[string]string{}
+			for name, check := range body.Checks {
+				got[name] = check.Status
+				if check.Error != "" {
+					got[name] += ": " + check.Error
+				}
+				if check.LatencyMs < 0 {
+					t.Errorf("%s latency = %v", name, check.LatencyMs)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadyKeepsErrorsInTheLog(t *testing.T) {
+	var logs bytes.Buffer
+	failing := func(context.Context) error { return errors.New("dial tcp 10.0.0.5:3306: connect: connection refused") }
+	handler := NewServer(store.NewMemory(), Config{ReadyChecks: []Check{{"database", failing}}}, slog.New(slog.NewJSONHandler(&logs, nil)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/health/ready", nil))
+
+	if strings.Contains(rec.Body.String(), "10.0.0.5") {
+		t.Errorf("response shows the driver error: %s", rec.Body.String())
+	}
+	var logged bool
+	for _, line := range logLines(t, &logs) {
+		if line["msg"] == "readiness check failed" {
+			logged = line["check"] == "database" && strings.Contains(fmt.Sprint(line["error"]), "10.0.0.5")
+		}
+	}
+	if !logged {
+		t.Errorf("the failure was not logged with its error: %s", logs.String())
+	}
+}
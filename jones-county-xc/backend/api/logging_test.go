@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/store"
+)
+
+// logLines decodes the JSON lines a test logger wrote.
+func logLines(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	dec := json.NewDecoder(logs)
+	for dec.More() {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		header string
+		keep   bool
+	}{
+		{"", false},
+		{"7f3c9a1e2b4d4c6e8f0a1b2c3d4e5f60", true},
+		{"bad id\nlevel=ERROR", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/health", nil)
+		if tt.header != "" {
+			req.Header.Set("X-Request-ID", tt.header)
+		}
+		rec := httptest.NewRecorder()
+		newTestServer(store.NewMemory()).ServeHTTP(rec, req)
+
+		got := rec.Header().Get("X-Request-ID")
+		if tt.keep && got != tt.header {
+			t.Errorf("X-Request-ID %q came back as %q", tt.header, got)
+		}
+		if !tt.keep && (got == tt.header || !validRequestID.MatchString(got)) {
+			t.Errorf("X-Request-ID %q: got %q, want a new ID", tt.header, got)
+		}
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var logs bytes.Buffer
+	st := store.NewMemory()
+	cookie := sessionFor(t, st, auth.RoleCoach)
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	handler := NewServer(st, Config{AccessLog: logger}, logger)
+
+	req := httptest.NewRequest("GET", "/api/athletes/x", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	req.AddCookie(cookie)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/health", nil))
+
+	lines := logLines(t, &logs)
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2: %s", len(lines), logs.String())
+	}
+	first := lines[0]
+	for key, want := range map[string]any{
+		"msg":        "request",
+		"level":      "INFO",
+		"request_id": "req-1",
+		"method":     "GET",
+		"path":       "/api/athletes/x",
+		"status":     float64(http.StatusBadRequest),
+		"user":       "tester",
+	} {
+		if first[key] != want {
+			t.Errorf("%s = %v, want %v", key, first[key], want)
+		}
+	}
+	if _, ok := first["latency_ms"].(float64); !ok {
+		t.Errorf("latency_ms = %v, want a number", first["latency_ms"])
+	}
+	if second := lines[1]; second["status"] != float64(http.StatusOK) || second["user"] != nil {
+		t.Errorf("anonymous request logged as %v", second)
+	}
+
+	NewServer(st, Config{}, logger).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/health", nil))
+	if logs.Len() != 0 {
+		t.Errorf("logged %s with no access log", logs.String())
+	}
+}
@@ -0,0 +1,298 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/scoring"
+	"jones-county-xc/backend/store"
+)
+
+// raceTeamScores scores one race's finish list by team.
+func (s *Server) raceTeamScores(ctx context.Context, raceID int32) ([]scoring.TeamScore, error) {
+	rows, err := s.store.RaceFinishers(ctx, raceID)
+	if err != nil {
+		return nil, err
+	}
+	finishers := make([]scoring.Finisher, len(rows))
+	for i, row := range rows {
+		finishers[i] = scoring.Finisher{
+			AthleteID: int(row.AthleteID.Int32),
+			Name:      row.RunnerName,
+			Team:      row.TeamName,
+			Place:     int(row.Place),
+			Time:      row.Time,
+		}
+	}
+	return scoring.Score(finishers), nil
+}
+
+// courseDistance is the distance a race is run at when none is given: the
+// standard distance of its course (the race's own, else the meet's), or a
+// 5K.
+func courseDistance(ctx context.Context, q store.Queries, raceCourseID int, meetCourseID sql.NullInt32) (int, error) {
+	courseID := nullInt32(raceCourseID)
+	if !courseID.Valid {
+		courseID = meetCourseID
+	}
+	if !courseID.Valid {
+		return defaultRaceDistance, nil
+	}
+	course, err := q.Course(ctx, courseID.Int32)
+	if err == sql.ErrNoRows {
+		return defaultRaceDistance, nil
+	}
+	return int(course.DistanceM), err
+}
+
+// meetFromPath looks up the meet named by the route's {id}, writing a 400
+// or 404 and returning false when there is none.
+func (s *Server) meetFromPath(w http.ResponseWriter, r *http.Request) (store.Meet, bool) {
+	id, ok := pathID(w, r, "meet")
+	if !ok {
+		return store.Meet{}, false
+	}
+	row, err := s.store.Meet(r.Context(), id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.MeetNotFound, "meet not found")
+		return store.Meet{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return store.Meet{}, false
+	}
+	return row, true
+}
+
+// Handle GET /api/meets — the season's meets
+func (s *Server) listMeets(w http.ResponseWriter, r *http.Request) {
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+	rows, err := s.store.Meets(r.Context(), scope.Start, scope.End)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	meets := make([]Meet, len(rows))
+	for i, row := range rows {
+		meets[i] = newMeet(row)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meets)
+}
+
+// Handle POST /api/meets — create new meet
+func (s *Server) createMeet(w http.ResponseWriter, r *http.Request) {
+	var body meetRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	date, err := body.validate()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	id, err := s.store.CreateMeet(r.Context(), body.meet(0, date))
+	if store.Violates(err, store.ErrNoReferencedRow) {
+		s.writeError(w, r, apierror.Invalid("courseId", "courseId does not match a course"))
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newMeet(body.meet(id, date)))
+}
+
+// Handle GET /api/meets/{id}
+func (s *Server) getMeet(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newMeet(row))
+}
+
+// Handle PUT /api/meets/{id} — update (or cancel) meet
+func (s *Server) updateMeet(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	var body meetRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	date, err := body.validate()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	// A new date or course can change which finishes were PRs
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.UpdateMeet(r.Context(), body.meet(row.ID, date))
+	if store.Violates(err, store.ErrNoReferencedRow) {
+		s.writeError(w, r, apierror.Invalid("courseId", "courseId does not match a course"))
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	athleteIDs, err := tx.MeetAthleteIDs(r.Context(), row.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := refreshRecords(r.Context(), tx, athleteIDs...); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newMeet(body.meet(row.ID, date)))
+}
+
+// Handle DELETE /api/meets/{id} — delete meet with no results
+func (s *Server) deleteMeet(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	has, err := s.store.MeetHasResults(r.Context(), row.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if has {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "meet has results; delete them first or mark the meet cancelled")
+		return
+	}
+	err = s.store.DeleteMeet(r.Context(), row.ID)
+	if store.Violates(err, store.ErrReferenced) {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "meet has results; delete them first or mark the meet cancelled")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// Handle GET /api/meets/{id}/team-scores — cross-country team scoring for
+// each of the meet's races
+func (s *Server) meetTeamScores(w http.ResponseWriter, r *http.Request) {
+	meet, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	races, err := s.store.MeetRaces(r.Context(), meet.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	type RaceScores struct {
+		Race
+		Teams []scoring.TeamScore `json:"teams"`
+	}
+	scores := make([]RaceScores, len(races))
+	for i, race := range races {
+		teams, err := s.raceTeamScores(r.Context(), race.ID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		scores[i] = RaceScores{Race: newRace(race), Teams: teams}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"meetId": int(meet.ID),
+		"races":  scores,
+	})
+}
+
+// Handle GET /api/meets/{id}/races — a meet's races
+func (s *Server) listMeetRaces(w http.ResponseWriter, r *http.Request) {
+	meet, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	rows, err := s.store.MeetRaces(r.Context(), meet.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	races := make([]Race, len(rows))
+	for i, row := range rows {
+		races[i] = newRace(row)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(races)
+}
+
+// Handle POST /api/meets/{id}/races — add a race to a meet
+func (s *Server) createRace(w http.ResponseWriter, r *http.Request) {
+	meet, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	var body raceRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	start, err := body.validate()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if body.Distance == 0 {
+		body.Distance, err = courseDistance(r.Context(), s.store, body.CourseID, meet.CourseID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	raceID, err := s.store.CreateRace(r.Context(), body.race(0, meet.ID, start))
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "the meet already has a race for that gender and division")
+		return
+	}
+	if store.Violates(err, store.ErrNoReferencedRow) {
+		s.writeError(w, r, apierror.Invalid("courseId", "courseId does not match a course"))
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newRace(body.race(raceID, meet.ID, start)))
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+)
+
+// invitational adds the Invitational, run today at City Park, as meet 1.
+func invitational(t *testing.T, st store.Store) {
+	t.Helper()
+	id, err := st.CreateMeet(context.Background(), store.Meet{
+		Name:     "Invitational",
+		Date:     today(),
+		Location: "Gray",
+		CourseID: nullInt32(1),
+	})
+	inserted(t, 1, id, err)
+}
+
+// today is the date meets run today are stored under.
+func today() time.Time {
+	d, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	return d
+}
+
+func TestMeetEndpoints(t *testing.T) {
+	const meet = `{"name":"Invitational","date":"2025-09-13","location":"Gray"}`
+	samsRaces := seed(sam, parkCourse, invitational, girlsVarsity, samsFinish)
+	// The day before the opener, so the Invitational becomes Sam's first
+	// race and the opener no longer a PR
+	start, _ := season.Bounds(season.YearOf(time.Now()))
+	earlier := start.AddDate(0, 0, -1).Format("2006-01-02")
+
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/meets", setup: seed(thisSeason, parkCourse, invitational), status: 200, want: `"name":"Invitational"`},
+		{name: "list when the store is down", method: "GET", path: "/api/meets", fail: "Meets", status: 500},
+		{
+			name: "create", method: "POST", path: "/api/meets", role: auth.RoleAdmin, body: meet, status: 201, want: `"name":"Invitational"`,
+			check: func(t *testing.T, st store.Store) {
+				if got, err := st.Meet(context.Background(), 1); err != nil || got.Date.Format("2006-01-02") != "2025-09-13" {
+					t.Errorf("meet 1 = %+v, %v; want the Invitational on 2025-09-13", got, err)
+				}
+			},
+		},
+		{name: "create as a coach", method: "POST", path: "/api/meets", role: auth.RoleCoach, body: meet, status: 403},
+		{name: "create without a date", method: "POST", path: "/api/meets", role: auth.RoleAdmin, body: `{"name":"X","location":"Y"}`, status: 400, want: "date is required"},
+		{
+			name: "create on a missing course", method: "POST", path: "/api/meets", role: auth.RoleAdmin,
+			body:   `{"name":"Invitational","date":"2025-09-13","location":"Gray","courseId":9}`,
+			status: 400, want: "courseId does not match a course",
+		},
+		{name: "get", method: "GET", path: "/api/meets/1", setup: seed(parkCourse, invitational), status: 200, want: `"courseId":1`},
+		{name: "get a missing meet", method: "GET", path: "/api/meets/1", status: 404, want: "meet not found"},
+		{name: "get with a bad id", method: "GET", path: "/api/meets/next", status: 400, want: "invalid meet id"},
+		{
+			name: "update moves PRs", method: "PUT", path: "/api/meets/1", role: auth.RoleAdmin,
+			body:  fmt.Sprintf(`{"name":"Invitational","date":%q,"location":"Gray","courseId":1}`, earlier),
+			setup: seed(samsRaces, samsOpener), status: 200, want: fmt.Sprintf(`"date":%q`, earlier),
+			check: func(t *testing.T, st store.Store) {
+				opener, err := st.Result(context.Background(), 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if opener.IsPR {
+					t.Error("the opener is still a PR with a faster race before it")
+				}
+			},
+		},
+		{name: "update a missing meet", method: "PUT", path: "/api/meets/1", role: auth.RoleAdmin, body: meet, status: 404},
+		{name: "update with bad JSON", method: "PUT", path: "/api/meets/1", role: auth.RoleAdmin, body: `{`, setup: seed(parkCourse, invitational), status: 400},
+		{
+			name: "update onto a missing course", method: "PUT", path: "/api/meets/1", role: auth.RoleAdmin,
+			body:  `{"name":"Invitational","date":"2025-09-13","location":"Gray","courseId":9}`,
+			setup: seed(parkCourse, invitational), status: 400, want: "courseId does not match a course",
+		},
+		{
+			name: "delete", method: "DELETE", path: "/api/meets/1", role: auth.RoleAdmin, setup: seed(parkCourse, invitational), status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Meet(context.Background(), 1); err != sql.ErrNoRows {
+					t.Errorf("meet lookup after delete = %v, want no meet", err)
+				}
+			},
+		},
+		{name: "delete a meet with results", method: "DELETE", path: "/api/meets/1", role: auth.RoleAdmin, setup: samsRaces, status: 409, want: "meet has results"},
+		{name: "team scores", method: "GET", path: "/api/meets/1/team-scores", setup: samsRaces, status: 200, want: `"meetId":1`},
+		{name: "team scores of a missing meet", method: "GET", path: "/api/meets/1/team-scores", status: 404},
+		{name: "races", method: "GET", path: "/api/meets/1/races", setup: seed(parkCourse, invitational, girlsVarsity), status: 200, want: `"gender":"girls"`},
+		{name: "races of a missing meet", method: "GET", path: "/api/meets/1/races", status: 404},
+		{
+			name: "add a race at the course's distance", method: "POST", path: "/api/meets/1/races", role: auth.RoleAdmin,
+			body: `{"gender":"boys","division":"varsity"}`,
+			setup: func(t *testing.T, st store.Store) {
+				id, err := st.CreateCourse(context.Background(), store.Course{Name: "Hill Loop", DistanceM: 4000})
+				inserted(t, 1, id, err)
+				invitational(t, st)
+			},
+			status: 201, want: `"distanceMeters":4000`,
+		},
+		{
+			name: "add a race twice", method: "POST", path: "/api/meets/1/races", role: auth.RoleAdmin,
+			body:  `{"gender":"girls","division":"varsity","distanceMeters":5000}`,
+			setup: seed(parkCourse, invitational, girlsVarsity), status: 409, want: "already has a race",
+		},
+		{
+			name: "add a race with a bad gender", method: "POST", path: "/api/meets/1/races", role: auth.RoleAdmin,
+			body: `{"gender":"x","division":"varsity"}`, setup: seed(parkCourse, invitational), status: 400, want: "gender must be",
+		},
+		{
+			name: "add a race as a coach", method: "POST", path: "/api/meets/1/races", role: auth.RoleCoach,
+			body: `{"gender":"boys","division":"varsity"}`, status: 403,
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// measure records each request's route, status and latency in the
+// configured metrics. The route is the pattern mux matches the request to.
+func (s *Server) measure(mux *http.ServeMux, next http.Handler) http.Handler {
+	if s.config.Metrics == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		route := "unmatched"
+		if _, pattern := mux.Handler(r); pattern != "" {
+			route = pattern
+		}
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		s.config.Metrics.ObserveRequest(route, rec.Status(), time.Since(start))
+	})
+}
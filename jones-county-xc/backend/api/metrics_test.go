@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"jones-county-xc/backend/metrics"
+	"jones-county-xc/backend/store"
+)
+
+func TestMetrics(t *testing.T) {
+	handler := NewServer(store.NewMemory(), Config{Metrics: metrics.New()}, discardLogger())
+	for _, path := range []string{"/api/meets/x", "/api/meets/y", "/api/health", "/api/nope"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 200 {
+		t.Fatalf("GET /metrics = %d", rec.Code)
+	}
+	for _, want := range []string{
+		`xc_http_requests_total{route="GET /api/meets/{id}",status="400"} 2`,
+		`xc_http_requests_total{route="GET /api/health",status="200"} 1`,
+		`xc_http_requests_total{route="unmatched",status="404"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("metrics do not include %s", want)
+		}
+	}
+}
+
+func TestNoMetrics(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer(store.NewMemory()).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 404 {
+		t.Errorf("GET /metrics without metrics = %d, want 404", rec.Code)
+	}
+}
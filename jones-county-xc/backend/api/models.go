@@ -0,0 +1,252 @@
+package api
+
+import (
+	"database/sql"
+	"time"
+
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+)
+
+// Athlete is a runner. Grade is the grade in the season being viewed, which
+// is the current season unless a ?season= filter says otherwise.
+// PersonalRecord is the 5K PR: the best 5K result, or a faster hand-entered
+// time from before results were kept here. PersonalRecords, every distance
+// and course, is filled in on single-athlete responses.
+type Athlete struct {
+	ID              int                   `json:"id"`
+	TeamID          int                   `json:"teamId"`
+	Name            string                `json:"name"`
+	Grade           int                   `json:"grade"`
+	GraduationYear  int                   `json:"graduationYear"`
+	PersonalRecord  racetime.NullDuration `json:"personalRecord"`
+	Events          string                `json:"events"`
+	PersonalRecords []PersonalRecord      `json:"personalRecords,omitempty"`
+}
+
+// newAthlete makes an Athlete of a store one, in the given grade.
+func newAthlete(a store.Athlete, grade int) Athlete {
+	return Athlete{
+		ID:             int(a.ID),
+		TeamID:         int(a.TeamID),
+		Name:           a.Name,
+		Grade:          grade,
+		GraduationYear: int(a.GraduationYear),
+		PersonalRecord: a.PersonalRecord,
+		Events:         a.Events,
+	}
+}
+
+// PersonalRecord is an athlete's best time at a distance, overall when
+// CourseID is null or on that course, and the meet it was run at.
+type PersonalRecord struct {
+	Distance   int               `json:"distanceMeters"`
+	CourseID   *int              `json:"courseId"`
+	CourseName string            `json:"courseName,omitempty"`
+	Time       racetime.Duration `json:"time"`
+	ResultID   int               `json:"resultId"`
+	MeetID     int               `json:"meetId"`
+	MeetName   string            `json:"meetName"`
+	Date       string            `json:"date"`
+}
+
+type Season struct {
+	ID        int    `json:"id"`
+	Year      int    `json:"year"`
+	Name      string `json:"name"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+func newSeason(s store.Season) Season {
+	return Season{
+		ID:        int(s.ID),
+		Year:      int(s.Year),
+		Name:      s.Name,
+		StartDate: s.Start.Format("2006-01-02"),
+		EndDate:   s.End.Format("2006-01-02"),
+	}
+}
+
+// seasonScope is what a ?season= filter resolved to: one season's dates and
+// roster, or every season ever when ID is 0. Year is the season grades are
+// computed for, which is the current one for ?season=all.
+type seasonScope struct {
+	ID    int32
+	Year  int
+	Start time.Time
+	End   time.Time
+}
+
+type Team struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+	IsHome    bool   `json:"isHome"`
+}
+
+type Meet struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Date        string `json:"date"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+	Cancelled   bool   `json:"cancelled"`
+	CourseID    *int   `json:"courseId"`
+}
+
+func newMeet(m store.Meet) Meet {
+	return Meet{
+		ID:          int(m.ID),
+		Name:        m.Name,
+		Date:        m.Date.Format("2006-01-02"),
+		Location:    m.Location,
+		Description: m.Description,
+		Cancelled:   m.Cancelled,
+		CourseID:    intPtr(m.CourseID),
+	}
+}
+
+// Course is a venue's measured loop. Distance is its standard race length
+// in meters; ElevationGain is null when nobody has measured it.
+type Course struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	Distance      int    `json:"distanceMeters"`
+	Surface       string `json:"surface"`
+	ElevationGain *int   `json:"elevationGainMeters"`
+	Notes         string `json:"notes"`
+}
+
+func newCourse(c store.Course) Course {
+	return Course{
+		ID:            int(c.ID),
+		Name:          c.Name,
+		Address:       c.Address,
+		Distance:      int(c.DistanceM),
+		Surface:       c.Surface,
+		ElevationGain: intPtr(c.ElevationGainM),
+		Notes:         c.Notes,
+	}
+}
+
+// defaultRaceDistance is the length of a race when none is given: the
+// high-school 5K.
+const defaultRaceDistance = 5000
+
+// Race is one start at a meet, such as boys varsity or girls JV. Distance
+// is in meters and StartTime is HH:MM, or empty when not yet scheduled.
+// CourseID is set only when the race is not run on the meet's course.
+type Race struct {
+	ID        int    `json:"id"`
+	MeetID    int    `json:"meetId"`
+	Gender    string `json:"gender"`
+	Division  string `json:"division"`
+	Distance  int    `json:"distanceMeters"`
+	StartTime string `json:"startTime"`
+	CourseID  *int   `json:"courseId"`
+}
+
+func newRace(r store.Race) Race {
+	start := r.StartTime
+	if len(start) > 5 {
+		start = start[:5]
+	}
+	return Race{
+		ID:        int(r.ID),
+		MeetID:    int(r.MeetID),
+		Gender:    string(r.Gender),
+		Division:  string(r.Division),
+		Distance:  int(r.DistanceM),
+		StartTime: start,
+		CourseID:  intPtr(r.CourseID),
+	}
+}
+
+// Result is one finish. Opponent finishers have no athlete record, so
+// AthleteID is null and RunnerName/TeamID identify the runner. Splits are
+// filled in on single-result, per-meet and per-race responses. IsPR and
+// IsCoursePR say whether the finish set a personal record at its distance,
+// overall or on its course, at the time it was run.
+type Result struct {
+	ID         int               `json:"id"`
+	AthleteID  *int              `json:"athleteId"`
+	TeamID     int               `json:"teamId"`
+	RunnerName string            `json:"runnerName"`
+	RaceID     int               `json:"raceId"`
+	MeetID     int               `json:"meetId"`
+	Time       racetime.Duration `json:"time"`
+	Place      int               `json:"place"`
+	IsPR       bool              `json:"isPR"`
+	IsCoursePR bool              `json:"isCoursePR"`
+	Splits     []Split           `json:"splits,omitempty"`
+}
+
+// Split is a runner's elapsed time at a marker on the course. Segment is
+// the time since the previous split (or the start) and Pace is that
+// segment's pace per mile, which is where a runner fading shows up.
+type Split struct {
+	Index    int               `json:"index"`
+	Distance int               `json:"distanceMeters"`
+	Elapsed  racetime.Duration `json:"elapsed"`
+	Segment  racetime.Duration `json:"segment"`
+	Pace     racetime.Duration `json:"pacePerMile"`
+}
+
+// splitsByResult groups splits, ordered by result and split index, by
+// result id and works out each segment.
+func splitsByResult(rows []store.Split) map[int][]Split {
+	out := map[int][]Split{}
+	for _, row := range rows {
+		id := int(row.ResultID)
+		var prev Split
+		if n := len(out[id]); n > 0 {
+			prev = out[id][n-1]
+		}
+		segment := row.Elapsed - prev.Elapsed
+		out[id] = append(out[id], Split{
+			Index:    int(row.Index),
+			Distance: int(row.DistanceM),
+			Elapsed:  row.Elapsed,
+			Segment:  segment,
+			Pace:     segment.PerMile(int(row.DistanceM) - prev.Distance),
+		})
+	}
+	return out
+}
+
+// attachSplits fills in the splits of each result from rows.
+func attachSplits(results []Result, rows []store.Split) {
+	splits := splitsByResult(rows)
+	for i := range results {
+		results[i].Splits = splits[results[i].ID]
+	}
+}
+
+func newResult(r store.Result) Result {
+	return Result{
+		ID:         int(r.ID),
+		AthleteID:  intPtr(r.AthleteID),
+		TeamID:     int(r.TeamID),
+		RunnerName: r.RunnerName,
+		RaceID:     int(r.RaceID),
+		MeetID:     int(r.MeetID),
+		Time:       r.Time,
+		Place:      int(r.Place),
+		IsPR:       r.IsPR,
+		IsCoursePR: r.IsCoursePR,
+	}
+}
+
+func intPtr(n sql.NullInt32) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int32)
+	return &v
+}
+
+func nullInt32(n int) sql.NullInt32 {
+	return sql.NullInt32{Int32: int32(n), Valid: n != 0}
+}
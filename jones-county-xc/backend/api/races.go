@@ -0,0 +1,217 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/store"
+)
+
+// raceFromPath looks up the race named by the route's {id}, writing a 400
+// or 404 and returning false when there is none.
+func (s *Server) raceFromPath(w http.ResponseWriter, r *http.Request) (store.Race, bool) {
+	id, ok := pathID(w, r, "race")
+	if !ok {
+		return store.Race{}, false
+	}
+	race, err := s.store.Race(r.Context(), id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.RaceNotFound, "race not found")
+		return store.Race{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return store.Race{}, false
+	}
+	return race, true
+}
+
+// Handle GET /api/races/{id}
+func (s *Server) getRace(w http.ResponseWriter, r *http.Request) {
+	race, ok := s.raceFromPath(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newRace(race))
+}
+
+// Handle PUT /api/races/{id} — change a race's division, distance or start
+// time
+func (s *Server) updateRace(w http.ResponseWriter, r *http.Request) {
+	race, ok := s.raceFromPath(w, r)
+	if !ok {
+		return
+	}
+	var body raceRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	start, err := body.validate()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if body.Distance == 0 {
+		meet, err := s.store.Meet(r.Context(), race.MeetID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		body.Distance, err = courseDistance(r.Context(), s.store, body.CourseID, meet.CourseID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+
+	// A new distance, course or start time can change which finishes were
+	// PRs
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.UpdateRace(r.Context(), body.race(race.ID, race.MeetID, start))
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "the meet already has a race for that gender and division")
+		return
+	}
+	if store.Violates(err, store.ErrNoReferencedRow) {
+		s.writeError(w, r, apierror.Invalid("courseId", "courseId does not match a course"))
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	athleteIDs, err := tx.RaceAthleteIDs(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := refreshRecords(r.Context(), tx, athleteIDs...); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newRace(body.race(race.ID, race.MeetID, start)))
+}
+
+// Handle DELETE /api/races/{id} — delete a race with no results
+func (s *Server) deleteRace(w http.ResponseWriter, r *http.Request) {
+	race, ok := s.raceFromPath(w, r)
+	if !ok {
+		return
+	}
+	has, err := s.store.RaceHasResults(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if has {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "race has results; delete them first")
+		return
+	}
+	err = s.store.DeleteRace(r.Context(), race.ID)
+	if store.Violates(err, store.ErrReferenced) {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "race has results; delete them first")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// Handle GET /api/races/{id}/results — one race's finish list; ?team={id}
+// narrows it to one school
+func (s *Server) listRaceResults(w http.ResponseWriter, r *http.Request) {
+	race, ok := s.raceFromPath(w, r)
+	if !ok {
+		return
+	}
+	var rows []store.Result
+	if team := r.URL.Query().Get("team"); team != "" {
+		teamID, err := strconv.ParseInt(team, 10, 32)
+		if err != nil {
+			apierror.Write(w, http.StatusBadRequest, apierror.InvalidID, "invalid team id")
+			return
+		}
+		rows, err = s.store.RaceTeamResults(r.Context(), race.ID, int32(teamID))
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	} else {
+		var err error
+		rows, err = s.store.RaceResults(r.Context(), race.ID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	splits, err := s.store.RaceSplits(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	results := make([]Result, len(rows))
+	for i, row := range rows {
+		results[i] = newResult(row)
+	}
+	attachSplits(results, splits)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// Handle POST /api/races/{id}/results — replace the race's whole finish list
+func (s *Server) replaceRaceResults(w http.ResponseWriter, r *http.Request) {
+	race, ok := s.raceFromPath(w, r)
+	if !ok {
+		return
+	}
+	var body struct {
+		Results []resultRequest `json:"results"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	times, err := validateFinishList(body.Results)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.replaceFinishList(w, r, int(race.ID), 0, body.Results, times)
+}
+
+// Handle GET /api/races/{id}/team-scores — cross-country team scoring
+func (s *Server) raceTeamScoresHandler(w http.ResponseWriter, r *http.Request) {
+	race, ok := s.raceFromPath(w, r)
+	if !ok {
+		return
+	}
+	teams, err := s.raceTeamScores(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"raceId": int(race.ID),
+		"teams":  teams,
+	})
+}
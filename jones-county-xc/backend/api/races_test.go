@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+)
+
+// girlsVarsity adds the girls' varsity 5K at the Invitational as race 1.
+func girlsVarsity(t *testing.T, st store.Store) {
+	t.Helper()
+	id, err := st.CreateRace(context.Background(), store.Race{
+		MeetID:    1,
+		Gender:    store.GenderGirls,
+		Division:  store.DivisionVarsity,
+		DistanceM: 5000,
+	})
+	inserted(t, 1, id, err)
+}
+
+// boysVarsity adds the boys' varsity 5K at the Invitational as race 2.
+func boysVarsity(t *testing.T, st store.Store) {
+	t.Helper()
+	id, err := st.CreateRace(context.Background(), store.Race{
+		MeetID:    1,
+		Gender:    store.GenderBoys,
+		Division:  store.DivisionVarsity,
+		DistanceM: 5000,
+	})
+	inserted(t, 2, id, err)
+}
+
+func TestRaceEndpoints(t *testing.T) {
+	const race = `{"gender":"girls","division":"varsity","distanceMeters":5000,"startTime":"09:30"}`
+	theRace := seed(parkCourse, invitational, girlsVarsity)
+	samsRaces := seed(sam, theRace, samsFinish)
+
+	runAPITests(t, []apiTest{
+		{name: "get", method: "GET", path: "/api/races/1", setup: theRace, status: 200, want: `"division":"varsity"`},
+		{name: "get a missing race", method: "GET", path: "/api/races/1", status: 404, want: "race not found"},
+		{name: "get with a bad id", method: "GET", path: "/api/races/first", status: 400, want: "invalid race id"},
+		{
+			name: "update", method: "PUT", path: "/api/races/1", role: auth.RoleAdmin, body: race,
+			setup: theRace, status: 200, want: `"startTime":"09:30"`,
+			check: func(t *testing.T, st store.Store) {
+				got, err := st.Race(context.Background(), 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.HasPrefix(got.StartTime, "09:30") {
+					t.Errorf("race 1 starts at %q, want 09:30", got.StartTime)
+				}
+			},
+		},
+		{
+			name: "update to the meet's course distance", method: "PUT", path: "/api/races/1", role: auth.RoleAdmin,
+			body: `{"gender":"girls","division":"varsity"}`,
+			setup: func(t *testing.T, st store.Store) {
+				id, err := st.CreateCourse(context.Background(), store.Course{Name: "Hill Loop", DistanceM: 4000})
+				inserted(t, 1, id, err)
+				invitational(t, st)
+				girlsVarsity(t, st)
+			},
+			status: 200, want: `"distanceMeters":4000`,
+		},
+		{name: "update as a coach", method: "PUT", path: "/api/races/1", role: auth.RoleCoach, body: race, status: 403},
+		{name: "update with a bad start time", method: "PUT", path: "/api/races/1", role: auth.RoleAdmin, body: `{"gender":"girls","division":"varsity","startTime":"soon"}`, setup: theRace, status: 400},
+		{name: "update into a clash", method: "PUT", path: "/api/races/2", role: auth.RoleAdmin, body: race, setup: seed(theRace, boysVarsity), status: 409},
+		{
+			name: "delete", method: "DELETE", path: "/api/races/1", role: auth.RoleAdmin, setup: theRace, status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Race(context.Background(), 1); err != sql.ErrNoRows {
+					t.Errorf("race lookup after delete = %v, want no race", err)
+				}
+			},
+		},
+		{name: "delete a race with results", method: "DELETE", path: "/api/races/1", role: auth.RoleAdmin, setup: samsRaces, status: 409, want: "race has results"},
+		{name: "results", method: "GET", path: "/api/races/1/results", setup: samsRaces, status: 200, want: `"splits":[`},
+		{name: "results for one team", method: "GET", path: "/api/races/1/results?team=1", setup: samsRaces, status: 200, want: `"runnerName":"Sam Runner"`},
+		{name: "results with a bad team", method: "GET", path: "/api/races/1/results?team=x", setup: theRace, status: 400, want: "invalid team id"},
+		{
+			name: "replace results", method: "POST", path: "/api/races/1/results", role: auth.RoleCoach,
+			body:  `{"results":[{"athleteId":1,"time":"18:30","place":1},{"runnerName":"Other Runner","teamId":2,"time":"18:45","place":2}]}`,
+			setup: seed(rivals, samsRaces), status: 201,
+			check: func(t *testing.T, st store.Store) {
+				rows, err := st.RaceResults(context.Background(), 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rows) != 2 || rows[1].TeamID != 2 || rows[1].Place != 2 {
+					t.Errorf("results = %+v, want Sam and then the opponent in 2nd", rows)
+				}
+			},
+		},
+		{
+			name: "replace results with an unknown athlete", method: "POST", path: "/api/races/1/results", role: auth.RoleCoach,
+			body:  `{"results":[{"athleteId":8,"time":"18:30","place":1}]}`,
+			setup: theRace, status: 400, want: "unknown athlete ids: [8]",
+		},
+		{
+			name: "replace results with a shared place", method: "POST", path: "/api/races/1/results", role: auth.RoleCoach,
+			body:  `{"results":[{"athleteId":1,"time":"18:30","place":1},{"athleteId":8,"time":"18:31","place":1}]}`,
+			setup: theRace, status: 400,
+		},
+		{name: "replace results as a parent", method: "POST", path: "/api/races/1/results", role: auth.RoleParent, body: `{"results":[]}`, status: 403},
+		{
+			name: "team scores", method: "GET", path: "/api/races/1/team-scores",
+			setup: func(t *testing.T, st store.Store) {
+				rivals(t, st)
+				theRace(t, st)
+				for place := int32(1); place <= 5; place++ {
+					id, err := st.CreateResult(context.Background(), store.Result{
+						TeamID: 2, RunnerName: fmt.Sprintf("Runner %d", place),
+						RaceID: 1, Time: racetime.Duration(1000000 + int64(place)), Place: place,
+					})
+					inserted(t, place, id, err)
+				}
+			},
+			status: 200, want: `"score":15`,
+		},
+		{name: "team scores of a missing race", method: "GET", path: "/api/races/1/team-scores", status: 404},
+	})
+}
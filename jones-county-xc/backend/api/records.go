@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/records"
+	"jones-county-xc/backend/store"
+)
+
+// refreshRecords rebuilds the personal records of the given athletes from
+// their results, re-flags the results that set them and brings each
+// athlete's 5K PR up to date. Zero ids, opponents with no athlete record,
+// are skipped. Run it in the same transaction as any write that adds,
+// removes or moves a finish, or changes a race's distance or course.
+func refreshRecords(ctx context.Context, q store.Queries, athleteIDs ...int32) error {
+	seen := map[int32]bool{}
+	for _, id := range athleteIDs {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		races, err := q.AthleteRaces(ctx, id)
+		if err != nil {
+			return err
+		}
+		results := make([]records.Result, len(races))
+		for i, race := range races {
+			results[i] = records.Result{
+				ID:       int(race.ResultID),
+				Distance: int(race.DistanceM),
+				CourseID: int(race.CourseID),
+				Time:     race.Time,
+			}
+		}
+		recs, flags := records.Compute(results)
+
+		prs := make([]store.PersonalRecord, len(recs))
+		for i, rec := range recs {
+			prs[i] = store.PersonalRecord{
+				DistanceM: int32(rec.Distance),
+				CourseID:  nullInt32(rec.CourseID),
+				ResultID:  int32(rec.ResultID),
+				Time:      rec.Time,
+			}
+		}
+		if err := q.SetPersonalRecords(ctx, id, prs); err != nil {
+			return err
+		}
+		set := make([]store.PRFlags, 0, len(flags))
+		for resultID, f := range flags {
+			set = append(set, store.PRFlags{ResultID: int32(resultID), PR: f.PR, CoursePR: f.CoursePR})
+		}
+		if err := q.SetPRFlags(ctx, id, set); err != nil {
+			return err
+		}
+
+		// The hand-entered PR stands until a faster 5K is run
+		pr, err := q.AthleteManualPR(ctx, id)
+		if err != nil {
+			return err
+		}
+		if best, ok := records.Best(recs, defaultRaceDistance); ok && (!pr.Valid || best.Time < pr.Duration) {
+			pr = racetime.NullDuration{Duration: best.Time, Valid: true}
+		}
+		if err := q.SetAthletePR(ctx, id, pr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
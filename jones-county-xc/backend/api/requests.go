@@ -0,0 +1,377 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+)
+
+// teamRequest is the body accepted by POST /api/teams and PUT /api/teams/{id}.
+type teamRequest struct {
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+}
+
+func (t *teamRequest) validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	t.ShortName = strings.TrimSpace(t.ShortName)
+	invalid := apierror.Fields{}
+	if t.Name == "" || len(t.Name) > 255 {
+		invalid.Add("name", "name is required and must be at most 255 characters")
+	}
+	if len(t.ShortName) > 32 {
+		invalid.Add("shortName", "shortName must be at most 32 characters")
+	}
+	return invalid.Err()
+}
+
+// athleteRequest is the body accepted by POST /api/athletes and PUT
+// /api/athletes/{id}. The athlete's class is given either as graduationYear
+// or as the grade they are in this season.
+type athleteRequest struct {
+	TeamID         int    `json:"teamId"`
+	Name           string `json:"name"`
+	Grade          int    `json:"grade"`
+	GraduationYear int    `json:"graduationYear"`
+	PersonalRecord string `json:"personalRecord"`
+}
+
+// validate trims the name and returns the graduation year, worked out
+// against the given season year when only a grade was sent, and the parsed
+// personal record.
+func (a *athleteRequest) validate(year int) (int, racetime.NullDuration, error) {
+	invalid := apierror.Fields{}
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" || len(a.Name) > 255 {
+		invalid.Add("name", "name is required and must be at most 255 characters")
+	}
+
+	gradYear := a.GraduationYear
+	switch {
+	case gradYear == 0 && !season.InHighSchool(a.Grade):
+		invalid.Add("graduationYear", "graduationYear or a grade of 9-12 is required")
+	case gradYear == 0:
+		gradYear = season.GraduationYear(a.Grade, year)
+	case gradYear < 1900 || gradYear > year+20:
+		invalid.Add("graduationYear", "graduationYear is out of range")
+	case a.Grade != 0 && season.Grade(gradYear, year) != a.Grade:
+		invalid.Add("grade", fmt.Sprintf("graduationYear %d is not grade %d this season", gradYear, a.Grade))
+	}
+
+	pr, err := racetime.ParseNull(a.PersonalRecord)
+	if err != nil {
+		invalid.Add("personalRecord", "personalRecord must look like mm:ss, mm:ss.f or h:mm:ss")
+	}
+	if err := invalid.Err(); err != nil {
+		return 0, racetime.NullDuration{}, err
+	}
+	return gradYear, pr, nil
+}
+
+// courseRequest is the body accepted by POST /api/courses and PUT
+// /api/courses/{id}.
+type courseRequest struct {
+	Name          string `json:"name"`
+	Address       string `json:"address"`
+	Distance      int    `json:"distanceMeters"`
+	Surface       string `json:"surface"`
+	ElevationGain *int   `json:"elevationGainMeters"`
+	Notes         string `json:"notes"`
+}
+
+// validate trims the text fields and fills in the default distance.
+func (c *courseRequest) validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Address = strings.TrimSpace(c.Address)
+	c.Surface = strings.TrimSpace(c.Surface)
+	c.Notes = strings.TrimSpace(c.Notes)
+
+	invalid := apierror.Fields{}
+	if c.Name == "" || len(c.Name) > 255 {
+		invalid.Add("name", "name is required and must be at most 255 characters")
+	}
+	if len(c.Address) > 255 {
+		invalid.Add("address", "address must be at most 255 characters")
+	}
+	if c.Distance == 0 {
+		c.Distance = defaultRaceDistance
+	}
+	if c.Distance < 100 || c.Distance > 20000 {
+		invalid.Add("distanceMeters", "distanceMeters must be between 100 and 20000")
+	}
+	if len(c.Surface) > 64 {
+		invalid.Add("surface", "surface must be at most 64 characters")
+	}
+	if c.ElevationGain != nil && *c.ElevationGain < 0 {
+		invalid.Add("elevationGainMeters", "elevationGainMeters must not be negative")
+	}
+	return invalid.Err()
+}
+
+func (c courseRequest) course(id int32) store.Course {
+	course := store.Course{
+		ID:        id,
+		Name:      c.Name,
+		Address:   c.Address,
+		DistanceM: int32(c.Distance),
+		Surface:   c.Surface,
+		Notes:     c.Notes,
+	}
+	if c.ElevationGain != nil {
+		course.ElevationGainM = sql.NullInt32{Int32: int32(*c.ElevationGain), Valid: true}
+	}
+	return course
+}
+
+// meetRequest is the body accepted by POST /api/meets and PUT /api/meets/{id}.
+type meetRequest struct {
+	Name        string `json:"name"`
+	Date        string `json:"date"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+	Cancelled   bool   `json:"cancelled"`
+	CourseID    int    `json:"courseId"`
+}
+
+// validate trims the text fields and returns the parsed meet date.
+func (m *meetRequest) validate() (time.Time, error) {
+	m.Name = strings.TrimSpace(m.Name)
+	m.Location = strings.TrimSpace(m.Location)
+	m.Description = strings.TrimSpace(m.Description)
+
+	invalid := apierror.Fields{}
+	if m.Name == "" || len(m.Name) > 255 {
+		invalid.Add("name", "name is required and must be at most 255 characters")
+	}
+	if m.Location == "" || len(m.Location) > 255 {
+		invalid.Add("location", "location is required and must be at most 255 characters")
+	}
+	date, err := time.Parse("2006-01-02", m.Date)
+	if err != nil {
+		invalid.Add("date", "date is required and must be YYYY-MM-DD")
+	}
+	if m.CourseID < 0 {
+		invalid.Add("courseId", "courseId must be positive")
+	}
+	if err := invalid.Err(); err != nil {
+		return time.Time{}, err
+	}
+	return date, nil
+}
+
+func (m meetRequest) meet(id int32, date time.Time) store.Meet {
+	return store.Meet{
+		ID:          id,
+		Name:        m.Name,
+		Date:        date,
+		Location:    m.Location,
+		Description: m.Description,
+		Cancelled:   m.Cancelled,
+		CourseID:    nullInt32(m.CourseID),
+	}
+}
+
+// raceRequest is the body accepted by POST /api/meets/{id}/races and PUT
+// /api/races/{id}. Leaving distanceMeters out means the course's distance.
+type raceRequest struct {
+	Gender    string `json:"gender"`
+	Division  string `json:"division"`
+	Distance  int    `json:"distanceMeters"`
+	StartTime string `json:"startTime"`
+	CourseID  int    `json:"courseId"`
+}
+
+// validate returns the start time in the form the store keeps it, HH:MM:SS
+// or empty.
+func (rr *raceRequest) validate() (string, error) {
+	invalid := apierror.Fields{}
+	switch store.Gender(rr.Gender) {
+	case store.GenderBoys, store.GenderGirls, store.GenderMixed:
+	default:
+		invalid.Add("gender", "gender must be boys, girls or mixed")
+	}
+	switch store.Division(rr.Division) {
+	case store.DivisionVarsity, store.DivisionJV, store.DivisionMiddleSchool, store.DivisionOpen:
+	default:
+		invalid.Add("division", "division must be varsity, jv, middle_school or open")
+	}
+	if rr.Distance != 0 && (rr.Distance < 100 || rr.Distance > 20000) {
+		invalid.Add("distanceMeters", "distanceMeters must be between 100 and 20000")
+	}
+	if rr.CourseID < 0 {
+		invalid.Add("courseId", "courseId must be positive")
+	}
+	var start string
+	if rr.StartTime != "" {
+		t, err := time.Parse("15:04", rr.StartTime)
+		if err != nil {
+			invalid.Add("startTime", "startTime must be HH:MM")
+		}
+		start = t.Format("15:04:05")
+	}
+	if err := invalid.Err(); err != nil {
+		return "", err
+	}
+	return start, nil
+}
+
+func (rr raceRequest) race(id, meetID int32, start string) store.Race {
+	return store.Race{
+		ID:        id,
+		MeetID:    meetID,
+		Gender:    store.Gender(rr.Gender),
+		Division:  store.Division(rr.Division),
+		DistanceM: int32(rr.Distance),
+		StartTime: start,
+		CourseID:  nullInt32(rr.CourseID),
+	}
+}
+
+// resultRequest is one finish as accepted by POST /api/results, PUT
+// /api/results/{id} and each entry of a bulk finish list. Our own runners
+// are given by athleteId (their team comes from the athlete record);
+// opponents by runnerName and teamId. The race is raceId, or meetId alone
+// for a meet with a single race.
+type resultRequest struct {
+	AthleteID  int            `json:"athleteId"`
+	TeamID     int            `json:"teamId"`
+	RunnerName string         `json:"runnerName"`
+	RaceID     int            `json:"raceId"`
+	MeetID     int            `json:"meetId"`
+	Time       string         `json:"time"`
+	Place      int            `json:"place"`
+	Splits     []splitRequest `json:"splits"`
+
+	// splitTimes are the parsed Splits[i].Elapsed, set by validate.
+	splitTimes []racetime.Duration
+}
+
+// splitRequest is one split in a result payload. Splits are numbered from 1
+// in the order given.
+type splitRequest struct {
+	Distance int    `json:"distanceMeters"`
+	Elapsed  string `json:"elapsed"`
+}
+
+// result is the finish the request describes, run in raceID, scoring for
+// teamID and timed at t.
+func (r resultRequest) result(id, teamID, raceID int32, t racetime.Duration) store.Result {
+	return store.Result{
+		ID:         id,
+		AthleteID:  nullInt32(r.AthleteID),
+		TeamID:     teamID,
+		RunnerName: r.RunnerName,
+		RaceID:     raceID,
+		Time:       t,
+		Place:      int32(r.Place),
+	}
+}
+
+// splits are the request's splits, once validate has parsed them.
+func (r resultRequest) splits() []store.Split {
+	splits := make([]store.Split, len(r.Splits))
+	for i, split := range r.Splits {
+		splits[i] = store.Split{DistanceM: int32(split.Distance), Elapsed: r.splitTimes[i]}
+	}
+	return splits
+}
+
+// validate checks the fields common to every result payload and returns the
+// parsed finishing time.
+func (r *resultRequest) validate() (racetime.Duration, error) {
+	invalid := r.check()
+	if err := invalid.Err(); err != nil {
+		return 0, err
+	}
+	t, _ := racetime.Parse(r.Time)
+	return t, nil
+}
+
+// check is validate's work, returning every invalid field.
+func (r *resultRequest) check() apierror.Fields {
+	invalid := apierror.Fields{}
+	r.RunnerName = strings.TrimSpace(r.RunnerName)
+	if r.AthleteID < 0 {
+		invalid.Add("athleteId", "athleteId must be positive")
+	}
+	if r.AthleteID == 0 {
+		if r.TeamID <= 0 || r.RunnerName == "" {
+			invalid.Add("athleteId", "either athleteId or both runnerName and teamId are required")
+		}
+		if len(r.RunnerName) > 255 {
+			invalid.Add("runnerName", "runnerName must be at most 255 characters")
+		}
+	} else {
+		r.RunnerName = ""
+	}
+	if r.RaceID < 0 {
+		invalid.Add("raceId", "raceId must be positive")
+	}
+	if r.MeetID < 0 {
+		invalid.Add("meetId", "meetId must be positive")
+	}
+	if r.Place < 1 {
+		invalid.Add("place", "place must be 1 or greater")
+	}
+	t, err := racetime.Parse(r.Time)
+	if err != nil || t <= 0 {
+		invalid.Add("time", "time must look like mm:ss, mm:ss.f or h:mm:ss")
+	}
+
+	r.splitTimes = make([]racetime.Duration, len(r.Splits))
+	for i, split := range r.Splits {
+		field := fmt.Sprintf("splits[%d]", i)
+		elapsed, err := racetime.Parse(split.Elapsed)
+		switch {
+		case err != nil || elapsed <= 0:
+			invalid.Add(field+".elapsed", field+": elapsed must look like mm:ss, mm:ss.f or h:mm:ss")
+		case split.Distance <= 0:
+			invalid.Add(field+".distanceMeters", field+": distanceMeters must be positive")
+		case i > 0 && (split.Distance <= r.Splits[i-1].Distance || elapsed <= r.splitTimes[i-1]):
+			invalid.Add(field, field+": splits must get further and later in order")
+		case t > 0 && elapsed >= t:
+			invalid.Add(field+".elapsed", field+": elapsed must be less than the finishing time")
+		}
+		r.splitTimes[i] = elapsed
+	}
+	return invalid
+}
+
+// validateFinishList validates every entry of a bulk finish list and rejects
+// an athlete or place that appears more than once. Fields are named by
+// their place in the list, as results[2].time.
+func validateFinishList(entries []resultRequest) ([]racetime.Duration, error) {
+	invalid := apierror.Fields{}
+	if len(entries) == 0 {
+		invalid.Add("results", "results must contain at least one finish")
+	}
+	times := make([]racetime.Duration, len(entries))
+	athletes := make(map[int]bool, len(entries))
+	places := make(map[int]bool, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		entry := fmt.Sprintf("results[%d]", i)
+		for field, message := range e.check() {
+			invalid.Add(entry+"."+field, entry+": "+message)
+		}
+		if e.AthleteID != 0 && athletes[e.AthleteID] {
+			invalid.Add(entry+".athleteId", fmt.Sprintf("%s: athlete %d appears more than once", entry, e.AthleteID))
+		}
+		if places[e.Place] {
+			invalid.Add(entry+".place", fmt.Sprintf("%s: place %d appears more than once", entry, e.Place))
+		}
+		athletes[e.AthleteID] = true
+		places[e.Place] = true
+		times[i], _ = racetime.Parse(e.Time)
+	}
+	if err := invalid.Err(); err != nil {
+		return nil, err
+	}
+	return times, nil
+}
@@ -0,0 +1,592 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+)
+
+// runnerTeam works out which team a finish counts for: an athlete's own
+// team, or the opponent team given in the request. An id that matches
+// nothing is an apierror.Fields error.
+func runnerTeam(ctx context.Context, q store.Queries, body resultRequest) (int32, error) {
+	if body.AthleteID != 0 {
+		athlete, err := q.Athlete(ctx, int32(body.AthleteID))
+		if err == sql.ErrNoRows {
+			return 0, apierror.Invalid("athleteId", "athleteId does not match an athlete")
+		}
+		return athlete.TeamID, err
+	}
+	team, err := q.Team(ctx, int32(body.TeamID))
+	if err == sql.ErrNoRows {
+		return 0, apierror.Invalid("teamId", "teamId does not match a team")
+	}
+	return team.ID, err
+}
+
+// raceFor finds the race a finish belongs to: the raceId given or, for a
+// meetId alone, the meet's only race. A meet with no races yet gets an open
+// race over its course the first time a result is entered for it. Ids that
+// do not pick out a race are an apierror.Fields error.
+func raceFor(ctx context.Context, q store.Queries, raceID, meetID int) (store.Race, error) {
+	if raceID != 0 {
+		race, err := q.Race(ctx, int32(raceID))
+		if err == sql.ErrNoRows {
+			return store.Race{}, apierror.Invalid("raceId", "raceId does not match a race")
+		}
+		if err == nil && meetID != 0 && int(race.MeetID) != meetID {
+			return store.Race{}, apierror.Invalid("raceId", "raceId is not a race at meetId")
+		}
+		return race, err
+	}
+	if meetID == 0 {
+		return store.Race{}, apierror.Invalid("raceId", "raceId or meetId is required")
+	}
+	meet, err := q.Meet(ctx, int32(meetID))
+	if err == sql.ErrNoRows {
+		return store.Race{}, apierror.Invalid("meetId", "meetId does not match a meet")
+	}
+	if err != nil {
+		return store.Race{}, err
+	}
+	races, err := q.MeetRaces(ctx, int32(meetID))
+	if err != nil {
+		return store.Race{}, err
+	}
+	switch len(races) {
+	case 0:
+		distance, err := courseDistance(ctx, q, 0, meet.CourseID)
+		if err != nil {
+			return store.Race{}, err
+		}
+		id, err := q.CreateRace(ctx, store.Race{
+			MeetID:    int32(meetID),
+			Gender:    store.GenderMixed,
+			Division:  store.DivisionOpen,
+			DistanceM: int32(distance),
+		})
+		if err != nil {
+			return store.Race{}, err
+		}
+		return q.Race(ctx, id)
+	case 1:
+		return races[0], nil
+	default:
+		return store.Race{}, apierror.Invalid("raceId", "the meet has more than one race; give a raceId")
+	}
+}
+
+// loadResult loads one result with its splits.
+func loadResult(ctx context.Context, q store.Queries, id int32) (Result, error) {
+	row, err := q.Result(ctx, id)
+	if err != nil {
+		return Result{}, err
+	}
+	splits, err := q.ResultSplits(ctx, id)
+	if err != nil {
+		return Result{}, err
+	}
+	result := newResult(row)
+	result.Splits = splitsByResult(splits)[int(id)]
+	return result, nil
+}
+
+// replaceFinishList replaces the whole finish list, ours and opponents', of
+// the race raceFor picks out for raceID and meetID, in one transaction and
+// answers with the new list. The entries have already been through
+// validateFinishList.
+func (s *Server) replaceFinishList(w http.ResponseWriter, r *http.Request, raceID, meetID int, entries []resultRequest, times []racetime.Duration) {
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	race, err := raceFor(r.Context(), tx, raceID, meetID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	var athleteIDs, teamIDs []int32
+	for _, res := range entries {
+		if res.AthleteID != 0 {
+			athleteIDs = append(athleteIDs, int32(res.AthleteID))
+		} else {
+			teamIDs = append(teamIDs, int32(res.TeamID))
+		}
+	}
+	athleteTeams := map[int32]int32{}
+	if len(athleteIDs) > 0 {
+		athleteTeams, err = tx.AthleteTeams(r.Context(), athleteIDs)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		known := make([]int32, 0, len(athleteTeams))
+		for id := range athleteTeams {
+			known = append(known, id)
+		}
+		if unknown := missingIDs(athleteIDs, known); len(unknown) > 0 {
+			s.writeError(w, r, apierror.Invalid("results", fmt.Sprintf("unknown athlete ids: %v", unknown)))
+			return
+		}
+	}
+	if len(teamIDs) > 0 {
+		known, err := tx.KnownTeamIDs(r.Context(), teamIDs)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		if unknown := missingIDs(teamIDs, known); len(unknown) > 0 {
+			s.writeError(w, r, apierror.Invalid("results", fmt.Sprintf("unknown team ids: %v", unknown)))
+			return
+		}
+	}
+
+	// Runners dropped from the list lose any records set here too
+	touched, err := tx.RaceAthleteIDs(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.DeleteRaceResults(r.Context(), race.ID); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	for i, res := range entries {
+		teamID := int32(res.TeamID)
+		if res.AthleteID != 0 {
+			teamID = athleteTeams[int32(res.AthleteID)]
+		}
+		id, err := tx.CreateResult(r.Context(), res.result(0, teamID, race.ID, times[i]))
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		if err := tx.SetSplits(r.Context(), id, res.splits()); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		touched = append(touched, int32(res.AthleteID))
+	}
+	if err := refreshRecords(r.Context(), tx, touched...); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	rows, err := tx.RaceResults(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	splits, err := tx.RaceSplits(r.Context(), race.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	results := make([]Result, len(rows))
+	for i, row := range rows {
+		results[i] = newResult(row)
+	}
+	attachSplits(results, splits)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(results)
+}
+
+// Handle GET /api/results — every result in the season
+func (s *Server) listResults(w http.ResponseWriter, r *http.Request) {
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+	rows, err := s.store.ResultsBetween(r.Context(), scope.Start, scope.End)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	results := make([]Result, len(rows))
+	for i, row := range rows {
+		results[i] = newResult(row)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// Handle POST /api/results — record a single finish
+func (s *Server) createResult(w http.ResponseWriter, r *http.Request) {
+	var body resultRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	t, err := body.validate()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	// Looked up in the transaction, so that a race raceFor creates goes
+	// away again if the result cannot be saved
+	race, err := raceFor(r.Context(), tx, body.RaceID, body.MeetID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	teamID, err := runnerTeam(r.Context(), tx, body)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	id, err := tx.CreateResult(r.Context(), body.result(0, teamID, race.ID, t))
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "athlete or place already recorded for this race")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.SetSplits(r.Context(), id, body.splits()); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := refreshRecords(r.Context(), tx, int32(body.AthleteID)); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	created, err := loadResult(r.Context(), tx, id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// resultFromPath looks up the result named by the route's {id}, writing a
+// 400 or 404 and returning false when there is none.
+func (s *Server) resultFromPath(w http.ResponseWriter, r *http.Request) (store.Result, bool) {
+	id, ok := pathID(w, r, "result")
+	if !ok {
+		return store.Result{}, false
+	}
+	row, err := s.store.Result(r.Context(), id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.ResultNotFound, "result not found")
+		return store.Result{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return store.Result{}, false
+	}
+	return row, true
+}
+
+// Handle GET /api/results/meet/{id} — a meet's finish list; ?team={id}
+// narrows it to one school
+func (s *Server) listMeetResults(w http.ResponseWriter, r *http.Request) {
+	meet, ok := s.meetFromPath(w, r)
+	if !ok {
+		return
+	}
+	meetID := meet.ID
+	var rows []store.Result
+	var err error
+	if team := r.URL.Query().Get("team"); team != "" {
+		n, parseErr := strconv.ParseInt(team, 10, 32)
+		if parseErr != nil {
+			apierror.Write(w, http.StatusBadRequest, apierror.InvalidID, "invalid team id")
+			return
+		}
+		if _, err = s.store.Team(r.Context(), int32(n)); err == sql.ErrNoRows {
+			apierror.Write(w, http.StatusNotFound, apierror.TeamNotFound, "team not found")
+			return
+		} else if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		rows, err = s.store.MeetTeamResults(r.Context(), meetID, int32(n))
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	} else {
+		rows, err = s.store.MeetResults(r.Context(), meetID)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	splits, err := s.store.MeetSplits(r.Context(), meetID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	results := make([]Result, len(rows))
+	for i, row := range rows {
+		results[i] = newResult(row)
+	}
+	attachSplits(results, splits)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// Handle POST /api/results/meet/{id} — replace the finish list of a meet
+// with a single race; meets with several races post to
+// /api/races/{id}/results instead
+func (s *Server) replaceMeetResults(w http.ResponseWriter, r *http.Request) {
+	meetID, ok := pathID(w, r, "meet")
+	if !ok {
+		return
+	}
+	var body struct {
+		Results []resultRequest `json:"results"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	times, err := validateFinishList(body.Results)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if _, err := s.store.Meet(r.Context(), meetID); err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.MeetNotFound, "meet not found")
+		return
+	} else if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	s.replaceFinishList(w, r, 0, int(meetID), body.Results, times)
+}
+
+// Handle GET /api/results/{id}
+func (s *Server) getResult(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "result")
+	if !ok {
+		return
+	}
+	result, err := loadResult(r.Context(), s.store, id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.ResultNotFound, "result not found")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// Handle PUT /api/results/{id} — correct a finish
+func (s *Server) updateResult(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.resultFromPath(w, r)
+	if !ok {
+		return
+	}
+	id := row.ID
+	var body resultRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	if body.RaceID == 0 && body.MeetID == 0 {
+		body.RaceID = int(row.RaceID)
+	}
+	t, err := body.validate()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	race, err := raceFor(r.Context(), tx, body.RaceID, body.MeetID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	teamID, err := runnerTeam(r.Context(), tx, body)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	err = tx.UpdateResult(r.Context(), body.result(id, teamID, race.ID, t))
+	if store.Violates(err, store.ErrNoReferencedRow) {
+		s.writeError(w, r, apierror.Invalid("raceId", "raceId does not match a race"))
+		return
+	}
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "athlete or place already recorded for this race")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	// Leaving splits out keeps the ones on file; [] clears them
+	if body.Splits != nil {
+		if err := tx.SetSplits(r.Context(), id, body.splits()); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	if err := refreshRecords(r.Context(), tx, row.AthleteID.Int32, int32(body.AthleteID)); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	updated, err := loadResult(r.Context(), tx, id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// Handle DELETE /api/results/{id} — remove a finish
+func (s *Server) deleteResult(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.resultFromPath(w, r)
+	if !ok {
+		return
+	}
+	id := row.ID
+
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := tx.DeleteResult(r.Context(), id); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := refreshRecords(r.Context(), tx, row.AthleteID.Int32); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// Handle GET /api/results/fastest — top 10 fastest times at one distance
+// across the season's meets
+func (s *Server) fastestTimes(w http.ResponseWriter, r *http.Request) {
+	type FastestTime struct {
+		AthleteName string            `json:"athleteName"`
+		MeetName    string            `json:"meetName"`
+		Time        racetime.Duration `json:"time"`
+		Place       int               `json:"place"`
+	}
+
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+	// Only times over one distance compare: ?distance={meters}, a 5K by default
+	distance := defaultRaceDistance
+	if d := r.URL.Query().Get("distance"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n <= 0 {
+			apierror.Write(w, http.StatusBadRequest, apierror.InvalidParameter, "invalid distance")
+			return
+		}
+		distance = n
+	}
+	rows, err := s.store.FastestTimes(r.Context(), scope.Start, scope.End, int32(distance))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	times := make([]FastestTime, len(rows))
+	for i, row := range rows {
+		times[i] = FastestTime{
+			AthleteName: row.AthleteName,
+			MeetName:    row.MeetName,
+			Time:        row.Time,
+			Place:       int(row.Place),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(times)
+}
+
+// Handle GET /api/results/latest — results for the season's most recent meet
+func (s *Server) latestResults(w http.ResponseWriter, r *http.Request) {
+	type LatestResult struct {
+		AthleteName string            `json:"athleteName"`
+		MeetName    string            `json:"meetName"`
+		Time        racetime.Duration `json:"time"`
+		Place       int               `json:"place"`
+	}
+
+	scope, ok := s.seasonScopeFor(w, r)
+	if !ok {
+		return
+	}
+	rows, err := s.store.LatestMeetResults(r.Context(), scope.Start, scope.End)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	results := make([]LatestResult, len(rows))
+	for i, row := range rows {
+		results[i] = LatestResult{
+			AthleteName: row.AthleteName,
+			MeetName:    row.MeetName,
+			Time:        row.Time,
+			Place:       int(row.Place),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
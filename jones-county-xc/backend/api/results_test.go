@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+)
+
+// samsTime is Sam's winning time at the Invitational, 18:30.
+const samsTime = racetime.Duration(1110000)
+
+// samsFinish adds Sam's win in the girls' varsity race, with a 5:50 first
+// mile, as result 1 and brings his records up to date.
+func samsFinish(t *testing.T, st store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	id, err := st.CreateResult(ctx, store.Result{AthleteID: nullInt32(1), TeamID: 1, RaceID: 1, Time: samsTime, Place: 1})
+	inserted(t, 1, id, err)
+	if err := st.SetSplits(ctx, 1, []store.Split{{DistanceM: 1609, Elapsed: racetime.Duration(350000)}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := refreshRecords(ctx, st, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResultEndpoints(t *testing.T) {
+	const finish = `{"athleteId":1,"raceId":1,"time":"18:30","place":1,"splits":[{"distanceMeters":1609,"elapsed":"5:50"}]}`
+	const update = `{"athleteId":1,"time":"18:20","place":1}`
+	samsRace := seed(sam, parkCourse, invitational, girlsVarsity)
+	samsRaces := seed(samsRace, samsFinish)
+
+	// samsTimeIs checks result 1 against the time it should have been left at.
+	samsTimeIs := func(want racetime.Duration) func(t *testing.T, st store.Store) {
+		return func(t *testing.T, st store.Store) {
+			got, err := st.Result(context.Background(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Time != want {
+				t.Errorf("result 1 time = %v, want %v", got.Time, want)
+			}
+		}
+	}
+
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/results", setup: seed(thisSeason, samsRaces), status: 200, want: `"time":"18:30"`},
+		{name: "list when the store is down", method: "GET", path: "/api/results", setup: thisSeason, fail: "ResultsBetween", status: 500},
+		{
+			name: "create", method: "POST", path: "/api/results", role: auth.RoleCoach, body: finish,
+			setup: samsRace, status: 201, want: `"runnerName":"Sam Runner"`,
+			check: func(t *testing.T, st store.Store) {
+				splits, err := st.ResultSplits(context.Background(), 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(splits) != 1 || splits[0].Elapsed != racetime.Duration(350000) {
+					t.Errorf("splits = %+v, want 5:50 elapsed", splits)
+				}
+			},
+		},
+		{
+			name: "create in a meet's only race", method: "POST", path: "/api/results", role: auth.RoleCoach,
+			body:  `{"runnerName":"Other Runner","teamId":2,"meetId":1,"time":"19:00","place":3}`,
+			setup: seed(rivals, parkCourse, invitational, girlsVarsity), status: 201,
+			check: func(t *testing.T, st store.Store) {
+				got, err := st.Result(context.Background(), 1)
+				if err != nil || got.RaceID != 1 || got.TeamID != 2 {
+					t.Errorf("result 1 = %+v, %v; want race 1 for team 2", got, err)
+				}
+			},
+		},
+		{
+			name: "create at a meet with several races", method: "POST", path: "/api/results", role: auth.RoleCoach,
+			body: `{"athleteId":1,"meetId":1,"time":"18:30","place":1}`,
+			setup: func(t *testing.T, st store.Store) {
+				samsRace(t, st)
+				id, err := st.CreateRace(context.Background(), store.Race{MeetID: 1, Gender: store.GenderBoys, Division: store.DivisionVarsity, DistanceM: 5000})
+				inserted(t, 2, id, err)
+			},
+			status: 400, want: "give a raceId",
+		},
+		{name: "create in an unknown race", method: "POST", path: "/api/results", role: auth.RoleCoach, body: finish, setup: sam, status: 400, want: "raceId does not match a race"},
+		{
+			name: "create for an unknown athlete", method: "POST", path: "/api/results", role: auth.RoleCoach, body: finish,
+			setup: seed(parkCourse, invitational, girlsVarsity), status: 400, want: "athleteId does not match an athlete",
+		},
+		{name: "create with a bad time", method: "POST", path: "/api/results", role: auth.RoleCoach, body: `{"athleteId":1,"raceId":1,"time":"fast","place":1}`, status: 400, want: "time must look like"},
+		{name: "create a place already taken", method: "POST", path: "/api/results", role: auth.RoleCoach, body: finish, setup: samsRaces, status: 409, want: "already recorded"},
+		{name: "create as an athlete", method: "POST", path: "/api/results", role: auth.RoleAthlete, body: finish, status: 403},
+		{name: "fastest", method: "GET", path: "/api/results/fastest", setup: seed(thisSeason, samsRaces), status: 200, want: `"time":"18:30"`},
+		{name: "fastest at another distance", method: "GET", path: "/api/results/fastest?distance=3200", setup: seed(thisSeason, samsRaces), status: 200, want: `[]`},
+		{name: "fastest with a bad distance", method: "GET", path: "/api/results/fastest?distance=-1", setup: thisSeason, status: 400, want: "invalid distance"},
+		{name: "latest", method: "GET", path: "/api/results/latest", setup: seed(thisSeason, samsRaces), status: 200, want: `"meetName":"Invitational"`},
+		{name: "meet results", method: "GET", path: "/api/results/meet/1", setup: samsRaces, status: 200, want: `"runnerName":"Sam Runner"`},
+		{name: "meet results for a team", method: "GET", path: "/api/results/meet/1?team=1", setup: samsRaces, status: 200, want: `"runnerName":"Sam Runner"`},
+		{name: "meet results for another team", method: "GET", path: "/api/results/meet/1?team=2", setup: seed(rivals, samsRaces), status: 200, want: `[]`},
+		{name: "meet results for a missing team", method: "GET", path: "/api/results/meet/1?team=9", setup: samsRaces, status: 404, want: `"code":"team_not_found"`},
+		{name: "meet results with a bad team", method: "GET", path: "/api/results/meet/1?team=x", setup: samsRaces, status: 400, want: "invalid team id"},
+		{name: "meet results of a missing meet", method: "GET", path: "/api/results/meet/1", status: 404, want: `"code":"meet_not_found"`},
+		{name: "meet results with a bad id", method: "GET", path: "/api/results/meet/two", status: 400, want: "invalid meet id"},
+		{
+			name: "replace meet results", method: "POST", path: "/api/results/meet/1", role: auth.RoleCoach,
+			body: `{"results":[{"athleteId":1,"time":"18:20","place":1}]}`, setup: samsRaces, status: 201,
+			check: func(t *testing.T, st store.Store) {
+				rows, err := st.MeetResults(context.Background(), 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rows) != 1 || rows[0].Time != racetime.Duration(1100000) {
+					t.Errorf("meet results = %+v, want only the 18:20", rows)
+				}
+			},
+		},
+		{name: "replace results of a missing meet", method: "POST", path: "/api/results/meet/1", role: auth.RoleCoach, body: `{"results":[{"athleteId":1,"time":"18:30","place":1}]}`, status: 404},
+		{name: "replace with an empty list", method: "POST", path: "/api/results/meet/1", role: auth.RoleCoach, body: `{"results":[]}`, status: 400, want: "at least one finish"},
+		{name: "get", method: "GET", path: "/api/results/1", setup: samsRaces, status: 200, want: `"place":1`},
+		{name: "get a missing result", method: "GET", path: "/api/results/1", status: 404, want: "result not found"},
+		{
+			name: "update", method: "PUT", path: "/api/results/1", role: auth.RoleCoach, body: update, setup: samsRaces, status: 200,
+			check: func(t *testing.T, st store.Store) {
+				samsTimeIs(racetime.Duration(1100000))(t, st)
+				if splits, err := st.ResultSplits(context.Background(), 1); err != nil || len(splits) != 1 {
+					t.Errorf("splits = %+v, %v; want the first mile kept when none were given", splits, err)
+				}
+			},
+		},
+		{name: "update a missing result", method: "PUT", path: "/api/results/1", role: auth.RoleCoach, body: update, status: 404},
+		{
+			name: "update when the commit fails", method: "PUT", path: "/api/results/1", role: auth.RoleCoach, body: update,
+			setup: samsRaces, fail: "Commit", status: 500, check: samsTimeIs(samsTime),
+		},
+		{
+			name: "delete", method: "DELETE", path: "/api/results/1", role: auth.RoleCoach, setup: samsRaces, status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Result(context.Background(), 1); err != sql.ErrNoRows {
+					t.Errorf("result lookup after delete = %v, want no result", err)
+				}
+			},
+		},
+		{name: "delete a missing result", method: "DELETE", path: "/api/results/1", role: auth.RoleCoach, status: 404},
+		{name: "delete when not logged in", method: "DELETE", path: "/api/results/1", status: 401},
+	})
+}
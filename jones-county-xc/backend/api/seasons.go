@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+)
+
+// ensureSeason finds a season by year, creating it (with no roster) the
+// first time an athlete is put on it. Only requests that change data may
+// call it; reads go by the seasons that exist, see latestSeason.
+func ensureSeason(ctx context.Context, q store.Queries, year int) (store.Season, error) {
+	row, err := q.Season(ctx, int32(year))
+	if err != sql.ErrNoRows {
+		return row, err
+	}
+	start, end := season.Bounds(year)
+	_, err = q.CreateSeason(ctx, store.Season{
+		Year:  int32(year),
+		Name:  fmt.Sprintf("%d Season", year),
+		Start: start,
+		End:   end,
+	})
+	if err != nil && !store.Violates(err, store.ErrDuplicate) {
+		return store.Season{}, err
+	}
+	return q.Season(ctx, int32(year))
+}
+
+// latestSeason finds the current season or, when it has not been started
+// yet, the newest one before it. It returns sql.ErrNoRows when there is
+// neither.
+func latestSeason(ctx context.Context, q store.Queries) (store.Season, error) {
+	current := season.YearOf(time.Now())
+	row, err := q.Season(ctx, int32(current))
+	if err != sql.ErrNoRows {
+		return row, err
+	}
+	rows, err := q.Seasons(ctx)
+	if err != nil {
+		return store.Season{}, err
+	}
+	for _, row := range rows {
+		if int(row.Year) < current {
+			return row, nil
+		}
+	}
+	return store.Season{}, sql.ErrNoRows
+}
+
+// emptySeason finds the season for year when nobody is on its roster, and
+// returns store.ErrDuplicate when someone is.
+func emptySeason(ctx context.Context, q store.Queries, year int) (store.Season, error) {
+	row, err := q.Season(ctx, int32(year))
+	if err != nil {
+		return store.Season{}, err
+	}
+	roster, err := q.Roster(ctx, row.ID)
+	if err != nil {
+		return store.Season{}, err
+	}
+	if len(roster) > 0 {
+		return store.Season{}, store.ErrDuplicate
+	}
+	return row, nil
+}
+
+// seasonScopeFor resolves the ?season= filter: a season year, "all", or by
+// default the latest season (everything, before any season exists). It
+// writes the error response and returns false when the filter is bad.
+func (s *Server) seasonScopeFor(w http.ResponseWriter, r *http.Request) (seasonScope, bool) {
+	all := seasonScope{
+		Year:  season.YearOf(time.Now()),
+		Start: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}
+	param := r.URL.Query().Get("season")
+	if param == "all" {
+		return all, true
+	}
+
+	var row store.Season
+	var err error
+	if param == "" {
+		row, err = latestSeason(r.Context(), s.store)
+		if err == sql.ErrNoRows {
+			return all, true
+		}
+	} else {
+		year, perr := strconv.Atoi(param)
+		if perr != nil {
+			apierror.Write(w, http.StatusBadRequest, apierror.InvalidParameter, "season must be a year or all")
+			return seasonScope{}, false
+		}
+		row, err = s.store.Season(r.Context(), int32(year))
+	}
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.SeasonNotFound, "season not found")
+		return seasonScope{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return seasonScope{}, false
+	}
+	return seasonScope{ID: row.ID, Year: int(row.Year), Start: row.Start, End: row.End}, true
+}
+
+// seasonFromPath looks up the season named by the route's {year}, writing a
+// 400 or 404 and returning false when there is none.
+func (s *Server) seasonFromPath(w http.ResponseWriter, r *http.Request) (store.Season, bool) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidID, "invalid season year")
+		return store.Season{}, false
+	}
+	row, err := s.store.Season(r.Context(), int32(year))
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.SeasonNotFound, "season not found")
+		return store.Season{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return store.Season{}, false
+	}
+	return row, true
+}
+
+// Handle GET /api/seasons — school years, newest first
+func (s *Server) listSeasons(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.store.Seasons(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	seasons := make([]Season, len(rows))
+	for i, row := range rows {
+		seasons[i] = newSeason(row)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(seasons)
+}
+
+// Handle POST /api/seasons — start a season, optionally carrying over last
+// season's roster with everyone moved up a grade
+func (s *Server) createSeason(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Year     int    `json:"year"`
+		Name     string `json:"name"`
+		RollOver bool   `json:"rollOver"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Year < 1900 || body.Year > 9998 {
+		s.writeError(w, r, apierror.Invalid("year", "year is required"))
+		return
+	}
+	named := body.Name != ""
+	if !named {
+		body.Name = fmt.Sprintf("%d Season", body.Year)
+	}
+	if len(body.Name) > 64 {
+		s.writeError(w, r, apierror.Invalid("name", "name must be at most 64 characters"))
+		return
+	}
+
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	start, end := season.Bounds(body.Year)
+	created := store.Season{Year: int32(body.Year), Name: body.Name, Start: start, End: end}
+	id, err := tx.CreateSeason(r.Context(), created)
+	if err == nil {
+		created.ID = id
+	} else if store.Violates(err, store.ErrDuplicate) && body.RollOver {
+		// A season started before anyone was put on it, as adding an
+		// athlete does, is filled in rather than refused, and takes the
+		// name given for it
+		created, err = emptySeason(r.Context(), tx, body.Year)
+		if err == nil && named && created.Name != body.Name {
+			created.Name = body.Name
+			err = tx.RenameSeason(r.Context(), created.ID, created.Name)
+		}
+	}
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "that season already exists")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	if body.RollOver {
+		prev, err := tx.Season(r.Context(), int32(body.Year-1))
+		if err != nil && err != sql.ErrNoRows {
+			s.writeError(w, r, err)
+			return
+		}
+		if err == nil {
+			roster, err := tx.Roster(r.Context(), prev.ID)
+			if err != nil {
+				s.writeError(w, r, err)
+				return
+			}
+			for _, entry := range roster {
+				grade := season.Grade(int(entry.GraduationYear), body.Year)
+				if !season.InHighSchool(grade) {
+					continue
+				}
+				if err := tx.PutOnRoster(r.Context(), store.RosterEntry{
+					SeasonID:  created.ID,
+					AthleteID: entry.AthleteID,
+					Grade:     int32(grade),
+				}); err != nil {
+					s.writeError(w, r, err)
+					return
+				}
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newSeason(created))
+}
+
+// Handle GET /api/seasons/{year}
+func (s *Server) getSeason(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.seasonFromPath(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newSeason(row))
+}
+
+// Handle PUT /api/seasons/{year}/roster/{athleteId} — put an athlete on the
+// roster, in the grade their class is in that season unless another grade
+// is given
+func (s *Server) addToRoster(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.seasonFromPath(w, r)
+	if !ok {
+		return
+	}
+	athleteID, ok := pathInt32(w, r, "athleteId", "athlete")
+	if !ok {
+		return
+	}
+	var body struct {
+		Grade int `json:"grade"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	athlete, err := s.store.Athlete(r.Context(), athleteID)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.AthleteNotFound, "athlete not found")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if body.Grade == 0 {
+		body.Grade = season.Grade(int(athlete.GraduationYear), int(row.Year))
+	}
+	if !season.InHighSchool(body.Grade) {
+		s.writeError(w, r, apierror.Invalid("grade", "grade must be 9-12"))
+		return
+	}
+	if err := s.store.PutOnRoster(r.Context(), store.RosterEntry{
+		SeasonID:  row.ID,
+		AthleteID: athlete.ID,
+		Grade:     int32(body.Grade),
+	}); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newAthlete(athlete, body.Grade))
+}
+
+// Handle DELETE /api/seasons/{year}/roster/{athleteId} — take an athlete off
+// that season's roster
+func (s *Server) removeFromRoster(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.seasonFromPath(w, r)
+	if !ok {
+		return
+	}
+	athleteID, ok := pathInt32(w, r, "athleteId", "athlete")
+	if !ok {
+		return
+	}
+	found, err := s.store.TakeOffRoster(r.Context(), row.ID, athleteID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if !found {
+		apierror.Write(w, http.StatusNotFound, apierror.AthleteNotFound, "athlete is not on that season's roster")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+)
+
+// thisSeason starts the current season, with nobody on its roster yet.
+func thisSeason(t *testing.T, st store.Store) {
+	t.Helper()
+	year := season.YearOf(time.Now())
+	start, end := season.Bounds(year)
+	if _, err := st.CreateSeason(context.Background(), store.Season{Year: int32(year), Name: "This Season", Start: start, End: end}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// lastYearsRoster starts the 2029 season with two athletes on it: athlete
+// 1, a sophomore, and athlete 2, a senior who graduates before 2030.
+func lastYearsRoster(t *testing.T, st store.Store) {
+	t.Helper()
+	ctx := context.Background()
+	start, end := season.Bounds(2029)
+	id, err := st.CreateSeason(ctx, store.Season{Year: 2029, Name: "2029 Season", Start: start, End: end})
+	inserted(t, 1, id, err)
+	for i, class := range []int{2032, 2030} {
+		id, err := st.CreateAthlete(ctx, store.Athlete{TeamID: 1, Name: fmt.Sprintf("Runner %d", i+1), GraduationYear: int32(class)})
+		inserted(t, int32(i+1), id, err)
+		if err := st.PutOnRoster(ctx, store.RosterEntry{SeasonID: 1, AthleteID: int32(i + 1), Grade: int32(season.Grade(class, 2029))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// emptySeason2030 starts the 2030 season, under its default name, before
+// anyone is put on it.
+func emptySeason2030(t *testing.T, st store.Store) {
+	t.Helper()
+	start, end := season.Bounds(2030)
+	if _, err := st.CreateSeason(context.Background(), store.Season{Year: 2030, Name: "2030 Season", Start: start, End: end}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// season2030Is checks the name and roster the 2030 season was left with.
+func season2030Is(name string, roster ...store.RosterEntry) func(t *testing.T, st store.Store) {
+	return func(t *testing.T, st store.Store) {
+		t.Helper()
+		ctx := context.Background()
+		got, err := st.Season(ctx, 2030)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != name {
+			t.Errorf("2030 season is named %q, want %q", got.Name, name)
+		}
+		rows, err := st.Roster(ctx, got.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []store.RosterEntry
+		for _, row := range rows {
+			entries = append(entries, store.RosterEntry{SeasonID: got.ID, AthleteID: row.AthleteID, Grade: row.Grade})
+		}
+		for i := range roster {
+			roster[i].SeasonID = got.ID
+		}
+		if fmt.Sprint(entries) != fmt.Sprint(roster) {
+			t.Errorf("2030 roster = %v, want %v", entries, roster)
+		}
+	}
+}
+
+func TestSeasonEndpoints(t *testing.T) {
+	year := season.YearOf(time.Now())
+	current := fmt.Sprintf("/api/seasons/%d", year)
+	rolledOver := store.RosterEntry{AthleteID: 1, Grade: 11}
+
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/seasons", setup: thisSeason, status: 200, want: `"name":"This Season"`},
+		{
+			name: "list before the current season is started", method: "GET", path: "/api/seasons",
+			status: 200, want: "[]",
+			check: func(t *testing.T, st store.Store) {
+				if seasons, err := st.Seasons(context.Background()); err != nil || len(seasons) != 0 {
+					t.Errorf("seasons = %+v, %v; listing them should not start one", seasons, err)
+				}
+			},
+		},
+		{name: "list when the store is down", method: "GET", path: "/api/seasons", fail: "Seasons", status: 500},
+		{
+			name: "create", method: "POST", path: "/api/seasons", role: auth.RoleAdmin, body: `{"year":2030}`,
+			status: 201, want: `"name":"2030 Season"`, check: season2030Is("2030 Season"),
+		},
+		{
+			name: "create rolling over the roster", method: "POST", path: "/api/seasons", role: auth.RoleAdmin,
+			body: `{"year":2030,"rollOver":true}`, setup: lastYearsRoster, status: 201,
+			check: season2030Is("2030 Season", rolledOver),
+		},
+		{
+			name: "create rolling over into an empty season", method: "POST", path: "/api/seasons", role: auth.RoleAdmin,
+			body:  `{"year":2030,"name":"Cross Country 2030","rollOver":true}`,
+			setup: seed(lastYearsRoster, emptySeason2030), status: 201, want: `"name":"Cross Country 2030"`,
+			check: season2030Is("Cross Country 2030", rolledOver),
+		},
+		{
+			name: "create rolling over into an empty season without a name", method: "POST", path: "/api/seasons", role: auth.RoleAdmin,
+			body:  `{"year":2030,"rollOver":true}`,
+			setup: seed(lastYearsRoster, emptySeason2030), status: 201, want: `"name":"2030 Season"`,
+			check: season2030Is("2030 Season", rolledOver),
+		},
+		{
+			name: "create rolling over into a season with a roster", method: "POST", path: "/api/seasons", role: auth.RoleAdmin,
+			body: `{"year":2030,"name":"Cross Country 2030","rollOver":true}`,
+			setup: func(t *testing.T, st store.Store) {
+				lastYearsRoster(t, st)
+				emptySeason2030(t, st)
+				if err := st.PutOnRoster(context.Background(), store.RosterEntry{SeasonID: 2, AthleteID: 1, Grade: 12}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			status: 409, want: "already exists",
+			check: season2030Is("2030 Season", store.RosterEntry{AthleteID: 1, Grade: 12}),
+		},
+		{name: "create without a year", method: "POST", path: "/api/seasons", role: auth.RoleAdmin, body: `{}`, status: 400, want: "year is required"},
+		{name: "create as a coach", method: "POST", path: "/api/seasons", role: auth.RoleCoach, body: `{"year":2030}`, status: 403},
+		{
+			name: "create an existing season", method: "POST", path: "/api/seasons", role: auth.RoleAdmin, body: `{"year":2030}`,
+			setup: emptySeason2030, status: 409, want: "already exists",
+		},
+		{name: "get", method: "GET", path: current, setup: thisSeason, status: 200, want: `"name":"This Season"`},
+		{name: "get a missing season", method: "GET", path: "/api/seasons/1999", status: 404, want: "season not found"},
+		{name: "get with a bad year", method: "GET", path: "/api/seasons/last", status: 400, want: "invalid season year"},
+		{
+			name: "add to the roster", method: "PUT", path: current + "/roster/1", role: auth.RoleAdmin,
+			body: `{"grade":12}`, setup: seed(sam, thisSeason), status: 200, want: `"grade":12`,
+		},
+		{
+			name: "add to the roster in their class's grade", method: "PUT", path: current + "/roster/1", role: auth.RoleAdmin,
+			setup: seed(sam, thisSeason), status: 200, want: `"grade":11`,
+		},
+		{
+			name: "add to the roster in a bad grade", method: "PUT", path: current + "/roster/1", role: auth.RoleAdmin,
+			body: `{"grade":3}`, setup: seed(sam, thisSeason), status: 400, want: "grade must be 9-12",
+		},
+		{
+			name: "add a missing athlete to the roster", method: "PUT", path: current + "/roster/1", role: auth.RoleAdmin,
+			setup: thisSeason, status: 404, want: "athlete not found",
+		},
+		{
+			name: "add to the roster with a bad athlete id", method: "PUT", path: current + "/roster/x", role: auth.RoleAdmin,
+			setup: thisSeason, status: 400, want: "invalid athlete id",
+		},
+		{
+			name: "remove from the roster", method: "DELETE", path: current + "/roster/1", role: auth.RoleAdmin,
+			setup: seed(thisSeason, sam), status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if roster, err := st.Roster(context.Background(), 1); err != nil || len(roster) != 0 {
+					t.Errorf("roster = %+v, %v; want nobody on it", roster, err)
+				}
+			},
+		},
+		{
+			name: "remove someone not on the roster", method: "DELETE", path: current + "/roster/1", role: auth.RoleAdmin,
+			setup: seed(sam, thisSeason), status: 404, want: `"code":"athlete_not_found"`,
+		},
+		{name: "remove from the roster of a missing season", method: "DELETE", path: "/api/seasons/1999/roster/1", role: auth.RoleAdmin, status: 404},
+		{
+			name: "remove from the roster when the store is down", method: "DELETE", path: current + "/roster/1", role: auth.RoleAdmin,
+			setup: seed(thisSeason, sam), fail: "TakeOffRoster", status: 500,
+		},
+	})
+}
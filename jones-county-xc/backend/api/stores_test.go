@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/season"
+	"jones-county-xc/backend/store"
+	"jones-county-xc/backend/store/storetest"
+)
+
+// storeClient sends requests to a server on a real store, logged in as an
+// admin.
+type storeClient struct {
+	t       *testing.T
+	handler http.Handler
+	cookie  *http.Cookie
+}
+
+func newStoreClient(t *testing.T, st store.Store) *storeClient {
+	t.Helper()
+	hash, err := auth.HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.CreateUser(context.Background(), store.User{Username: "admin", PasswordHash: hash, Role: string(auth.RoleAdmin)}); err != nil {
+		t.Fatal(err)
+	}
+	c := &storeClient{t: t, handler: newTestServer(st)}
+	rec := c.send("POST", "/api/auth/login", `{"username":"admin","password":"correct horse"}`, http.StatusOK)
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == auth.CookieName {
+			c.cookie = cookie
+		}
+	}
+	if c.cookie == nil {
+		t.Fatal("login set no session cookie")
+	}
+	return c
+}
+
+func (c *storeClient) send(method, path, body string, status int) *httptest.ResponseRecorder {
+	c.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if c.cookie != nil {
+		req.AddCookie(c.cookie)
+	}
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+	if rec.Code != status {
+		c.t.Fatalf("%s %s: status = %d, want %d; body %q", method, path, rec.Code, status, rec.Body.String())
+	}
+	return rec
+}
+
+// create sends a POST that should succeed with 201 and returns the new id.
+func (c *storeClient) create(path, body string) int {
+	c.t.Helper()
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(c.send("POST", path, body, http.StatusCreated).Body.Bytes(), &created); err != nil {
+		c.t.Fatal(err)
+	}
+	return created.ID
+}
+
+// get sends a GET that should succeed and checks the body contains each of
+// want.
+func (c *storeClient) get(path string, want ...string) {
+	c.t.Helper()
+	body := c.send("GET", path, "", http.StatusOK).Body.String()
+	for _, w := range want {
+		if !strings.Contains(body, w) {
+			c.t.Errorf("GET %s: body %q does not contain %q", path, body, w)
+		}
+	}
+}
+
+// TestStores runs a season's worth of requests through the handlers on
+// each real store. The other tests' tables run on the real stores only
+// when they need nothing in them, so this is what shows that the queries
+// behave with data to find.
+func TestStores(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, newStore func(t *testing.T) store.Store) {
+		c := newStoreClient(t, newStore(t))
+		today := time.Now().Format("2006-01-02")
+		senior := season.YearOf(time.Now()) + 1
+
+		course := c.create("/api/courses", `{"name":"City Park","distanceMeters":5000}`)
+		meet := c.create("/api/meets", fmt.Sprintf(`{"name":"County Invitational","date":%q,"location":"Gray","courseId":%d}`, today, course))
+		race := c.create(fmt.Sprintf("/api/meets/%d/races", meet), `{"gender":"girls","division":"varsity","startTime":"09:30"}`)
+		rival := c.create("/api/teams", `{"name":"Rival High"}`)
+		c.send("POST", "/api/teams", `{"name":"rival high"}`, http.StatusConflict)
+		athlete := c.create("/api/athletes", fmt.Sprintf(`{"name":"Sam Runner","graduationYear":%d,"personalRecord":"19:00"}`, senior))
+
+		c.send("POST", fmt.Sprintf("/api/races/%d/results", race), fmt.Sprintf(`{"results":[
+			{"athleteId":%d,"time":"18:30","place":1},
+			{"runnerName":"Riley Rival","teamId":%d,"time":"18:45","place":2}
+		]}`, athlete, rival), http.StatusCreated)
+
+		c.get(fmt.Sprintf("/api/races/%d/results", race), "Sam Runner", "Riley Rival")
+		c.get(fmt.Sprintf("/api/athletes/%d", athlete), `"personalRecord":"18:30"`, `"courseName":"City Park"`)
+		c.get(fmt.Sprintf("/api/athletes/%d/progression", athlete), "County Invitational")
+		c.get("/api/results/latest", "Sam Runner")
+		c.get("/api/results/fastest", "Sam Runner")
+		c.get(fmt.Sprintf("/api/courses/%d/records", course), "Sam Runner")
+		c.get("/api/meets", "County Invitational")
+
+		c.send("DELETE", fmt.Sprintf("/api/teams/%d", rival), "", http.StatusConflict)
+		c.send("DELETE", fmt.Sprintf("/api/courses/%d", course), "", http.StatusConflict)
+		c.send("DELETE", fmt.Sprintf("/api/races/%d", race), "", http.StatusConflict)
+	})
+}
+
+// A meet's first result creates its race, in the same transaction as the
+// result, so a result that cannot be saved leaves no race behind.
+func TestFailedResultLeavesNoRace(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, newStore func(t *testing.T) store.Store) {
+		c := newStoreClient(t, newStore(t))
+		meet := c.create("/api/meets", fmt.Sprintf(`{"name":"Dual","date":%q,"location":"Gray"}`, time.Now().Format("2006-01-02")))
+
+		c.send("POST", "/api/results", fmt.Sprintf(`{"meetId":%d,"athleteId":999,"time":"18:30","place":1}`, meet), http.StatusBadRequest)
+		c.send("POST", fmt.Sprintf("/api/results/meet/%d", meet), `{"results":[{"athleteId":999,"time":"18:30","place":1}]}`, http.StatusBadRequest)
+		if body := c.send("GET", fmt.Sprintf("/api/meets/%d/races", meet), "", http.StatusOK).Body.String(); body != "[]\n" {
+			t.Errorf("races after failed results = %s, want none", body)
+		}
+	})
+}
+
+// TestReadsLeaveSeasonsAlone checks that reading lists does not start the
+// current season, which would leave it to be started without its roster.
+func TestReadsLeaveSeasonsAlone(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, newStore func(t *testing.T) store.Store) {
+		c := newStoreClient(t, newStore(t))
+		current := season.YearOf(time.Now())
+		c.create("/api/seasons", fmt.Sprintf(`{"year":%d}`, current-1))
+
+		c.get("/api/athletes")
+		c.get("/api/meets")
+		c.get("/api/seasons", fmt.Sprintf(`"year":%d`, current-1))
+		if body := c.send("GET", "/api/seasons", "", http.StatusOK).Body.String(); strings.Contains(body, fmt.Sprintf(`"year":%d`, current)) {
+			t.Errorf("GET /api/seasons = %s; reads started the current season", body)
+		}
+		c.create("/api/seasons", fmt.Sprintf(`{"year":%d,"rollOver":true}`, current))
+	})
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/store"
+)
+
+// teamOrHome resolves an optional teamId from a request or query string,
+// defaulting to our own school.
+func (s *Server) teamOrHome(ctx context.Context, teamID int) (int32, error) {
+	if teamID == 0 {
+		home, err := s.store.HomeTeam(ctx)
+		return home.ID, err
+	}
+	team, err := s.store.Team(ctx, int32(teamID))
+	return team.ID, err
+}
+
+// teamFromPath looks up the team named by the route's {id}, writing a 400
+// or 404 and returning false when there is none.
+func (s *Server) teamFromPath(w http.ResponseWriter, r *http.Request) (store.Team, bool) {
+	id, ok := pathID(w, r, "team")
+	if !ok {
+		return store.Team{}, false
+	}
+	row, err := s.store.Team(r.Context(), id)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, http.StatusNotFound, apierror.TeamNotFound, "team not found")
+		return store.Team{}, false
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return store.Team{}, false
+	}
+	return row, true
+}
+
+// Handle GET /api/teams — our school and the opponents we race against
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.store.Teams(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	teams := make([]Team, len(rows))
+	for i, row := range rows {
+		teams[i] = Team{ID: int(row.ID), Name: row.Name, ShortName: row.ShortName, IsHome: row.IsHome}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(teams)
+}
+
+// Handle POST /api/teams — add an opponent school
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	var body teamRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	if err := body.validate(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	id, err := s.store.CreateTeam(r.Context(), store.Team{Name: body.Name, ShortName: body.ShortName})
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "a team with that name already exists")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(Team{ID: int(id), Name: body.Name, ShortName: body.ShortName})
+}
+
+// Handle GET /api/teams/{id}
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.teamFromPath(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Team{ID: int(row.ID), Name: row.Name, ShortName: row.ShortName, IsHome: row.IsHome})
+}
+
+// Handle PUT /api/teams/{id} — rename a team
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.teamFromPath(w, r)
+	if !ok {
+		return
+	}
+	var body teamRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	if err := body.validate(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	err := s.store.UpdateTeam(r.Context(), store.Team{ID: row.ID, Name: body.Name, ShortName: body.ShortName})
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "a team with that name already exists")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Team{ID: int(row.ID), Name: body.Name, ShortName: body.ShortName, IsHome: row.IsHome})
+}
+
+// Handle DELETE /api/teams/{id} — only teams nobody refers to
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	row, ok := s.teamFromPath(w, r)
+	if !ok {
+		return
+	}
+	if row.IsHome {
+		apierror.Write(w, http.StatusConflict, apierror.Conflict, "the home team cannot be deleted")
+		return
+	}
+	used, err := s.store.TeamInUse(r.Context(), row.ID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if used {
+		apierror.Write(w, http.StatusConflict, apierror.InUse, "team still has athletes or results")
+		return
+	}
+	if err := s.store.DeleteTeam(r.Context(), row.ID); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
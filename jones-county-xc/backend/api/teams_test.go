@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/store"
+)
+
+// rivals adds Lamar County as team 2, after the home team.
+func rivals(t *testing.T, st store.Store) {
+	t.Helper()
+	id, err := st.CreateTeam(context.Background(), store.Team{
+		Name: "Lamar County", ShortName: "Lamar",
+	})
+	inserted(t, 2, id, err)
+}
+
+func TestTeamEndpoints(t *testing.T) {
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/teams", status: 200, want: `"isHome":true`},
+		{name: "list when the store is down", method: "GET", path: "/api/teams", fail: "Teams", status: 500},
+		{
+			name: "create", method: "POST", path: "/api/teams", role: auth.RoleCoach,
+			body: `{"name":"Lamar County","shortName":"Lamar"}`, status: 201, want: `"name":"Lamar County"`,
+			check: func(t *testing.T, st store.Store) {
+				if got, err := st.Team(context.Background(), 2); err != nil || got.ShortName != "Lamar" {
+					t.Errorf("team 2 = %+v, %v; want Lamar County", got, err)
+				}
+			},
+		},
+		{name: "create when not logged in", method: "POST", path: "/api/teams", body: `{"name":"Lamar County"}`, status: 401},
+		{name: "create as a parent", method: "POST", path: "/api/teams", role: auth.RoleParent, body: `{"name":"Lamar County"}`, status: 403},
+		{name: "create with bad JSON", method: "POST", path: "/api/teams", role: auth.RoleCoach, body: `[`, status: 400, want: "invalid JSON"},
+		{name: "create without a name", method: "POST", path: "/api/teams", role: auth.RoleCoach, body: `{}`, status: 400, want: "name is required"},
+		{name: "create a duplicate", method: "POST", path: "/api/teams", role: auth.RoleCoach, body: `{"name":"Lamar County"}`, setup: rivals, status: 409, want: "already exists"},
+		{name: "get", method: "GET", path: "/api/teams/2", setup: rivals, status: 200, want: `"shortName":"Lamar"`},
+		{name: "get a missing team", method: "GET", path: "/api/teams/2", status: 404, want: "team not found"},
+		{name: "get with a bad id", method: "GET", path: "/api/teams/two", status: 400, want: "invalid team id"},
+		{name: "get when the store is down", method: "GET", path: "/api/teams/2", setup: rivals, fail: "Team", status: 500},
+		{
+			name: "rename", method: "PUT", path: "/api/teams/2", role: auth.RoleAdmin, body: `{"name":"Lamar County High"}`,
+			setup: rivals, status: 200, want: `"name":"Lamar County High"`,
+			check: func(t *testing.T, st store.Store) {
+				if got, err := st.Team(context.Background(), 2); err != nil || got.Name != "Lamar County High" {
+					t.Errorf("team 2 = %+v, %v; want it renamed", got, err)
+				}
+			},
+		},
+		{name: "rename a missing team", method: "PUT", path: "/api/teams/2", role: auth.RoleAdmin, body: `{"name":"X"}`, status: 404},
+		{name: "rename to nothing", method: "PUT", path: "/api/teams/2", role: auth.RoleAdmin, body: `{"name":""}`, setup: rivals, status: 400},
+		{name: "rename to a taken name", method: "PUT", path: "/api/teams/2", role: auth.RoleAdmin, body: `{"name":"Jones County"}`, setup: rivals, status: 409},
+		{
+			name: "delete", method: "DELETE", path: "/api/teams/2", role: auth.RoleAdmin, setup: rivals, status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.Team(context.Background(), 2); err != sql.ErrNoRows {
+					t.Errorf("team lookup after delete = %v, want no team", err)
+				}
+			},
+		},
+		{name: "delete the home team", method: "DELETE", path: "/api/teams/1", role: auth.RoleAdmin, status: 409, want: "home team"},
+		{
+			name: "delete a team still in use", method: "DELETE", path: "/api/teams/2", role: auth.RoleAdmin,
+			setup: func(t *testing.T, st store.Store) {
+				rivals(t, st)
+				id, err := st.CreateAthlete(context.Background(), store.Athlete{TeamID: 2, Name: "Lamar Runner", GraduationYear: int32(juniors)})
+				inserted(t, 1, id, err)
+			},
+			status: 409, want: "still has athletes or results",
+		},
+	})
+}
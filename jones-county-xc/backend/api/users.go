@@ -0,0 +1,201 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"jones-county-xc/backend/apierror"
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/store"
+)
+
+// Handle POST /api/auth/login — check username/password and start a session
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+
+	row, err := s.store.UserByUsername(r.Context(), strings.TrimSpace(body.Username))
+	if err != nil && err != sql.ErrNoRows {
+		s.writeError(w, r, err)
+		return
+	}
+	if !auth.CheckPassword(row.PasswordHash, body.Password) {
+		apierror.Write(w, http.StatusUnauthorized, apierror.InvalidCredentials, "invalid username or password")
+		return
+	}
+
+	token, hash, err := auth.NewSessionToken()
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	now := time.Now()
+	expires := now.Add(auth.SessionTTL)
+	if err := s.store.DeleteExpiredSessions(r.Context(), now); err != nil {
+		s.requestLogger(r).Warn("failed to prune expired sessions", "error", err)
+	}
+	if err := s.store.CreateSession(r.Context(), hash, row.ID, expires); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	auth.SetSessionCookie(w, r, token, expires)
+
+	user, err := s.loadUser(r.Context(), row)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// Handle POST /api/auth/logout — end the current session
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	if token, ok := auth.SessionToken(r); ok {
+		if err := s.store.DeleteSession(r.Context(), auth.HashToken(token)); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	auth.ClearSessionCookie(w, r)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "logged out"})
+}
+
+// Handle GET /api/auth/me — who is logged in on this browser
+func (s *Server) me(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		apierror.Write(w, http.StatusUnauthorized, apierror.LoginRequired, "not logged in")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// Handle GET /api/users — every account (head coach only)
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.store.Users(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	users := make([]auth.User, len(rows))
+	for i, row := range rows {
+		users[i], err = s.loadUser(r.Context(), row)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(users)
+}
+
+// Handle POST /api/users — create an account, linking athlete and parent
+// accounts to their athletes
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Username   string `json:"username"`
+		Password   string `json:"password"`
+		Role       string `json:"role"`
+		AthleteIDs []int  `json:"athleteIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		apierror.Write(w, http.StatusBadRequest, apierror.InvalidJSON, "invalid JSON")
+		return
+	}
+	body.Username = strings.TrimSpace(body.Username)
+	role := auth.Role(body.Role)
+	if body.Username == "" || len(body.Username) > 64 {
+		s.writeError(w, r, apierror.Invalid("username", "username is required and must be at most 64 characters"))
+		return
+	}
+	if !role.Valid() {
+		s.writeError(w, r, apierror.Invalid("role", "role must be admin, coach, athlete or parent"))
+		return
+	}
+	hash, err := auth.HashPassword(body.Password)
+	if err != nil {
+		s.writeError(w, r, apierror.Invalid("password", err.Error()))
+		return
+	}
+
+	tx, err := s.store.BeginTx(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+
+	created := store.User{Username: body.Username, PasswordHash: hash, Role: string(role)}
+	created.ID, err = tx.CreateUser(r.Context(), created)
+	if store.Violates(err, store.ErrDuplicate) {
+		apierror.Write(w, http.StatusConflict, apierror.Duplicate, "username is already taken")
+		return
+	}
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	for _, athleteID := range body.AthleteIDs {
+		err := tx.LinkAthlete(r.Context(), created.ID, int32(athleteID))
+		if store.Violates(err, store.ErrNoReferencedRow) {
+			s.writeError(w, r, apierror.Invalid("athleteIds", fmt.Sprintf("unknown athlete id %d", athleteID)))
+			return
+		}
+		if store.Violates(err, store.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+
+	user, err := s.loadUser(r.Context(), created)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+// Handle DELETE /api/users/{id} — remove someone else's account
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "user")
+	if !ok {
+		return
+	}
+	if current, _ := auth.UserFromContext(r.Context()); current.ID == int(id) {
+		apierror.Write(w, http.StatusConflict, apierror.Conflict, "you cannot delete your own account")
+		return
+	}
+	found, err := s.store.DeleteUser(r.Context(), id)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if !found {
+		apierror.Write(w, http.StatusNotFound, apierror.UserNotFound, "user not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/store"
+)
+
+func TestAuthEndpoints(t *testing.T) {
+	hash, err := auth.HashPassword("correct horse battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// coach is the login the tests sign in with, as user 1
+	coach := func(t *testing.T, st store.Store) {
+		t.Helper()
+		id, err := st.CreateUser(context.Background(), store.User{Username: "coach", PasswordHash: hash, Role: string(auth.RoleCoach)})
+		inserted(t, 1, id, err)
+	}
+
+	runAPITests(t, []apiTest{
+		{
+			name: "login", method: "POST", path: "/api/auth/login",
+			body:  `{"username":" coach ","password":"correct horse battery"}`,
+			setup: coach, status: 200, want: `"username":"coach"`,
+		},
+		{
+			name: "login with wrong password", method: "POST", path: "/api/auth/login",
+			body:  `{"username":"coach","password":"wrong"}`,
+			setup: coach, status: 401, want: "invalid username or password",
+		},
+		{
+			name: "login as nobody", method: "POST", path: "/api/auth/login",
+			body: `{"username":"nobody","password":"x"}`, status: 401, want: "invalid username or password",
+		},
+		{name: "login with bad JSON", method: "POST", path: "/api/auth/login", body: `{`, status: 400, want: "invalid JSON"},
+		{
+			name: "login when the store is down", method: "POST", path: "/api/auth/login",
+			body: `{"username":"coach","password":"x"}`, fail: "UserByUsername", status: 500,
+		},
+		{
+			name: "login when the session cannot be saved", method: "POST", path: "/api/auth/login",
+			body:  `{"username":"coach","password":"correct horse battery"}`,
+			setup: coach, fail: "CreateSession", status: 500,
+		},
+		{name: "logout", method: "POST", path: "/api/auth/logout", role: auth.RoleCoach, status: 200, want: "logged out"},
+		{name: "logout when not logged in", method: "POST", path: "/api/auth/logout", status: 200, want: "logged out"},
+		{name: "logout when the store is down", method: "POST", path: "/api/auth/logout", role: auth.RoleCoach, fail: "DeleteSession", status: 500},
+		{name: "me", method: "GET", path: "/api/auth/me", role: auth.RoleParent, status: 200, want: `"athleteIds":[1]`},
+		{name: "me when not logged in", method: "GET", path: "/api/auth/me", status: 401, want: "not logged in"},
+	})
+}
+
+func TestUserEndpoints(t *testing.T) {
+	// head is an admin other than the one making the request, as user 1
+	head := func(t *testing.T, st store.Store) {
+		t.Helper()
+		id, err := st.CreateUser(context.Background(), store.User{Username: "head", PasswordHash: "-", Role: string(auth.RoleAdmin)})
+		inserted(t, 1, id, err)
+	}
+
+	runAPITests(t, []apiTest{
+		{name: "list", method: "GET", path: "/api/users", role: auth.RoleAdmin, setup: head, status: 200, want: `"username":"head"`},
+		{name: "list when not logged in", method: "GET", path: "/api/users", status: 401},
+		{name: "list as a coach", method: "GET", path: "/api/users", role: auth.RoleCoach, status: 403},
+		{name: "list when the store is down", method: "GET", path: "/api/users", role: auth.RoleAdmin, fail: "Users", status: 500},
+		{
+			name: "create", method: "POST", path: "/api/users", role: auth.RoleAdmin,
+			body:  `{"username":"mom","password":"long enough password","role":"parent","athleteIds":[1]}`,
+			setup: sam, status: 201, want: `"username":"mom"`,
+			check: func(t *testing.T, st store.Store) {
+				ctx := context.Background()
+				mom, err := st.UserByUsername(ctx, "mom")
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids, err := st.LinkedAthleteIDs(ctx, mom.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !slices.Equal(ids, []int32{1}) {
+					t.Errorf("mom sees athletes %v, want [1]", ids)
+				}
+			},
+		},
+		{
+			name: "create with a bad role", method: "POST", path: "/api/users", role: auth.RoleAdmin,
+			body: `{"username":"x","password":"long enough password","role":"owner"}`, status: 400, want: "role must be",
+		},
+		{
+			name: "create without a username", method: "POST", path: "/api/users", role: auth.RoleAdmin,
+			body: `{"password":"long enough password","role":"coach"}`, status: 400, want: "username is required",
+		},
+		{
+			name: "create with a short password", method: "POST", path: "/api/users", role: auth.RoleAdmin,
+			body: `{"username":"x","password":"short","role":"coach"}`, status: 400,
+		},
+		{
+			name: "create a taken username", method: "POST", path: "/api/users", role: auth.RoleAdmin,
+			body:  `{"username":"head","password":"long enough password","role":"coach"}`,
+			setup: head, status: 409, want: "already taken",
+		},
+		{
+			name: "create linked to an unknown athlete", method: "POST", path: "/api/users", role: auth.RoleAdmin,
+			body:   `{"username":"x","password":"long enough password","role":"parent","athleteIds":[99]}`,
+			status: 400, want: "unknown athlete id 99",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.UserByUsername(context.Background(), "x"); err == nil {
+					t.Error("the user was kept though the link failed")
+				}
+			},
+		},
+		{
+			name: "create as a coach", method: "POST", path: "/api/users", role: auth.RoleCoach,
+			body: `{"username":"x","password":"long enough password","role":"coach"}`, status: 403,
+		},
+		{
+			name: "delete", method: "DELETE", path: "/api/users/1", role: auth.RoleAdmin, setup: head, status: 200, want: "deleted",
+			check: func(t *testing.T, st store.Store) {
+				if _, err := st.UserByUsername(context.Background(), "head"); err == nil {
+					t.Error("user 1 is still there")
+				}
+			},
+		},
+		{name: "delete a missing user", method: "DELETE", path: "/api/users/99", role: auth.RoleAdmin, status: 404, want: "user_not_found"},
+		{name: "delete yourself", method: "DELETE", path: "/api/users/1", role: auth.RoleAdmin, status: 409, want: "your own account"},
+		{name: "delete with a bad id", method: "DELETE", path: "/api/users/abc", role: auth.RoleAdmin, status: 400, want: "invalid user id"},
+		{name: "delete when the store is down", method: "DELETE", path: "/api/users/1", role: auth.RoleAdmin, setup: head, fail: "DeleteUser", status: 500},
+	})
+}
@@ -0,0 +1,133 @@
+// Package apierror is the JSON body of every API error response:
+//
+//	{"error": {"code": "athlete_not_found", "message": "athlete not found"}}
+//
+// The code is one of the constants below and is what the frontend should
+// switch on; the message is for people and may change. A validation_failed
+// error also carries fields, a message per invalid field of the request
+// body keyed by its JSON path, such as "name" or "results[2].time".
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// The error codes, by the status they are sent with. A code keeps its
+// meaning once released; add a new one rather than change it.
+const (
+	// 400: the request itself is wrong.
+	InvalidJSON      = "invalid_json"      // the body is not JSON of the right shape
+	InvalidID        = "invalid_id"        // an id in the path or query is not a number
+	InvalidParameter = "invalid_parameter" // a query parameter is missing or wrong
+	ValidationFailed = "validation_failed" // fields in the body are wrong; see Fields
+
+	// 401 and 403.
+	LoginRequired      = "login_required"
+	InvalidCredentials = "invalid_credentials"
+	Forbidden          = "forbidden" // the account's role does not allow it
+
+	// 404: the thing the path names does not exist.
+	NotFound         = "not_found"
+	AthleteNotFound  = "athlete_not_found"
+	CourseNotFound   = "course_not_found"
+	MeetNotFound     = "meet_not_found"
+	RaceNotFound     = "race_not_found"
+	ResultNotFound   = "result_not_found"
+	SeasonNotFound   = "season_not_found"
+	TeamNotFound     = "team_not_found"
+	UserNotFound     = "user_not_found"
+	ReferentNotFound = "referent_not_found" // something the request refers to was deleted meanwhile
+
+	// 405: the path does not take that method; see the Allow header.
+	MethodNotAllowed = "method_not_allowed"
+
+	// 409: the request clashes with what is already stored.
+	Duplicate = "duplicate" // it would repeat something unique, such as a name
+	InUse     = "in_use"    // other records still refer to what is being deleted
+	Conflict  = "conflict"  // some other rule forbids it
+
+	// 5xx: the server's fault. The message never includes the cause,
+	// which is logged instead.
+	Internal = "internal_error"
+	Timeout  = "timeout" // the database took too long; try again
+)
+
+// Error is an error response.
+type Error struct {
+	Status  int               `json:"-"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// New returns an error response with the given status, code and message.
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Write sends an error response, as http.Error does a plain-text one.
+func Write(w http.ResponseWriter, status int, code, message string) {
+	WriteError(w, New(status, code, message))
+}
+
+// WriteError sends e.
+func WriteError(w http.ResponseWriter, e *Error) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(e.Status)
+	json.NewEncoder(w).Encode(struct {
+		Error *Error `json:"error"`
+	}{e})
+}
+
+// Fields collects a message per invalid field of a request body. Each
+// message names its field, so it reads on its own.
+type Fields map[string]string
+
+// Invalid returns Fields holding one invalid field.
+func Invalid(field, message string) Fields {
+	return Fields{field: message}
+}
+
+// Add records a problem with field, keeping the first one found for it.
+func (f Fields) Add(field, message string) {
+	if _, ok := f[field]; !ok {
+		f[field] = message
+	}
+}
+
+// Err returns f as an error, or nil when no field is invalid.
+func (f Fields) Err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return f
+}
+
+// Error joins the messages, ordered by field.
+func (f Fields) Error() string {
+	fields := make([]string, 0, len(f))
+	for field := range f {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = f[field]
+	}
+	return strings.Join(messages, "; ")
+}
+
+// APIError is the 400 validation_failed response for f.
+func (f Fields) APIError() *Error {
+	return &Error{Status: http.StatusBadRequest, Code: ValidationFailed, Message: f.Error(), Fields: f}
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"net/http"
+	"slices"
+
+	"jones-county-xc/backend/apierror"
+)
+
+// Role is what kind of account a user has.
+type Role string
+
+const (
+	// RoleAdmin is the head coach: manages the roster, schedule, results
+	// and user accounts.
+	RoleAdmin Role = "admin"
+	// RoleCoach is an assistant coach who enters and corrects results.
+	RoleCoach Role = "coach"
+	// RoleAthlete is a runner who can see their own history.
+	RoleAthlete Role = "athlete"
+	// RoleParent can see the history of their linked children.
+	RoleParent Role = "parent"
+)
+
+// Roles lists every role, most to least privileged.
+var Roles = []Role{RoleAdmin, RoleCoach, RoleAthlete, RoleParent}
+
+// Permission is a single capability a handler can require.
+type Permission string
+
+const (
+	PermManageUsers    Permission = "users:manage"
+	PermManageAthletes Permission = "athletes:manage"
+	PermManageMeets    Permission = "meets:manage"
+	PermManageTeams    Permission = "teams:manage"
+	PermEnterResults   Permission = "results:write"
+	// PermViewHistory lets a user read race history at all; athletes and
+	// parents are further limited to their linked athletes.
+	PermViewHistory Permission = "history:view"
+	// PermViewAnyAthlete lifts the linked-athlete limit.
+	PermViewAnyAthlete Permission = "athletes:view-any"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermManageUsers,
+		PermManageAthletes,
+		PermManageMeets,
+		PermManageTeams,
+		PermEnterResults,
+		PermViewHistory,
+		PermViewAnyAthlete,
+	},
+	RoleCoach: {
+		// Assistant coaches add opponent schools while entering results
+		PermManageTeams,
+		PermEnterResults,
+		PermViewHistory,
+		PermViewAnyAthlete,
+	},
+	RoleAthlete: {
+		PermViewHistory,
+	},
+	RoleParent: {
+		PermViewHistory,
+	},
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can reports whether r grants p.
+func (r Role) Can(p Permission) bool {
+	return slices.Contains(rolePermissions[r], p)
+}
+
+// Can reports whether the user's role grants p.
+func (u User) Can(p Permission) bool {
+	return u.Role.Can(p)
+}
+
+// CanViewAthlete reports whether u may see the given athlete's data: staff
+// can see anyone, athletes and parents only their linked athletes.
+func (u User) CanViewAthlete(athleteID int) bool {
+	if !u.Can(PermViewHistory) {
+		return false
+	}
+	return u.Can(PermViewAnyAthlete) || slices.Contains(u.AthleteIDs, athleteID)
+}
+
+// Require wraps next so that it needs a logged-in user whose role grants
+// perm. It answers 401 when nobody is logged in and 403 when the role falls
+// short. The user must already be on the context; see WithUser.
+func Require(perm Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFromContext(r.Context())
+		if !ok {
+			apierror.Write(w, http.StatusUnauthorized, apierror.LoginRequired, "login required")
+			return
+		}
+		if !user.Can(perm) {
+			apierror.Write(w, http.StatusForbidden, apierror.Forbidden, "your account does not have permission to do that")
+			return
+		}
+
+		next(w, r)
+	}
+}
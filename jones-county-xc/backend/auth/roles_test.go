@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequire(t *testing.T) {
+	handler := Require(PermEnterResults, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("POST", "/api/results", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	for role, want := range map[Role]int{
+		RoleAdmin:   http.StatusOK,
+		RoleCoach:   http.StatusOK,
+		RoleAthlete: http.StatusForbidden,
+		RoleParent:  http.StatusForbidden,
+	} {
+		req := httptest.NewRequest("POST", "/api/results", nil)
+		req = req.WithContext(WithUser(req.Context(), User{ID: 1, Username: "u", Role: role}))
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		if rec.Code != want {
+			t.Errorf("%s status = %d, want %d", role, rec.Code, want)
+		}
+	}
+}
+
+func TestCanViewAthlete(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		id   int
+		want bool
+	}{
+		{"admin any athlete", User{Role: RoleAdmin}, 7, true},
+		{"coach any athlete", User{Role: RoleCoach}, 7, true},
+		{"athlete self", User{Role: RoleAthlete, AthleteIDs: []int{7}}, 7, true},
+		{"athlete someone else", User{Role: RoleAthlete, AthleteIDs: []int{7}}, 8, false},
+		{"parent own child", User{Role: RoleParent, AthleteIDs: []int{3, 7}}, 3, true},
+		{"parent other child", User{Role: RoleParent, AthleteIDs: []int{3, 7}}, 4, false},
+		{"unknown role", User{Role: "visitor", AthleteIDs: []int{7}}, 7, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.CanViewAthlete(tt.id); got != tt.want {
+				t.Errorf("CanViewAthlete(%d) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// Package config loads the server's settings. Each setting has one name,
+// such as DB_HOST, used as its environment variable and as its key in the
+// optional config file, and a flag named like it in lower case, -db-host.
+// A flag beats the environment, which beats the config file, which beats
+// the default.
+//
+// The config file, named by -config or CONFIG_FILE, holds KEY=value lines
+// in the format of a systemd EnvironmentFile, with # comments.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Config is the server's settings, loaded and checked by Load.
+type Config struct {
+	// Addr is the address to listen on, from HOST and PORT.
+	Addr string
+	// DatabaseURL names the database for store.Open. It is DATABASE_URL
+	// when set, and otherwise a MySQL DSN built from the DB_ settings.
+	DatabaseURL string
+	// AllowedOrigins are the cross-origin sites, the frontend, allowed to
+	// call the API with the session cookie.
+	AllowedOrigins []string
+
+	// ReadTimeout, WriteTimeout and IdleTimeout bound how long a client
+	// may take to send a request, the server to write its response, and a
+	// kept-alive connection to sit idle.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// RequestTimeout is the deadline on each request's context, and so on
+	// the queries it runs.
+	RequestTimeout time.Duration
+	// ShutdownTimeout is how long a stopping server waits for requests in
+	// flight to finish before dropping them.
+	ShutdownTimeout time.Duration
+
+	// Migrate applies pending database migrations before serving.
+	Migrate bool
+
+	// LogFormat is "text" for logfmt lines or "json" for one JSON object a
+	// line, which journald and log shippers can pick fields out of.
+	LogFormat string
+	// LogLevel is the least severe level logged.
+	LogLevel slog.Level
+	// AccessLogFormat is how each request's access log line is written, or
+	// AccessLogOff for none. It is LogFormat's unless set.
+	AccessLogFormat AccessLogFormat
+
+	// Metrics serves Prometheus metrics at /metrics.
+	Metrics bool
+	// DemoSeed gives the in-memory store an admin login, whose password
+	// is logged, so that there is someone to sign in as.
+	DemoSeed bool
+}
+
+// AccessLogFormat is how the access log is written.
+type AccessLogFormat string
+
+const (
+	AccessLogText AccessLogFormat = "text"
+	AccessLogJSON AccessLogFormat = "json"
+	AccessLogOff  AccessLogFormat = "off"
+)
+
+type kind int
+
+const (
+	text kind = iota
+	boolean
+	duration
+)
+
+type setting struct {
+	name  string
+	kind  kind
+	def   string
+	usage string
+}
+
+var settings = []setting{
+	{"HOST", text, "", "interface to listen on; empty for all of them"},
+	{"PORT", text, "8080", "port to listen on"},
+	{"DATABASE_URL", text, "", "database to use, such as sqlite:xc.db or memory:; see store.Open. Set this or the DB_ settings, not both"},
+	{"DB_HOST", text, "127.0.0.1", "MySQL host, when DATABASE_URL is not set"},
+	{"DB_PORT", text, "3306", "MySQL port"},
+	{"DB_USER", text, "root", "MySQL user"},
+	{"DB_PASS", text, "", "MySQL password"},
+	{"DB_NAME", text, "jones_county_xc", "MySQL database name"},
+	{"CORS_ORIGINS", text, "http://localhost:5173", "comma-separated origins allowed to call the API from a browser"},
+	{"READ_TIMEOUT", duration, "15s", "longest a client may take to send a request"},
+	{"WRITE_TIMEOUT", duration, "30s", "longest the server may take to write a response"},
+	{"IDLE_TIMEOUT", duration, "60s", "longest a kept-alive connection may sit idle"},
+	{"REQUEST_TIMEOUT", duration, "20s", "longest a request's database queries may run; less than WRITE_TIMEOUT"},
+	{"SHUTDOWN_TIMEOUT", duration, "15s", "longest to wait for requests in flight when stopping"},
+	{"MIGRATE", boolean, "false", "apply pending database migrations before starting"},
+	{"LOG_FORMAT", text, "text", "log as text or json"},
+	{"LOG_LEVEL", text, "info", "least severe level to log: debug, info, warn or error"},
+	{"ACCESS_LOG_FORMAT", text, "", "write the access log as text or json, or off for none; empty for LOG_FORMAT"},
+	{"METRICS", boolean, "true", "serve Prometheus metrics at /metrics"},
+	{"DEMO_SEED", boolean, "true", "give the in-memory store an admin login and log its password"},
+}
+
+// flagName is the flag for a setting: DB_HOST is -db-host.
+func flagName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
+// flagValue holds a setting given on the command line.
+type flagValue struct {
+	setting setting
+	values  map[string]string
+}
+
+func (v flagValue) String() string   { return "" }
+func (v flagValue) IsBoolFlag() bool { return v.setting.kind == boolean }
+
+func (v flagValue) Set(s string) error {
+	v.values[v.setting.name] = s
+	return nil
+}
+
+// Load reads the settings from the command-line arguments args (without
+// the program name), the environment through getenv and the config file,
+// and checks them. It returns the arguments left after the flags, and an
+// error naming every setting that is wrong.
+func Load(args []string, getenv func(string) string) (Config, []string, error) {
+	flags := map[string]string{}
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	configFile := fs.String("config", "", "file of KEY=value settings (env CONFIG_FILE)")
+	for _, s := range settings {
+		fs.Var(flagValue{s, flags}, flagName(s.name), fmt.Sprintf("%s (env %s, default %q)", s.usage, s.name, s.def))
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, nil, err
+	}
+
+	file := map[string]string{}
+	if *configFile == "" {
+		*configFile = getenv("CONFIG_FILE")
+	}
+	if *configFile != "" {
+		f, err := os.Open(*configFile)
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("config: %w", err)
+		}
+		defer f.Close()
+		if file, err = parseFile(f); err != nil {
+			return Config{}, nil, fmt.Errorf("config: %s: %w", *configFile, err)
+		}
+	}
+
+	values := map[string]string{}
+	given := map[string]bool{}
+	for _, s := range settings {
+		values[s.name] = s.def
+		for _, source := range []func(string) (string, bool){
+			func(name string) (string, bool) { v, ok := flags[name]; return v, ok },
+			func(name string) (string, bool) { v := getenv(name); return v, v != "" },
+			func(name string) (string, bool) { v, ok := file[name]; return v, ok },
+		} {
+			if v, ok := source(s.name); ok {
+				values[s.name] = v
+				given[s.name] = true
+				break
+			}
+		}
+	}
+
+	cfg, err := build(values, given)
+	return cfg, fs.Args(), err
+}
+
+// parseFile reads KEY=value lines, skipping blank lines and # comments. A
+// value may be wrapped in double quotes.
+func parseFile(r io.Reader) (map[string]string, error) {
+	known := map[string]bool{}
+	for _, s := range settings {
+		known[s.name] = true
+	}
+	values := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, fmt.Errorf("line %d: want KEY=value", n)
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("line %d: unknown setting %s", n, name)
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		}
+		values[name] = value
+	}
+	return values, scanner.Err()
+}
+
+// build turns the settings' values into a Config, checking each. given
+// holds the settings set anywhere other than by default.
+func build(values map[string]string, given map[string]bool) (Config, error) {
+	var errs []error
+	invalid := func(name, want string) {
+		errs = append(errs, fmt.Errorf("%s=%q: want %s", name, values[name], want))
+	}
+	port := func(name string) string {
+		if n, err := strconv.Atoi(values[name]); err != nil || n < 1 || n > 65535 {
+			invalid(name, "a port number from 1 to 65535")
+		}
+		return values[name]
+	}
+	durationOf := func(name string) time.Duration {
+		d, err := time.ParseDuration(values[name])
+		if err != nil || d <= 0 {
+			invalid(name, "a positive duration such as 30s or 2m")
+		}
+		return d
+	}
+	boolOf := func(name string) bool {
+		b, err := strconv.ParseBool(values[name])
+		if err != nil {
+			invalid(name, "true or false")
+		}
+		return b
+	}
+
+	cfg := Config{
+		Addr:            net.JoinHostPort(values["HOST"], port("PORT")),
+		DatabaseURL:     values["DATABASE_URL"],
+		ReadTimeout:     durationOf("READ_TIMEOUT"),
+		WriteTimeout:    durationOf("WRITE_TIMEOUT"),
+		IdleTimeout:     durationOf("IDLE_TIMEOUT"),
+		RequestTimeout:  durationOf("REQUEST_TIMEOUT"),
+		ShutdownTimeout: durationOf("SHUTDOWN_TIMEOUT"),
+		Migrate:         boolOf("MIGRATE"),
+		LogFormat:       values["LOG_FORMAT"],
+		AccessLogFormat: AccessLogFormat(values["ACCESS_LOG_FORMAT"]),
+		Metrics:         boolOf("METRICS"),
+		DemoSeed:        boolOf("DEMO_SEED"),
+	}
+	if cfg.LogFormat != "text" && cfg.LogFormat != "json" {
+		invalid("LOG_FORMAT", "text or json")
+	}
+	switch cfg.AccessLogFormat {
+	case "":
+		cfg.AccessLogFormat = AccessLogFormat(cfg.LogFormat)
+	case AccessLogText, AccessLogJSON, AccessLogOff:
+	default:
+		invalid("ACCESS_LOG_FORMAT", "text, json or off")
+	}
+	if err := cfg.LogLevel.UnmarshalText([]byte(values["LOG_LEVEL"])); err != nil {
+		invalid("LOG_LEVEL", "debug, info, warn or error")
+	}
+	// A request that runs out of time still needs to write its error
+	if cfg.RequestTimeout >= cfg.WriteTimeout && cfg.WriteTimeout > 0 {
+		invalid("REQUEST_TIMEOUT", fmt.Sprintf("a duration shorter than WRITE_TIMEOUT (%s)", cfg.WriteTimeout))
+	}
+
+	var components []string
+	for _, name := range []string{"DB_HOST", "DB_PORT", "DB_USER", "DB_PASS", "DB_NAME"} {
+		if given[name] {
+			components = append(components, name)
+		}
+	}
+	if cfg.DatabaseURL != "" && len(components) > 0 {
+		errs = append(errs, fmt.Errorf("DATABASE_URL and %s are both set: use one or the other", strings.Join(components, ", ")))
+	} else if cfg.DatabaseURL == "" {
+		if values["DB_HOST"] == "" {
+			invalid("DB_HOST", "a host name")
+		}
+		if values["DB_USER"] == "" {
+			invalid("DB_USER", "a user name")
+		}
+		if values["DB_NAME"] == "" {
+			invalid("DB_NAME", "a database name")
+		}
+		dsn := mysql.NewConfig()
+		dsn.User = values["DB_USER"]
+		dsn.Passwd = values["DB_PASS"]
+		dsn.Net = "tcp"
+		dsn.Addr = net.JoinHostPort(values["DB_HOST"], port("DB_PORT"))
+		dsn.DBName = values["DB_NAME"]
+		dsn.ParseTime = true
+		cfg.DatabaseURL = "mysql://" + dsn.FormatDSN()
+	}
+
+	for _, origin := range strings.Split(values["CORS_ORIGINS"], ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" {
+			invalid("CORS_ORIGINS", "origins like https://example.com, with no path, separated by commas")
+			break
+		}
+		cfg.AllowedOrigins = append(cfg.AllowedOrigins, origin)
+	}
+
+	if len(errs) > 0 {
+		return Config{}, fmt.Errorf("config: %w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestDefaults(t *testing.T) {
+	cfg, args, err := Load(nil, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Config{
+		Addr:            ":8080",
+		DatabaseURL:     "mysql://root@tcp(127.0.0.1:3306)/jones_county_xc?parseTime=true",
+		AllowedOrigins:  []string{"http://localhost:5173"},
+		ReadTimeout:     15 * time.Second,
+		WriteTimeout:    30 * time.Second,
+		IdleTimeout:     60 * time.Second,
+		RequestTimeout:  20 * time.Second,
+		ShutdownTimeout: 15 * time.Second,
+		LogFormat:       "text",
+		LogLevel:        slog.LevelInfo,
+		AccessLogFormat: AccessLogText,
+		Metrics:         true,
+		DemoSeed:        true,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %q, want none", args)
+	}
+}
+
+// The variables deploy/jonescountyxc.service sets.
+func TestSystemdUnit(t *testing.T) {
+	cfg, _, err := Load(nil, env(map[string]string{
+		"PORT":       "8080",
+		"DB_HOST":    "localhost",
+		"DB_PORT":    "3306",
+		"DB_USER":    "appuser",
+		"DB_PASS":    "p@ss/word",
+		"DB_NAME":    "jonescountyxc",
+		"LOG_FORMAT": "json",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "mysql://appuser:p@ss/word@tcp(localhost:3306)/jonescountyxc?parseTime=true"; cfg.DatabaseURL != want {
+		t.Errorf("DatabaseURL = %q, want %q", cfg.DatabaseURL, want)
+	}
+	if cfg.LogFormat != "json" || cfg.AccessLogFormat != AccessLogJSON {
+		t.Errorf("LogFormat = %q, AccessLogFormat = %q; want json for both", cfg.LogFormat, cfg.AccessLogFormat)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "xc.conf")
+	err := os.WriteFile(file, []byte(`# Jones County XC
+PORT=7000
+HOST=127.0.0.1
+CORS_ORIGINS="https://xc.example.com, https://www.xc.example.com"
+DATABASE_URL=sqlite:file.db
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, args, err := Load(
+		[]string{"-config", file, "-port", "9000", "-migrate", "migrate", "status"},
+		env(map[string]string{"PORT": "8000", "HOST": "10.0.0.1", "WRITE_TIMEOUT": "1m"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Addr != "10.0.0.1:9000" {
+		t.Errorf("Addr = %q, want the flag's port and the environment's host", cfg.Addr)
+	}
+	if cfg.DatabaseURL != "sqlite:file.db" {
+		t.Errorf("DatabaseURL = %q, want the file's", cfg.DatabaseURL)
+	}
+	if want := []string{"https://xc.example.com", "https://www.xc.example.com"}; !reflect.DeepEqual(cfg.AllowedOrigins, want) {
+		t.Errorf("AllowedOrigins = %q, want %q", cfg.AllowedOrigins, want)
+	}
+	if cfg.WriteTimeout != time.Minute || !cfg.Migrate {
+		t.Errorf("WriteTimeout = %v, Migrate = %v; want 1m and true", cfg.WriteTimeout, cfg.Migrate)
+	}
+	if want := []string{"migrate", "status"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+}
+
+// Each toggle is taken from the file, then from the environment over it,
+// then from a flag over both.
+func TestTogglePrecedence(t *testing.T) {
+	tests := []struct {
+		name            string
+		file, env, flag string
+		get             func(Config) string
+	}{
+		{"METRICS", "false", "true", "false", func(c Config) string { return strconv.FormatBool(c.Metrics) }},
+		{"DEMO_SEED", "false", "true", "false", func(c Config) string { return strconv.FormatBool(c.DemoSeed) }},
+		{"ACCESS_LOG_FORMAT", "off", "json", "text", func(c Config) string { return string(c.AccessLogFormat) }},
+	}
+	for _, tt := range tests {
+		file := filepath.Join(t.TempDir(), "xc.conf")
+		if err := os.WriteFile(file, []byte(tt.name+"="+tt.file+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		for _, step := range []struct {
+			args []string
+			env  string
+			want string
+		}{
+			{nil, "", tt.file},
+			{nil, tt.env, tt.env},
+			{[]string{"-" + flagName(tt.name) + "=" + tt.flag}, tt.env, tt.flag},
+		} {
+			cfg, _, err := Load(step.args, env(map[string]string{"CONFIG_FILE": file, tt.name: step.env}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.get(cfg); got != step.want {
+				t.Errorf("%s with args %q and env %q: got %s, want %s", tt.name, step.args, step.env, got, step.want)
+			}
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	tests := []struct {
+		vars map[string]string
+		want []string
+	}{
+		{map[string]string{"PORT": "http"}, []string{`PORT="http"`}},
+		{map[string]string{"PORT": "0", "DB_PORT": "99999"}, []string{`PORT="0"`, `DB_PORT="99999"`}},
+		{map[string]string{"READ_TIMEOUT": "10"}, []string{`READ_TIMEOUT="10"`}},
+		{map[string]string{"IDLE_TIMEOUT": "-1s"}, []string{`IDLE_TIMEOUT="-1s"`}},
+		{map[string]string{"MIGRATE": "yes"}, []string{`MIGRATE="yes"`}},
+		{map[string]string{"METRICS": "on", "DEMO_SEED": "2"}, []string{`METRICS="on"`, `DEMO_SEED="2"`}},
+		{map[string]string{"ACCESS_LOG_FORMAT": "combined"}, []string{`ACCESS_LOG_FORMAT="combined"`}},
+		{map[string]string{"LOG_FORMAT": "journald", "LOG_LEVEL": "loud"}, []string{`LOG_FORMAT="journald"`, `LOG_LEVEL="loud"`}},
+		{map[string]string{"REQUEST_TIMEOUT": "30s"}, []string{`REQUEST_TIMEOUT="30s"`, "WRITE_TIMEOUT (30s)"}},
+		{map[string]string{"CORS_ORIGINS": "https://xc.example.com/app"}, []string{"CORS_ORIGINS"}},
+		{map[string]string{"CORS_ORIGINS": "xc.example.com"}, []string{"CORS_ORIGINS"}},
+		{map[string]string{"DATABASE_URL": "memory:", "DB_USER": "appuser", "DB_PASS": "x"}, []string{"DB_USER, DB_PASS"}},
+	}
+	for _, tt := range tests {
+		_, _, err := Load(nil, env(tt.vars))
+		if err == nil {
+			t.Errorf("Load with %v succeeded", tt.vars)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Load with %v: error %q does not mention %s", tt.vars, err, want)
+			}
+		}
+	}
+}
+
+func TestInvalidFile(t *testing.T) {
+	for _, content := range []string{"PORT 8080\n", "DB_PASSWORD=secret\n"} {
+		file := filepath.Join(t.TempDir(), "xc.conf")
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err := Load(nil, env(map[string]string{"CONFIG_FILE": file}))
+		if err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("Load with file %q: err = %v, want one naming line 1", content, err)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// Package db holds the SQL the stores are built from: the numbered
+// migrations that create and evolve each database's schema, and the queries
+// sqlc generates the stores' code from. MySQL's queries are in this
+// directory and SQLite's in sqlite/.
+package db
+
+import "embed"
+
+// Migrations holds mysql/ and sqlite/, each a directory of numbered
+// migrations named like 012_personal_records.up.sql, with a .down.sql that
+// undoes it.
+//
+//go:embed migrations
+var Migrations embed.FS
@@ -0,0 +1,3 @@
+DROP TABLE results;
+DROP TABLE meets;
+DROP TABLE athletes;
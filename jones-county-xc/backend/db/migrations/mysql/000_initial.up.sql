@@ -1,3 +1,6 @@
+-- The schema the site started with, before migrations were tracked. Every
+-- later migration builds on it.
+
 CREATE TABLE athletes (
     id INT PRIMARY KEY AUTO_INCREMENT,
     name VARCHAR(255) NOT NULL,
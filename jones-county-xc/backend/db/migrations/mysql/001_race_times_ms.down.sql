@@ -0,0 +1,16 @@
+-- Put times back as "m:ss" text, with a fraction only where there is one.
+-- Times of an hour or more come back as minutes over 60 ("61:05").
+
+ALTER TABLE results ADD COLUMN time VARCHAR(20) NULL AFTER time_ms;
+UPDATE results
+SET time = CONCAT(time_ms DIV 60000, ':', LPAD(time_ms DIV 1000 % 60, 2, '0'),
+    IF(time_ms % 1000 = 0, '', TRIM(TRAILING '0' FROM CONCAT('.', LPAD(time_ms % 1000, 3, '0')))));
+ALTER TABLE results MODIFY time VARCHAR(20) NOT NULL;
+ALTER TABLE results DROP COLUMN time_ms;
+
+ALTER TABLE athletes ADD COLUMN personal_record VARCHAR(20) NULL AFTER personal_record_ms;
+UPDATE athletes
+SET personal_record = CONCAT(personal_record_ms DIV 60000, ':', LPAD(personal_record_ms DIV 1000 % 60, 2, '0'),
+    IF(personal_record_ms % 1000 = 0, '', TRIM(TRAILING '0' FROM CONCAT('.', LPAD(personal_record_ms % 1000, 3, '0')))))
+WHERE personal_record_ms IS NOT NULL;
+ALTER TABLE athletes DROP COLUMN personal_record_ms;
@@ -0,0 +1,32 @@
+-- Convert race times from VARCHAR strings ("mm:ss", "mm:ss.f", "h:mm:ss")
+-- to integer milliseconds so they sort and compare numerically.
+
+ALTER TABLE results ADD COLUMN time_ms INT NULL AFTER time;
+
+UPDATE results
+SET time_ms = ROUND(1000 * CASE LENGTH(TRIM(time)) - LENGTH(REPLACE(TRIM(time), ':', ''))
+    WHEN 2 THEN SUBSTRING_INDEX(TRIM(time), ':', 1) * 3600
+              + SUBSTRING_INDEX(SUBSTRING_INDEX(TRIM(time), ':', 2), ':', -1) * 60
+              + SUBSTRING_INDEX(TRIM(time), ':', -1)
+    WHEN 1 THEN SUBSTRING_INDEX(TRIM(time), ':', 1) * 60
+              + SUBSTRING_INDEX(TRIM(time), ':', -1)
+    ELSE TRIM(time)
+END);
+
+ALTER TABLE results MODIFY time_ms INT NOT NULL;
+ALTER TABLE results DROP COLUMN time;
+
+ALTER TABLE athletes ADD COLUMN personal_record_ms INT NULL AFTER personal_record;
+
+UPDATE athletes
+SET personal_record_ms = ROUND(1000 * CASE LENGTH(TRIM(personal_record)) - LENGTH(REPLACE(TRIM(personal_record), ':', ''))
+    WHEN 2 THEN SUBSTRING_INDEX(TRIM(personal_record), ':', 1) * 3600
+              + SUBSTRING_INDEX(SUBSTRING_INDEX(TRIM(personal_record), ':', 2), ':', -1) * 60
+              + SUBSTRING_INDEX(TRIM(personal_record), ':', -1)
+    WHEN 1 THEN SUBSTRING_INDEX(TRIM(personal_record), ':', 1) * 60
+              + SUBSTRING_INDEX(TRIM(personal_record), ':', -1)
+    ELSE TRIM(personal_record)
+END)
+WHERE TRIM(COALESCE(personal_record, '')) <> '';
+
+ALTER TABLE athletes DROP COLUMN personal_record;
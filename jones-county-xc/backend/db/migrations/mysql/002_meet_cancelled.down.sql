@@ -0,0 +1 @@
+ALTER TABLE meets DROP COLUMN cancelled;
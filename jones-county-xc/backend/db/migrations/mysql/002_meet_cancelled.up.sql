@@ -0,0 +1,3 @@
+-- Let coaches mark a meet as cancelled without deleting it.
+
+ALTER TABLE meets ADD COLUMN cancelled BOOLEAN NOT NULL DEFAULT FALSE;
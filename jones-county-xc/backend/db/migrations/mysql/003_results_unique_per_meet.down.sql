@@ -0,0 +1,7 @@
+-- The meet_id foreign key has been using uq_results_meet_athlete as its
+-- index, so it needs one of its own first.
+
+ALTER TABLE results
+    ADD KEY idx_results_meet (meet_id),
+    DROP KEY uq_results_meet_athlete,
+    DROP KEY uq_results_meet_place;
@@ -0,0 +1,8 @@
+-- An athlete finishes a meet at most once, and each place is awarded once.
+-- Check for existing duplicates before running:
+--   SELECT meet_id, athlete_id, COUNT(*) FROM results GROUP BY 1, 2 HAVING COUNT(*) > 1;
+--   SELECT meet_id, place, COUNT(*) FROM results GROUP BY 1, 2 HAVING COUNT(*) > 1;
+
+ALTER TABLE results
+    ADD UNIQUE KEY uq_results_meet_athlete (meet_id, athlete_id),
+    ADD UNIQUE KEY uq_results_meet_place (meet_id, place);
@@ -0,0 +1,2 @@
+DROP TABLE user_athletes;
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,16 @@
+-- Give each account a role and let athlete/parent accounts be linked to the
+-- athletes whose history they may see. Accounts that already exist belonged
+-- to coaches with full access, so they become admins.
+
+ALTER TABLE users
+    ADD COLUMN role ENUM('admin', 'coach', 'athlete', 'parent') NOT NULL DEFAULT 'coach' AFTER password_hash;
+
+UPDATE users SET role = 'admin';
+
+CREATE TABLE user_athletes (
+    user_id INT NOT NULL,
+    athlete_id INT NOT NULL,
+    PRIMARY KEY (user_id, athlete_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (athlete_id) REFERENCES athletes(id) ON DELETE CASCADE
+);
@@ -0,0 +1,31 @@
+-- Opponent finishers have no athlete, so they cannot be kept.
+
+DELETE FROM results WHERE athlete_id IS NULL;
+
+SET @fk = (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'results'
+      AND COLUMN_NAME = 'team_id' AND REFERENCED_TABLE_NAME = 'teams'
+);
+SET @sql = CONCAT('ALTER TABLE results DROP FOREIGN KEY ', @fk);
+PREPARE drop_fk FROM @sql;
+EXECUTE drop_fk;
+DEALLOCATE PREPARE drop_fk;
+
+SET @fk = (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'athletes'
+      AND COLUMN_NAME = 'team_id' AND REFERENCED_TABLE_NAME = 'teams'
+);
+SET @sql = CONCAT('ALTER TABLE athletes DROP FOREIGN KEY ', @fk);
+PREPARE drop_fk FROM @sql;
+EXECUTE drop_fk;
+DEALLOCATE PREPARE drop_fk;
+
+ALTER TABLE results
+    DROP COLUMN team_id,
+    DROP COLUMN runner_name,
+    MODIFY athlete_id INT NOT NULL;
+ALTER TABLE athletes DROP COLUMN team_id;
+
+DROP TABLE teams;
@@ -0,0 +1,27 @@
+-- Add schools. Every existing athlete belongs to the home team, and results
+-- gain a team so opponent finishers (who have no athletes row) can be
+-- recorded by name.
+
+CREATE TABLE teams (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    short_name VARCHAR(32),
+    is_home BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+INSERT INTO teams (name, short_name, is_home) VALUES ('Jones County', 'JC', TRUE);
+
+ALTER TABLE athletes ADD COLUMN team_id INT NULL AFTER id;
+UPDATE athletes SET team_id = (SELECT id FROM teams WHERE is_home LIMIT 1);
+ALTER TABLE athletes
+    MODIFY team_id INT NOT NULL,
+    ADD FOREIGN KEY (team_id) REFERENCES teams(id);
+
+ALTER TABLE results
+    MODIFY athlete_id INT NULL,
+    ADD COLUMN team_id INT NULL AFTER athlete_id,
+    ADD COLUMN runner_name VARCHAR(255) NULL AFTER team_id;
+UPDATE results r JOIN athletes a ON r.athlete_id = a.id SET r.team_id = a.team_id;
+ALTER TABLE results
+    MODIFY team_id INT NOT NULL,
+    ADD FOREIGN KEY (team_id) REFERENCES teams(id);
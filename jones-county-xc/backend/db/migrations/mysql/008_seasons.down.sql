@@ -0,0 +1,13 @@
+-- Athletes get back the grade they are in this season. Past rosters are
+-- lost.
+
+SET @current = YEAR(CURDATE()) - (MONTH(CURDATE()) < 7);
+
+ALTER TABLE athletes ADD COLUMN grade INT NULL AFTER name;
+UPDATE athletes SET grade = @current + 1 + 12 - graduation_year;
+ALTER TABLE athletes
+    MODIFY grade INT NOT NULL,
+    DROP COLUMN graduation_year;
+
+DROP TABLE season_athletes;
+DROP TABLE seasons;
@@ -0,0 +1,44 @@
+-- Add seasons and per-season rosters. Athletes store their graduation year
+-- instead of a grade, worked out from the grade they have now; everyone is
+-- put on the current season's roster, and a season is created for every
+-- school year that already has meets.
+
+CREATE TABLE seasons (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    year INT NOT NULL UNIQUE,
+    name VARCHAR(64) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL
+);
+
+CREATE TABLE season_athletes (
+    season_id INT NOT NULL,
+    athlete_id INT NOT NULL,
+    grade INT NOT NULL,
+    PRIMARY KEY (season_id, athlete_id),
+    FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE,
+    FOREIGN KEY (athlete_id) REFERENCES athletes(id) ON DELETE CASCADE
+);
+
+-- A season runs 1 July to 30 June and is named by the July year.
+SET @current = YEAR(CURDATE()) - (MONTH(CURDATE()) < 7);
+
+INSERT INTO seasons (year, name, start_date, end_date)
+SELECT y, CONCAT(y, ' Season'), MAKEDATE(y, 1) + INTERVAL 6 MONTH, MAKEDATE(y + 1, 1) + INTERVAL 6 MONTH - INTERVAL 1 DAY
+FROM (
+    SELECT @current AS y
+    UNION
+    SELECT DISTINCT YEAR(date) - (MONTH(date) < 7) FROM meets
+) years;
+
+ALTER TABLE athletes ADD COLUMN graduation_year INT NULL AFTER name;
+UPDATE athletes SET graduation_year = @current + 1 + 12 - grade;
+
+INSERT INTO season_athletes (season_id, athlete_id, grade)
+SELECT s.id, a.id, a.grade
+FROM athletes a
+JOIN seasons s ON s.year = @current;
+
+ALTER TABLE athletes
+    MODIFY graduation_year INT NOT NULL,
+    DROP COLUMN grade;
@@ -0,0 +1,27 @@
+-- Hang results off their meet again. This fails if a meet has finishes
+-- in more than one race, as an athlete or place may then appear twice in
+-- the meet; merge or delete those races first.
+
+ALTER TABLE results ADD COLUMN meet_id INT NULL AFTER runner_name;
+UPDATE results r JOIN races ra ON r.race_id = ra.id SET r.meet_id = ra.meet_id;
+
+SET @fk = (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'results'
+      AND COLUMN_NAME = 'race_id' AND REFERENCED_TABLE_NAME = 'races'
+);
+SET @sql = CONCAT('ALTER TABLE results DROP FOREIGN KEY ', @fk);
+PREPARE drop_fk FROM @sql;
+EXECUTE drop_fk;
+DEALLOCATE PREPARE drop_fk;
+
+ALTER TABLE results
+    DROP KEY uq_results_race_athlete,
+    DROP KEY uq_results_race_place,
+    DROP COLUMN race_id,
+    MODIFY meet_id INT NOT NULL,
+    ADD FOREIGN KEY (meet_id) REFERENCES meets(id),
+    ADD UNIQUE KEY uq_results_meet_athlete (meet_id, athlete_id),
+    ADD UNIQUE KEY uq_results_meet_place (meet_id, place);
+
+DROP TABLE races;
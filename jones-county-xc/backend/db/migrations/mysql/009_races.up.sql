@@ -0,0 +1,41 @@
+-- Split meets into races (boys/girls, varsity/JV/middle school) and hang
+-- results off a race instead of the meet. Every meet that already has
+-- results gets one open 5K race holding them; coaches can then add the real
+-- races and move finishes across.
+
+CREATE TABLE races (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    meet_id INT NOT NULL,
+    gender ENUM('boys', 'girls', 'mixed') NOT NULL,
+    division ENUM('varsity', 'jv', 'middle_school', 'open') NOT NULL,
+    distance_m INT NOT NULL DEFAULT 5000,
+    start_time TIME,
+    FOREIGN KEY (meet_id) REFERENCES meets(id) ON DELETE CASCADE,
+    UNIQUE KEY uq_races_meet_division (meet_id, gender, division)
+);
+
+INSERT INTO races (meet_id, gender, division)
+SELECT DISTINCT meet_id, 'mixed', 'open' FROM results;
+
+ALTER TABLE results ADD COLUMN race_id INT NULL AFTER runner_name;
+UPDATE results r JOIN races ra ON ra.meet_id = r.meet_id SET r.race_id = ra.id;
+
+-- The meet_id foreign key was created unnamed, so look its name up.
+SET @fk = (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'results'
+      AND COLUMN_NAME = 'meet_id' AND REFERENCED_TABLE_NAME = 'meets'
+);
+SET @sql = CONCAT('ALTER TABLE results DROP FOREIGN KEY ', @fk);
+PREPARE drop_fk FROM @sql;
+EXECUTE drop_fk;
+DEALLOCATE PREPARE drop_fk;
+
+ALTER TABLE results
+    DROP KEY uq_results_meet_athlete,
+    DROP KEY uq_results_meet_place,
+    DROP COLUMN meet_id,
+    MODIFY race_id INT NOT NULL,
+    ADD FOREIGN KEY (race_id) REFERENCES races(id),
+    ADD UNIQUE KEY uq_results_race_athlete (race_id, athlete_id),
+    ADD UNIQUE KEY uq_results_race_place (race_id, place);
@@ -0,0 +1,24 @@
+SET @fk = (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'meets'
+      AND COLUMN_NAME = 'course_id' AND REFERENCED_TABLE_NAME = 'courses'
+);
+SET @sql = CONCAT('ALTER TABLE meets DROP FOREIGN KEY ', @fk);
+PREPARE drop_fk FROM @sql;
+EXECUTE drop_fk;
+DEALLOCATE PREPARE drop_fk;
+
+SET @fk = (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'races'
+      AND COLUMN_NAME = 'course_id' AND REFERENCED_TABLE_NAME = 'courses'
+);
+SET @sql = CONCAT('ALTER TABLE races DROP FOREIGN KEY ', @fk);
+PREPARE drop_fk FROM @sql;
+EXECUTE drop_fk;
+DEALLOCATE PREPARE drop_fk;
+
+ALTER TABLE meets DROP COLUMN course_id;
+ALTER TABLE races DROP COLUMN course_id;
+
+DROP TABLE courses;
@@ -0,0 +1,27 @@
+-- Add courses and link meets and races to them. Each distinct meet
+-- location becomes a course (a 5K until someone corrects it) and its meets
+-- are linked to it; races run on their meet's course unless they name
+-- their own.
+
+CREATE TABLE courses (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    address VARCHAR(255),
+    distance_m INT NOT NULL DEFAULT 5000,
+    surface VARCHAR(64),
+    elevation_gain_m INT,
+    notes TEXT
+);
+
+ALTER TABLE meets
+    ADD COLUMN course_id INT NULL,
+    ADD FOREIGN KEY (course_id) REFERENCES courses(id);
+
+ALTER TABLE races
+    ADD COLUMN course_id INT NULL,
+    ADD FOREIGN KEY (course_id) REFERENCES courses(id);
+
+INSERT INTO courses (name)
+SELECT DISTINCT location FROM meets WHERE location IS NOT NULL AND location <> '';
+
+UPDATE meets m JOIN courses c ON c.name = m.location SET m.course_id = c.id;
@@ -0,0 +1 @@
+DROP TABLE result_splits;
@@ -0,0 +1,11 @@
+-- Add split times: elapsed time at each marker (usually every mile) along
+-- the course for a finish.
+
+CREATE TABLE result_splits (
+    result_id INT NOT NULL,
+    split_index INT NOT NULL,
+    distance_m INT NOT NULL,
+    elapsed_ms INT NOT NULL,
+    PRIMARY KEY (result_id, split_index),
+    FOREIGN KEY (result_id) REFERENCES results(id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+-- The hand-entered PR becomes the athlete's PR again; records and PR flags
+-- derived from results are dropped.
+
+DROP TABLE personal_records;
+
+ALTER TABLE results
+    DROP COLUMN is_pr,
+    DROP COLUMN is_course_pr;
+
+ALTER TABLE athletes DROP COLUMN personal_record_ms;
+ALTER TABLE athletes
+    RENAME COLUMN manual_pr_ms TO personal_record_ms;
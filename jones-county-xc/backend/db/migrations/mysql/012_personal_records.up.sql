@@ -0,0 +1,89 @@
+-- Derive personal records from results. The hand-entered PR moves to
+-- manual_pr_ms and personal_record_ms becomes the faster of it and the best
+-- 5K result. Existing results are flagged and records filled in here; the
+-- server keeps them up to date from then on. Needs MySQL 8 for the window
+-- functions.
+
+ALTER TABLE athletes
+    RENAME COLUMN personal_record_ms TO manual_pr_ms;
+
+ALTER TABLE athletes
+    ADD COLUMN personal_record_ms INT NULL AFTER graduation_year;
+
+ALTER TABLE results
+    ADD COLUMN is_pr BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN is_course_pr BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE personal_records (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    athlete_id INT NOT NULL,
+    distance_m INT NOT NULL,
+    course_id INT,
+    result_id INT NOT NULL,
+    time_ms INT NOT NULL,
+    KEY idx_personal_records_athlete (athlete_id, distance_m),
+    FOREIGN KEY (athlete_id) REFERENCES athletes(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (result_id) REFERENCES results(id) ON DELETE CASCADE
+);
+
+-- A finish is a PR when it beats everything the athlete ran before it at
+-- that distance (and, for a course PR, on that course).
+UPDATE results r
+JOIN (
+    SELECT r.id,
+           COALESCE(ra.course_id, m.course_id) AS course_id,
+           MIN(r.time_ms) OVER (
+               PARTITION BY r.athlete_id, ra.distance_m
+               ORDER BY m.date, ra.start_time, r.id
+               ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+           ) AS prev_best,
+           MIN(r.time_ms) OVER (
+               PARTITION BY r.athlete_id, ra.distance_m, COALESCE(ra.course_id, m.course_id)
+               ORDER BY m.date, ra.start_time, r.id
+               ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+           ) AS prev_course_best
+    FROM results r
+    JOIN races ra ON r.race_id = ra.id
+    JOIN meets m ON ra.meet_id = m.id
+    WHERE r.athlete_id IS NOT NULL
+) b ON b.id = r.id
+SET r.is_pr = (b.prev_best IS NULL OR r.time_ms < b.prev_best),
+    r.is_course_pr = (b.course_id IS NOT NULL AND (b.prev_course_best IS NULL OR r.time_ms < b.prev_course_best));
+
+-- The standing record is the fastest of the finishes that set one.
+INSERT INTO personal_records (athlete_id, distance_m, course_id, result_id, time_ms)
+SELECT r.athlete_id, ra.distance_m, NULL, r.id, r.time_ms
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+WHERE r.is_pr AND NOT EXISTS (
+    SELECT 1
+    FROM results r2
+    JOIN races ra2 ON r2.race_id = ra2.id
+    WHERE r2.athlete_id = r.athlete_id AND ra2.distance_m = ra.distance_m
+      AND r2.is_pr AND r2.time_ms < r.time_ms
+);
+
+INSERT INTO personal_records (athlete_id, distance_m, course_id, result_id, time_ms)
+SELECT r.athlete_id, ra.distance_m, COALESCE(ra.course_id, m.course_id), r.id, r.time_ms
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE r.is_course_pr AND NOT EXISTS (
+    SELECT 1
+    FROM results r2
+    JOIN races ra2 ON r2.race_id = ra2.id
+    JOIN meets m2 ON ra2.meet_id = m2.id
+    WHERE r2.athlete_id = r.athlete_id AND ra2.distance_m = ra.distance_m
+      AND COALESCE(ra2.course_id, m2.course_id) = COALESCE(ra.course_id, m.course_id)
+      AND r2.is_course_pr AND r2.time_ms < r.time_ms
+);
+
+UPDATE athletes a
+LEFT JOIN personal_records p
+    ON p.athlete_id = a.id AND p.distance_m = 5000 AND p.course_id IS NULL
+SET a.personal_record_ms = CASE
+    WHEN p.time_ms IS NULL THEN a.manual_pr_ms
+    WHEN a.manual_pr_ms IS NULL THEN p.time_ms
+    ELSE LEAST(p.time_ms, a.manual_pr_ms)
+END;
@@ -0,0 +1,3 @@
+ALTER TABLE results
+    DROP KEY idx_results_created_at,
+    DROP COLUMN created_at;
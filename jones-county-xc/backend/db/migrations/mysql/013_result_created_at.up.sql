@@ -0,0 +1,12 @@
+-- Record when each result was entered, for the results-entered-today
+-- metric. Results entered before now take their meet's date, which is when
+-- most of them were entered anyway.
+
+ALTER TABLE results
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD KEY idx_results_created_at (created_at);
+
+UPDATE results r
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+SET r.created_at = m.date;
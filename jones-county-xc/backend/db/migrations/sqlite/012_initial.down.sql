@@ -0,0 +1,13 @@
+DROP TABLE sessions;
+DROP TABLE user_athletes;
+DROP TABLE users;
+DROP TABLE personal_records;
+DROP TABLE result_splits;
+DROP TABLE results;
+DROP TABLE races;
+DROP TABLE meets;
+DROP TABLE courses;
+DROP TABLE season_athletes;
+DROP TABLE seasons;
+DROP TABLE athletes;
+DROP TABLE teams;
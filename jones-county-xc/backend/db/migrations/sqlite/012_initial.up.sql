@@ -0,0 +1,146 @@
+-- SQLite arrived when the MySQL schema was at version 12, so its history
+-- starts there, with everything the MySQL migrations up to 012 built. Each
+-- later version gets a migration in both directories, so that a version
+-- number means the same tables and columns whichever database it is:
+-- names compare without regard to case, as under MySQL's collation.
+-- SQLite only enforces the foreign keys when a connection turns on
+-- PRAGMA foreign_keys, which store.OpenSQLite does.
+
+CREATE TABLE teams (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    short_name TEXT,
+    is_home BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Athletes default to the home team, so a fresh database needs one.
+INSERT INTO teams (name, short_name, is_home) VALUES ('Jones County', 'JC', TRUE);
+
+-- personal_record_ms is the athlete's 5K PR: the faster of their best 5K
+-- result and manual_pr_ms, a hand-entered time kept for PRs run before
+-- results were recorded here. It is kept up to date with personal_records.
+CREATE TABLE athletes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    team_id INT NOT NULL REFERENCES teams(id),
+    name TEXT NOT NULL COLLATE NOCASE,
+    graduation_year INT NOT NULL,
+    personal_record_ms INT,
+    manual_pr_ms INT,
+    events TEXT
+);
+
+-- A season is named by the year its fall races are run and spans the school
+-- year, 1 July to 30 June.
+CREATE TABLE seasons (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    year INT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL
+);
+
+-- Who was on the roster each season, and in what grade.
+CREATE TABLE season_athletes (
+    season_id INT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    athlete_id INT NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    grade INT NOT NULL,
+    PRIMARY KEY (season_id, athlete_id)
+);
+
+-- A course is a venue's measured loop. Distance is the standard race
+-- length there; a race may still be run at another distance.
+CREATE TABLE courses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    address TEXT,
+    distance_m INT NOT NULL DEFAULT 5000,
+    surface TEXT,
+    elevation_gain_m INT,
+    notes TEXT
+);
+
+CREATE TABLE meets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    date DATE NOT NULL,
+    location TEXT,
+    description TEXT,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    course_id INT REFERENCES courses(id)
+);
+
+-- A meet runs one race per gender and division. A race is run on the
+-- meet's course unless course_id names another (say, a shorter middle
+-- school loop). SQLite has no ENUM, so CHECK keeps gender and division to
+-- the values MySQL allows.
+CREATE TABLE races (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    meet_id INT NOT NULL REFERENCES meets(id) ON DELETE CASCADE,
+    gender TEXT NOT NULL CHECK (gender IN ('boys', 'girls', 'mixed')),
+    division TEXT NOT NULL CHECK (division IN ('varsity', 'jv', 'middle_school', 'open')),
+    distance_m INT NOT NULL DEFAULT 5000,
+    start_time TEXT,
+    course_id INT REFERENCES courses(id),
+    UNIQUE (meet_id, gender, division)
+);
+
+-- Opponent finishers have no athletes row: athlete_id is NULL and the
+-- runner is identified by runner_name and team_id instead.
+CREATE TABLE results (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    athlete_id INT REFERENCES athletes(id),
+    team_id INT NOT NULL REFERENCES teams(id),
+    runner_name TEXT,
+    race_id INT NOT NULL REFERENCES races(id),
+    time_ms INT NOT NULL,
+    place INT NOT NULL,
+    is_pr BOOLEAN NOT NULL DEFAULT FALSE,
+    is_course_pr BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (race_id, athlete_id),
+    UNIQUE (race_id, place)
+);
+
+-- Elapsed times at markers along the course, numbered from 1 in the order
+-- they were passed.
+CREATE TABLE result_splits (
+    result_id INT NOT NULL REFERENCES results(id) ON DELETE CASCADE,
+    split_index INT NOT NULL,
+    distance_m INT NOT NULL,
+    elapsed_ms INT NOT NULL,
+    PRIMARY KEY (result_id, split_index)
+);
+
+-- Each athlete's fastest result at each distance: over every course when
+-- course_id is NULL, otherwise on that course. Rebuilt from results, along
+-- with results.is_pr and is_course_pr, whenever the athlete's results change.
+CREATE TABLE personal_records (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    athlete_id INT NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    distance_m INT NOT NULL,
+    course_id INT REFERENCES courses(id) ON DELETE CASCADE,
+    result_id INT NOT NULL REFERENCES results(id) ON DELETE CASCADE,
+    time_ms INT NOT NULL
+);
+
+CREATE INDEX idx_personal_records_athlete ON personal_records (athlete_id, distance_m);
+
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    password_hash TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'coach' CHECK (role IN ('admin', 'coach', 'athlete', 'parent')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE user_athletes (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    athlete_id INT NOT NULL REFERENCES athletes(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, athlete_id)
+);
+
+CREATE TABLE sessions (
+    token_hash TEXT PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at DATETIME NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP INDEX idx_results_created_at;
+
+ALTER TABLE results DROP COLUMN created_at;
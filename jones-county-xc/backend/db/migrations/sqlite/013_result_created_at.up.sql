@@ -0,0 +1,16 @@
+-- Record when each result was entered, as in the MySQL migration. SQLite
+-- cannot add a column defaulting to CURRENT_TIMESTAMP, so CreateResult sets
+-- it, and the default here only fills the rows the UPDATE then dates.
+
+ALTER TABLE results
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+CREATE INDEX idx_results_created_at ON results (created_at);
+
+UPDATE results
+SET created_at = (
+    SELECT m.date
+    FROM races ra
+    JOIN meets m ON ra.meet_id = m.id
+    WHERE ra.id = results.race_id
+);
@@ -6,15 +6,162 @@ package dbsqlc
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"time"
+
+	"jones-county-xc/backend/racetime"
+)
+
+type RacesDivision string
+
+const (
+	RacesDivisionVarsity      RacesDivision = "varsity"
+	RacesDivisionJv           RacesDivision = "jv"
+	RacesDivisionMiddleSchool RacesDivision = "middle_school"
+	RacesDivisionOpen         RacesDivision = "open"
+)
+
+func (e *RacesDivision) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RacesDivision(s)
+	case string:
+		*e = RacesDivision(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RacesDivision: %T", src)
+	}
+	return nil
+}
+
+type NullRacesDivision struct {
+	RacesDivision RacesDivision
+	Valid         bool // Valid is true if RacesDivision is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRacesDivision) Scan(value interface{}) error {
+	if value == nil {
+		ns.RacesDivision, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RacesDivision.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRacesDivision) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RacesDivision), nil
+}
+
+type RacesGender string
+
+const (
+	RacesGenderBoys  RacesGender = "boys"
+	RacesGenderGirls RacesGender = "girls"
+	RacesGenderMixed RacesGender = "mixed"
 )
 
+func (e *RacesGender) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RacesGender(s)
+	case string:
+		*e = RacesGender(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RacesGender: %T", src)
+	}
+	return nil
+}
+
+type NullRacesGender struct {
+	RacesGender RacesGender
+	Valid       bool // Valid is true if RacesGender is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRacesGender) Scan(value interface{}) error {
+	if value == nil {
+		ns.RacesGender, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RacesGender.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRacesGender) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RacesGender), nil
+}
+
+type UsersRole string
+
+const (
+	UsersRoleAdmin   UsersRole = "admin"
+	UsersRoleCoach   UsersRole = "coach"
+	UsersRoleAthlete UsersRole = "athlete"
+	UsersRoleParent  UsersRole = "parent"
+)
+
+func (e *UsersRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UsersRole(s)
+	case string:
+		*e = UsersRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UsersRole: %T", src)
+	}
+	return nil
+}
+
+type NullUsersRole struct {
+	UsersRole UsersRole
+	Valid     bool // Valid is true if UsersRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUsersRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.UsersRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UsersRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUsersRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UsersRole), nil
+}
+
 type Athlete struct {
+	ID               int32
+	Name             string
+	Events           sql.NullString
+	ManualPrMs       racetime.NullDuration
+	TeamID           int32
+	GraduationYear   int32
+	PersonalRecordMs racetime.NullDuration
+}
+
+type Course struct {
 	ID             int32
 	Name           string
-	Grade          int32
-	PersonalRecord sql.NullString
-	Events         sql.NullString
+	Address        sql.NullString
+	DistanceM      int32
+	Surface        sql.NullString
+	ElevationGainM sql.NullInt32
+	Notes          sql.NullString
 }
 
 type Meet struct {
@@ -23,12 +170,86 @@ type Meet struct {
 	Date        time.Time
 	Location    sql.NullString
 	Description sql.NullString
+	Cancelled   bool
+	CourseID    sql.NullInt32
 }
 
-type Result struct {
+type PersonalRecord struct {
 	ID        int32
 	AthleteID int32
+	DistanceM int32
+	CourseID  sql.NullInt32
+	ResultID  int32
+	TimeMs    racetime.Duration
+}
+
+type Race struct {
+	ID        int32
 	MeetID    int32
-	Time      string
-	Place     int32
+	Gender    RacesGender
+	Division  RacesDivision
+	DistanceM int32
+	StartTime sql.NullString
+	CourseID  sql.NullInt32
+}
+
+type Result struct {
+	ID         int32
+	Place      int32
+	TimeMs     racetime.Duration
+	AthleteID  sql.NullInt32
+	RunnerName sql.NullString
+	TeamID     int32
+	RaceID     int32
+	IsPr       bool
+	IsCoursePr bool
+	CreatedAt  time.Time
+}
+
+type ResultSplit struct {
+	ResultID   int32
+	SplitIndex int32
+	DistanceM  int32
+	ElapsedMs  racetime.Duration
+}
+
+type Season struct {
+	ID        int32
+	Year      int32
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type SeasonAthlete struct {
+	SeasonID  int32
+	AthleteID int32
+	Grade     int32
+}
+
+type Session struct {
+	TokenHash string
+	UserID    int32
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type Team struct {
+	ID        int32
+	Name      string
+	ShortName sql.NullString
+	IsHome    bool
+}
+
+type User struct {
+	ID           int32
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+	Role         UsersRole
+}
+
+type UserAthlete struct {
+	UserID    int32
+	AthleteID int32
 }
@@ -0,0 +1,109 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package dbsqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"jones-county-xc/backend/racetime"
+)
+
+type Querier interface {
+	ClearResultPRFlags(ctx context.Context, athleteID sql.NullInt32) error
+	CountAthletes(ctx context.Context) (int64, error)
+	CountCourseReferences(ctx context.Context, arg CountCourseReferencesParams) (int32, error)
+	CountResultsByMeet(ctx context.Context, meetID int32) (int64, error)
+	CountResultsByRace(ctx context.Context, raceID int32) (int64, error)
+	CountResultsCreatedSince(ctx context.Context, createdAt time.Time) (int64, error)
+	CountTeamReferences(ctx context.Context, arg CountTeamReferencesParams) (int32, error)
+	CreateAthlete(ctx context.Context, arg CreateAthleteParams) (sql.Result, error)
+	CreateCourse(ctx context.Context, arg CreateCourseParams) (sql.Result, error)
+	CreateMeet(ctx context.Context, arg CreateMeetParams) (sql.Result, error)
+	CreatePersonalRecord(ctx context.Context, arg CreatePersonalRecordParams) error
+	CreateRace(ctx context.Context, arg CreateRaceParams) (sql.Result, error)
+	CreateResult(ctx context.Context, arg CreateResultParams) (sql.Result, error)
+	CreateResultSplit(ctx context.Context, arg CreateResultSplitParams) error
+	CreateSeason(ctx context.Context, arg CreateSeasonParams) (sql.Result, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) error
+	CreateTeam(ctx context.Context, arg CreateTeamParams) (sql.Result, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error)
+	DeleteAthlete(ctx context.Context, id int32) (int64, error)
+	DeleteCourse(ctx context.Context, id int32) error
+	DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error
+	DeleteMeet(ctx context.Context, id int32) error
+	DeletePersonalRecords(ctx context.Context, athleteID int32) error
+	DeleteRace(ctx context.Context, id int32) error
+	DeleteResult(ctx context.Context, id int32) error
+	DeleteResultSplits(ctx context.Context, resultID int32) error
+	DeleteResultsByRace(ctx context.Context, raceID int32) error
+	DeleteRosterEntry(ctx context.Context, arg DeleteRosterEntryParams) (int64, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteTeam(ctx context.Context, id int32) error
+	DeleteUser(ctx context.Context, id int32) (int64, error)
+	GetAthleteByID(ctx context.Context, id int32) (GetAthleteByIDRow, error)
+	GetAthleteManualPR(ctx context.Context, id int32) (racetime.NullDuration, error)
+	GetCourseByID(ctx context.Context, id int32) (GetCourseByIDRow, error)
+	GetHomeTeam(ctx context.Context) (GetHomeTeamRow, error)
+	GetMeetByID(ctx context.Context, id int32) (GetMeetByIDRow, error)
+	GetRaceByID(ctx context.Context, id int32) (Race, error)
+	GetResultByID(ctx context.Context, id int32) (GetResultByIDRow, error)
+	GetSeasonByYear(ctx context.Context, year int32) (Season, error)
+	GetSessionUser(ctx context.Context, arg GetSessionUserParams) (GetSessionUserRow, error)
+	GetTeamByID(ctx context.Context, id int32) (GetTeamByIDRow, error)
+	GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error)
+	LinkUserAthlete(ctx context.Context, arg LinkUserAthleteParams) error
+	ListAthleteHistory(ctx context.Context, arg ListAthleteHistoryParams) ([]ListAthleteHistoryRow, error)
+	ListAthleteProgression(ctx context.Context, athleteID sql.NullInt32) ([]ListAthleteProgressionRow, error)
+	ListAthleteTeamsIn(ctx context.Context, ids []int32) ([]ListAthleteTeamsInRow, error)
+	ListAthletes(ctx context.Context, arg ListAthletesParams) ([]ListAthletesRow, error)
+	ListAthletesAllSeasons(ctx context.Context, teamID int32) ([]ListAthletesAllSeasonsRow, error)
+	// Every finish on a course, fastest first within each gender and distance.
+	// A race is on the course if it names it, or names no course and its meet
+	// does.
+	ListCourseResults(ctx context.Context, arg ListCourseResultsParams) ([]ListCourseResultsRow, error)
+	ListCourses(ctx context.Context) ([]ListCoursesRow, error)
+	ListFastestAthletes(ctx context.Context) ([]ListFastestAthletesRow, error)
+	ListFastestAthletesInSeason(ctx context.Context, seasonID int32) ([]ListFastestAthletesInSeasonRow, error)
+	ListFastestTimes(ctx context.Context, arg ListFastestTimesParams) ([]ListFastestTimesRow, error)
+	// Our finishes at the most recent meet between the two dates.
+	ListLatestMeetResults(ctx context.Context, arg ListLatestMeetResultsParams) ([]ListLatestMeetResultsRow, error)
+	ListMeetAthleteIDs(ctx context.Context, meetID int32) ([]sql.NullInt32, error)
+	ListMeets(ctx context.Context, arg ListMeetsParams) ([]ListMeetsRow, error)
+	// An athlete's records, overall before per-course within each distance.
+	ListPersonalRecords(ctx context.Context, athleteID int32) ([]ListPersonalRecordsRow, error)
+	ListRaceAthleteIDs(ctx context.Context, raceID int32) ([]sql.NullInt32, error)
+	ListRaceFinishers(ctx context.Context, raceID int32) ([]ListRaceFinishersRow, error)
+	ListRacesByMeet(ctx context.Context, meetID int32) ([]Race, error)
+	ListResultsBetween(ctx context.Context, arg ListResultsBetweenParams) ([]ListResultsBetweenRow, error)
+	ListResultsByMeet(ctx context.Context, meetID int32) ([]ListResultsByMeetRow, error)
+	ListResultsByMeetAndTeam(ctx context.Context, arg ListResultsByMeetAndTeamParams) ([]ListResultsByMeetAndTeamRow, error)
+	ListResultsByRace(ctx context.Context, raceID int32) ([]ListResultsByRaceRow, error)
+	ListResultsByRaceAndTeam(ctx context.Context, arg ListResultsByRaceAndTeamParams) ([]ListResultsByRaceAndTeamRow, error)
+	ListRoster(ctx context.Context, seasonID int32) ([]ListRosterRow, error)
+	ListSeasons(ctx context.Context) ([]Season, error)
+	ListSplitsByAthlete(ctx context.Context, athleteID sql.NullInt32) ([]ResultSplit, error)
+	ListSplitsByMeet(ctx context.Context, meetID int32) ([]ResultSplit, error)
+	ListSplitsByRace(ctx context.Context, raceID int32) ([]ResultSplit, error)
+	ListSplitsByResult(ctx context.Context, resultID int32) ([]ResultSplit, error)
+	ListTeamIDsIn(ctx context.Context, ids []int32) ([]int32, error)
+	ListTeams(ctx context.Context) ([]ListTeamsRow, error)
+	ListUserAthleteIDs(ctx context.Context, userID int32) ([]int32, error)
+	ListUsers(ctx context.Context) ([]ListUsersRow, error)
+	SetAthletePR(ctx context.Context, arg SetAthletePRParams) error
+	SetResultPRFlags(ctx context.Context, arg SetResultPRFlagsParams) error
+	UpdateAthlete(ctx context.Context, arg UpdateAthleteParams) error
+	UpdateCourse(ctx context.Context, arg UpdateCourseParams) error
+	UpdateMeet(ctx context.Context, arg UpdateMeetParams) error
+	UpdateRace(ctx context.Context, arg UpdateRaceParams) error
+	UpdateResult(ctx context.Context, arg UpdateResultParams) error
+	UpdateRosterGrade(ctx context.Context, arg UpdateRosterGradeParams) error
+	UpdateSeason(ctx context.Context, arg UpdateSeasonParams) error
+	UpdateTeam(ctx context.Context, arg UpdateTeamParams) error
+	UpsertRosterEntry(ctx context.Context, arg UpsertRosterEntryParams) error
+}
+
+var _ Querier = (*Queries)(nil)
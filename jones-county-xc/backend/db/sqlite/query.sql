@@ -0,0 +1,491 @@
+-- The SQLite version of db/query.sql: the same queries under the same
+-- names, so store.NewSQLite can hand back the dbsqlc row types. Keep the
+-- two in step.
+
+-- name: ListAthletes :many
+SELECT a.id, a.team_id, a.name, sa.grade, a.graduation_year, a.personal_record_ms, COALESCE(a.events, '') AS events
+FROM athletes a
+JOIN season_athletes sa ON sa.athlete_id = a.id
+WHERE a.team_id = ? AND sa.season_id = ?
+ORDER BY sa.grade, a.name;
+
+-- name: ListAthletesAllSeasons :many
+SELECT id, team_id, name, graduation_year, personal_record_ms, COALESCE(events, '') AS events
+FROM athletes
+WHERE team_id = ?
+ORDER BY graduation_year DESC, name;
+
+-- name: GetAthleteByID :one
+SELECT id, team_id, name, graduation_year, personal_record_ms, COALESCE(events, '') AS events
+FROM athletes
+WHERE id = ?;
+
+-- name: ListSeasons :many
+SELECT id, year, name, start_date, end_date
+FROM seasons
+ORDER BY year DESC;
+
+-- name: GetSeasonByYear :one
+SELECT id, year, name, start_date, end_date
+FROM seasons
+WHERE year = ?;
+
+-- name: CreateSeason :execresult
+INSERT INTO seasons (year, name, start_date, end_date)
+VALUES (?, ?, ?, ?);
+
+-- name: UpdateSeason :exec
+UPDATE seasons SET name = ?
+WHERE id = ?;
+
+-- name: ListRoster :many
+SELECT sa.athlete_id, sa.grade, a.graduation_year
+FROM season_athletes sa
+JOIN athletes a ON sa.athlete_id = a.id
+WHERE sa.season_id = ?;
+
+-- name: UpsertRosterEntry :exec
+INSERT INTO season_athletes (season_id, athlete_id, grade)
+VALUES (?, ?, ?)
+ON CONFLICT (season_id, athlete_id) DO UPDATE SET grade = excluded.grade;
+
+-- name: UpdateRosterGrade :exec
+UPDATE season_athletes
+SET grade = ?
+WHERE season_id = ? AND athlete_id = ?;
+
+-- name: DeleteRosterEntry :execrows
+DELETE FROM season_athletes
+WHERE season_id = ? AND athlete_id = ?;
+
+-- name: ListTeams :many
+SELECT id, name, COALESCE(short_name, '') AS short_name, is_home
+FROM teams
+ORDER BY is_home DESC, name;
+
+-- name: GetTeamByID :one
+SELECT id, name, COALESCE(short_name, '') AS short_name, is_home
+FROM teams
+WHERE id = ?;
+
+-- name: GetHomeTeam :one
+SELECT id, name, COALESCE(short_name, '') AS short_name, is_home
+FROM teams
+WHERE is_home
+ORDER BY id
+LIMIT 1;
+
+-- name: CreateTeam :execresult
+INSERT INTO teams (name, short_name)
+VALUES (?, ?);
+
+-- name: UpdateTeam :exec
+UPDATE teams
+SET name = ?, short_name = ?
+WHERE id = ?;
+
+-- name: DeleteTeam :exec
+DELETE FROM teams
+WHERE id = ?;
+
+-- name: CountTeamReferences :one
+SELECT (SELECT COUNT(*) FROM athletes a WHERE a.team_id = sqlc.arg(id))
+     + (SELECT COUNT(*) FROM results r WHERE r.team_id = sqlc.arg(id)) AS refs;
+
+-- name: ListMeets :many
+SELECT id, name, date, COALESCE(location, '') AS location, COALESCE(description, '') AS description, cancelled, course_id
+FROM meets
+WHERE date BETWEEN sqlc.arg(start_date) AND sqlc.arg(end_date)
+ORDER BY date;
+
+-- name: GetMeetByID :one
+SELECT id, name, date, COALESCE(location, '') AS location, COALESCE(description, '') AS description, cancelled, course_id
+FROM meets
+WHERE id = ?;
+
+-- name: CreateMeet :execresult
+INSERT INTO meets (name, date, location, description, cancelled, course_id)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateMeet :exec
+UPDATE meets
+SET name = ?, date = ?, location = ?, description = ?, cancelled = ?, course_id = ?
+WHERE id = ?;
+
+-- name: ListCourses :many
+SELECT id, name, COALESCE(address, '') AS address, distance_m, COALESCE(surface, '') AS surface, elevation_gain_m, COALESCE(notes, '') AS notes
+FROM courses
+ORDER BY name;
+
+-- name: GetCourseByID :one
+SELECT id, name, COALESCE(address, '') AS address, distance_m, COALESCE(surface, '') AS surface, elevation_gain_m, COALESCE(notes, '') AS notes
+FROM courses
+WHERE id = ?;
+
+-- name: CreateCourse :execresult
+INSERT INTO courses (name, address, distance_m, surface, elevation_gain_m, notes)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateCourse :exec
+UPDATE courses
+SET name = ?, address = ?, distance_m = ?, surface = ?, elevation_gain_m = ?, notes = ?
+WHERE id = ?;
+
+-- name: DeleteCourse :exec
+DELETE FROM courses
+WHERE id = ?;
+
+-- name: CountCourseReferences :one
+SELECT (SELECT COUNT(*) FROM meets m WHERE m.course_id = sqlc.arg(id))
+     + (SELECT COUNT(*) FROM races ra WHERE ra.course_id = sqlc.arg(id)) AS refs;
+
+-- name: ListCourseResults :many
+-- Every finish on a course, fastest first within each gender and distance.
+-- A race is on the course if it names it, or names no course and its meet
+-- does.
+SELECT r.athlete_id, COALESCE(a.name, r.runner_name, '') AS runner_name, t.name AS team_name,
+       m.name AS meet_name, m.date, ra.id AS race_id, ra.gender, ra.distance_m, r.time_ms, r.place
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN teams t ON r.team_id = t.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE ra.course_id = sqlc.arg(course_id)
+   OR (ra.course_id IS NULL AND m.course_id = sqlc.arg(course_id))
+ORDER BY ra.gender, ra.distance_m, r.time_ms;
+
+-- name: DeleteMeet :exec
+DELETE FROM meets
+WHERE id = ?;
+
+-- name: ListRaceFinishers :many
+SELECT r.athlete_id, COALESCE(a.name, r.runner_name, '') AS runner_name, t.name AS team_name, r.place, r.time_ms
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN teams t ON r.team_id = t.id
+WHERE r.race_id = ?
+ORDER BY r.place;
+
+-- name: CountResultsCreatedSince :one
+SELECT COUNT(*)
+FROM results
+WHERE created_at >= ?;
+
+-- name: CountResultsByMeet :one
+SELECT COUNT(*)
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ?;
+
+-- name: CountResultsByRace :one
+SELECT COUNT(*)
+FROM results
+WHERE race_id = ?;
+
+-- name: ListResultsByMeet :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ?
+ORDER BY r.race_id, r.place;
+
+-- name: ListResultsByMeetAndTeam :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ? AND r.team_id = ?
+ORDER BY r.race_id, r.place;
+
+-- name: ListResultsByRace :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE r.race_id = ?
+ORDER BY r.place;
+
+-- name: ListResultsByRaceAndTeam :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE r.race_id = ? AND r.team_id = ?
+ORDER BY r.place;
+
+-- name: ListRacesByMeet :many
+SELECT id, meet_id, gender, division, distance_m, start_time, course_id
+FROM races
+WHERE meet_id = ?
+ORDER BY start_time IS NULL, start_time, id;
+
+-- name: GetRaceByID :one
+SELECT id, meet_id, gender, division, distance_m, start_time, course_id
+FROM races
+WHERE id = ?;
+
+-- name: CreateRace :execresult
+INSERT INTO races (meet_id, gender, division, distance_m, start_time, course_id)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateRace :exec
+UPDATE races
+SET gender = ?, division = ?, distance_m = ?, start_time = ?, course_id = ?
+WHERE id = ?;
+
+-- name: DeleteRace :exec
+DELETE FROM races
+WHERE id = ?;
+
+-- name: CountAthletes :one
+SELECT COUNT(*) FROM athletes;
+
+-- name: CreateAthlete :execresult
+INSERT INTO athletes (team_id, name, graduation_year, personal_record_ms, manual_pr_ms)
+VALUES (?, ?, ?, sqlc.arg(manual_pr_ms), sqlc.arg(manual_pr_ms));
+
+-- name: CreateResult :execresult
+INSERT INTO results (athlete_id, team_id, runner_name, race_id, time_ms, place, created_at)
+VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP);
+
+-- name: GetResultByID :one
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE r.id = ?;
+
+-- name: UpdateResult :exec
+UPDATE results
+SET athlete_id = ?, team_id = ?, runner_name = ?, race_id = ?, time_ms = ?, place = ?
+WHERE id = ?;
+
+-- name: DeleteResult :exec
+DELETE FROM results
+WHERE id = ?;
+
+-- name: DeleteResultsByRace :exec
+DELETE FROM results
+WHERE race_id = ?;
+
+-- name: CreateResultSplit :exec
+INSERT INTO result_splits (result_id, split_index, distance_m, elapsed_ms)
+VALUES (?, ?, ?, ?);
+
+-- name: DeleteResultSplits :exec
+DELETE FROM result_splits
+WHERE result_id = ?;
+
+-- name: ListSplitsByResult :many
+SELECT result_id, split_index, distance_m, elapsed_ms
+FROM result_splits
+WHERE result_id = ?
+ORDER BY split_index;
+
+-- name: ListSplitsByMeet :many
+SELECT s.result_id, s.split_index, s.distance_m, s.elapsed_ms
+FROM result_splits s
+JOIN results r ON s.result_id = r.id
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ?
+ORDER BY s.result_id, s.split_index;
+
+-- name: ListSplitsByRace :many
+SELECT s.result_id, s.split_index, s.distance_m, s.elapsed_ms
+FROM result_splits s
+JOIN results r ON s.result_id = r.id
+WHERE r.race_id = ?
+ORDER BY s.result_id, s.split_index;
+
+-- name: ListSplitsByAthlete :many
+SELECT s.result_id, s.split_index, s.distance_m, s.elapsed_ms
+FROM result_splits s
+JOIN results r ON s.result_id = r.id
+WHERE r.athlete_id = ?
+ORDER BY s.result_id, s.split_index;
+
+-- name: ListAthleteProgression :many
+SELECT r.id, m.id AS meet_id, m.name AS meet_name, m.date, ra.id AS race_id, ra.gender, ra.division, ra.distance_m,
+       COALESCE(ra.course_id, m.course_id, 0) AS course_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE r.athlete_id = ?
+ORDER BY m.date, ra.start_time, r.id;
+
+-- name: ListRaceAthleteIDs :many
+SELECT athlete_id
+FROM results
+WHERE race_id = ? AND athlete_id IS NOT NULL;
+
+-- name: ListMeetAthleteIDs :many
+SELECT DISTINCT r.athlete_id
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ? AND r.athlete_id IS NOT NULL;
+
+-- name: GetAthleteManualPR :one
+SELECT manual_pr_ms
+FROM athletes
+WHERE id = ?;
+
+-- name: SetAthletePR :exec
+UPDATE athletes
+SET personal_record_ms = ?
+WHERE id = ?;
+
+-- name: DeletePersonalRecords :exec
+DELETE FROM personal_records
+WHERE athlete_id = ?;
+
+-- name: CreatePersonalRecord :exec
+INSERT INTO personal_records (athlete_id, distance_m, course_id, result_id, time_ms)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ClearResultPRFlags :exec
+UPDATE results
+SET is_pr = FALSE, is_course_pr = FALSE
+WHERE athlete_id = ?;
+
+-- name: SetResultPRFlags :exec
+UPDATE results
+SET is_pr = ?, is_course_pr = ?
+WHERE id = ?;
+
+-- name: ListPersonalRecords :many
+-- An athlete's records, overall before per-course within each distance.
+-- sqlc's SQLite engine takes the LEFT JOINed c.name to be NOT NULL, so it
+-- comes back as '' and store.NewSQLite restores the NULL.
+SELECT p.distance_m, p.course_id, COALESCE(c.name, '') AS course_name, p.time_ms, p.result_id,
+       m.id AS meet_id, m.name AS meet_name, m.date
+FROM personal_records p
+LEFT JOIN courses c ON p.course_id = c.id
+JOIN results r ON p.result_id = r.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE p.athlete_id = ?
+ORDER BY p.distance_m, p.course_id IS NOT NULL, c.name;
+
+-- name: ListAthleteTeamsIn :many
+SELECT id, team_id
+FROM athletes
+WHERE id IN (sqlc.slice('ids'));
+
+-- name: ListTeamIDsIn :many
+SELECT id
+FROM teams
+WHERE id IN (sqlc.slice('ids'));
+
+-- name: UpdateAthlete :exec
+UPDATE athletes
+SET team_id = ?, name = ?, graduation_year = ?, manual_pr_ms = ?
+WHERE id = ?;
+
+-- name: DeleteAthlete :execrows
+DELETE FROM athletes
+WHERE id = ?;
+
+-- name: ListFastestTimes :many
+SELECT a.name AS athlete_name, m.name AS meet_name, r.time_ms, r.place
+FROM results r
+JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE m.date BETWEEN sqlc.arg(start_date) AND sqlc.arg(end_date)
+  AND ra.distance_m = sqlc.arg(distance_m)
+ORDER BY r.time_ms
+LIMIT 10;
+
+-- name: ListFastestAthletes :many
+SELECT id, team_id, name, graduation_year, personal_record_ms, COALESCE(events, '') AS events
+FROM athletes
+WHERE personal_record_ms IS NOT NULL
+ORDER BY personal_record_ms
+LIMIT 5;
+
+-- name: ListFastestAthletesInSeason :many
+SELECT a.id, a.team_id, a.name, a.graduation_year, a.personal_record_ms, COALESCE(a.events, '') AS events
+FROM athletes a
+JOIN season_athletes sa ON sa.athlete_id = a.id
+WHERE a.personal_record_ms IS NOT NULL AND sa.season_id = ?
+ORDER BY a.personal_record_ms
+LIMIT 5;
+
+-- name: ListLatestMeetResults :many
+-- Our finishes at the most recent meet between the two dates.
+SELECT a.name AS athlete_name, m.name AS meet_name, r.time_ms, r.place
+FROM results r
+JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE m.date = (
+    -- Not BETWEEN, whose arguments sqlc binds twice inside a subquery
+    SELECT MAX(latest.date) FROM meets latest WHERE latest.date >= sqlc.arg(start_date) AND latest.date <= sqlc.arg(end_date)
+)
+ORDER BY r.place;
+
+-- name: ListResultsBetween :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE m.date BETWEEN sqlc.arg(start_date) AND sqlc.arg(end_date)
+ORDER BY m.date, r.race_id, r.place;
+
+-- name: ListAthleteHistory :many
+SELECT r.id, m.id AS meet_id, m.name AS meet_name, m.date, ra.id AS race_id, ra.gender, ra.division, ra.distance_m,
+       COALESCE(ra.course_id, m.course_id, 0) AS course_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+-- The parentheses keep sqlc's SQLite parser from losing the dates.
+WHERE r.athlete_id = sqlc.arg(athlete_id) AND (m.date BETWEEN sqlc.arg(start_date) AND sqlc.arg(end_date))
+ORDER BY m.date, ra.start_time, r.id;
+
+-- name: CreateUser :execresult
+INSERT INTO users (username, password_hash, role)
+VALUES (?, ?, ?);
+
+-- name: GetUserByUsername :one
+SELECT id, username, password_hash, role
+FROM users
+WHERE username = ?;
+
+-- name: ListUsers :many
+SELECT id, username, role
+FROM users
+ORDER BY username;
+
+-- name: DeleteUser :execrows
+DELETE FROM users
+WHERE id = ?;
+
+-- name: LinkUserAthlete :exec
+INSERT INTO user_athletes (user_id, athlete_id)
+VALUES (?, ?);
+
+-- name: ListUserAthleteIDs :many
+SELECT athlete_id
+FROM user_athletes
+WHERE user_id = ?
+ORDER BY athlete_id;
+
+-- name: CreateSession :exec
+INSERT INTO sessions (token_hash, user_id, expires_at)
+VALUES (?, ?, ?);
+
+-- name: GetSessionUser :one
+SELECT u.id, u.username, u.role, s.expires_at
+FROM sessions s
+JOIN users u ON s.user_id = u.id
+WHERE s.token_hash = ? AND s.expires_at > ?;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE token_hash = ?;
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions
+WHERE expires_at <= ?;
@@ -0,0 +1,2526 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: query.sql
+
+package sqlitesqlc
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+	"jones-county-xc/backend/racetime"
+)
+
+const clearResultPRFlags = `-- name: ClearResultPRFlags :exec
+UPDATE results
+SET is_pr = FALSE, is_course_pr = FALSE
+WHERE athlete_id = ?
+`
+
+func (q *Queries) ClearResultPRFlags(ctx context.Context, athleteID sql.NullInt32) error {
+	_, err := q.db.ExecContext(ctx, clearResultPRFlags, athleteID)
+	return err
+}
+
+const countAthletes = `-- name: CountAthletes :one
+SELECT COUNT(*) FROM athletes
+`
+
+func (q *Queries) CountAthletes(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAthletes)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCourseReferences = `-- name: CountCourseReferences :one
+SELECT (SELECT COUNT(*) FROM meets m WHERE m.course_id = ?1)
+     + (SELECT COUNT(*) FROM races ra WHERE ra.course_id = ?1) AS refs
+`
+
+func (q *Queries) CountCourseReferences(ctx context.Context, id sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCourseReferences, id)
+	var refs int64
+	err := row.Scan(&refs)
+	return refs, err
+}
+
+const countResultsByMeet = `-- name: CountResultsByMeet :one
+SELECT COUNT(*)
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ?
+`
+
+func (q *Queries) CountResultsByMeet(ctx context.Context, meetID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResultsByMeet, meetID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countResultsByRace = `-- name: CountResultsByRace :one
+SELECT COUNT(*)
+FROM results
+WHERE race_id = ?
+`
+
+func (q *Queries) CountResultsByRace(ctx context.Context, raceID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResultsByRace, raceID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countResultsCreatedSince = `-- name: CountResultsCreatedSince :one
+SELECT COUNT(*)
+FROM results
+WHERE created_at >= ?
+`
+
+func (q *Queries) CountResultsCreatedSince(ctx context.Context, createdAt time.Time) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResultsCreatedSince, createdAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTeamReferences = `-- name: CountTeamReferences :one
+SELECT (SELECT COUNT(*) FROM athletes a WHERE a.team_id = ?1)
+     + (SELECT COUNT(*) FROM results r WHERE r.team_id = ?1) AS refs
+`
+
+func (q *Queries) CountTeamReferences(ctx context.Context, id int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTeamReferences, id)
+	var refs int64
+	err := row.Scan(&refs)
+	return refs, err
+}
+
+const createAthlete = `-- name: CreateAthlete :execresult
+INSERT INTO athletes (team_id, name, graduation_year, personal_record_ms, manual_pr_ms)
+VALUES (?, ?, ?, ?4, ?4)
+`
+
+type CreateAthleteParams struct {
+	TeamID         int32
+	Name           string
+	GraduationYear int32
+	ManualPrMs     racetime.NullDuration
+}
+
+func (q *Queries) CreateAthlete(ctx context.Context, arg CreateAthleteParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createAthlete,
+		arg.TeamID,
+		arg.Name,
+		arg.GraduationYear,
+		arg.ManualPrMs,
+	)
+}
+
+const createCourse = `-- name: CreateCourse :execresult
+INSERT INTO courses (name, address, distance_m, surface, elevation_gain_m, notes)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateCourseParams struct {
+	Name           string
+	Address        sql.NullString
+	DistanceM      int32
+	Surface        sql.NullString
+	ElevationGainM sql.NullInt32
+	Notes          sql.NullString
+}
+
+func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createCourse,
+		arg.Name,
+		arg.Address,
+		arg.DistanceM,
+		arg.Surface,
+		arg.ElevationGainM,
+		arg.Notes,
+	)
+}
+
+const createMeet = `-- name: CreateMeet :execresult
+INSERT INTO meets (name, date, location, description, cancelled, course_id)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateMeetParams struct {
+	Name        string
+	Date        time.Time
+	Location    sql.NullString
+	Description sql.NullString
+	Cancelled   bool
+	CourseID    sql.NullInt32
+}
+
+func (q *Queries) CreateMeet(ctx context.Context, arg CreateMeetParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createMeet,
+		arg.Name,
+		arg.Date,
+		arg.Location,
+		arg.Description,
+		arg.Cancelled,
+		arg.CourseID,
+	)
+}
+
+const createPersonalRecord = `-- name: CreatePersonalRecord :exec
+INSERT INTO personal_records (athlete_id, distance_m, course_id, result_id, time_ms)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreatePersonalRecordParams struct {
+	AthleteID int32
+	DistanceM int32
+	CourseID  sql.NullInt32
+	ResultID  int32
+	TimeMs    racetime.Duration
+}
+
+func (q *Queries) CreatePersonalRecord(ctx context.Context, arg CreatePersonalRecordParams) error {
+	_, err := q.db.ExecContext(ctx, createPersonalRecord,
+		arg.AthleteID,
+		arg.DistanceM,
+		arg.CourseID,
+		arg.ResultID,
+		arg.TimeMs,
+	)
+	return err
+}
+
+const createRace = `-- name: CreateRace :execresult
+INSERT INTO races (meet_id, gender, division, distance_m, start_time, course_id)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateRaceParams struct {
+	MeetID    int32
+	Gender    dbsqlc.RacesGender
+	Division  dbsqlc.RacesDivision
+	DistanceM int32
+	StartTime sql.NullString
+	CourseID  sql.NullInt32
+}
+
+func (q *Queries) CreateRace(ctx context.Context, arg CreateRaceParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createRace,
+		arg.MeetID,
+		arg.Gender,
+		arg.Division,
+		arg.DistanceM,
+		arg.StartTime,
+		arg.CourseID,
+	)
+}
+
+const createResult = `-- name: CreateResult :execresult
+INSERT INTO results (athlete_id, team_id, runner_name, race_id, time_ms, place, created_at)
+VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+`
+
+type CreateResultParams struct {
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName sql.NullString
+	RaceID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+}
+
+func (q *Queries) CreateResult(ctx context.Context, arg CreateResultParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createResult,
+		arg.AthleteID,
+		arg.TeamID,
+		arg.RunnerName,
+		arg.RaceID,
+		arg.TimeMs,
+		arg.Place,
+	)
+}
+
+const createResultSplit = `-- name: CreateResultSplit :exec
+INSERT INTO result_splits (result_id, split_index, distance_m, elapsed_ms)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateResultSplitParams struct {
+	ResultID   int32
+	SplitIndex int32
+	DistanceM  int32
+	ElapsedMs  racetime.Duration
+}
+
+func (q *Queries) CreateResultSplit(ctx context.Context, arg CreateResultSplitParams) error {
+	_, err := q.db.ExecContext(ctx, createResultSplit,
+		arg.ResultID,
+		arg.SplitIndex,
+		arg.DistanceM,
+		arg.ElapsedMs,
+	)
+	return err
+}
+
+const createSeason = `-- name: CreateSeason :execresult
+INSERT INTO seasons (year, name, start_date, end_date)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateSeasonParams struct {
+	Year      int32
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (q *Queries) CreateSeason(ctx context.Context, arg CreateSeasonParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createSeason,
+		arg.Year,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
+	)
+}
+
+const createSession = `-- name: CreateSession :exec
+INSERT INTO sessions (token_hash, user_id, expires_at)
+VALUES (?, ?, ?)
+`
+
+type CreateSessionParams struct {
+	TokenHash string
+	UserID    int32
+	ExpiresAt time.Time
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
+	_, err := q.db.ExecContext(ctx, createSession, arg.TokenHash, arg.UserID, arg.ExpiresAt)
+	return err
+}
+
+const createTeam = `-- name: CreateTeam :execresult
+INSERT INTO teams (name, short_name)
+VALUES (?, ?)
+`
+
+type CreateTeamParams struct {
+	Name      string
+	ShortName sql.NullString
+}
+
+func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createTeam, arg.Name, arg.ShortName)
+}
+
+const createUser = `-- name: CreateUser :execresult
+INSERT INTO users (username, password_hash, role)
+VALUES (?, ?, ?)
+`
+
+type CreateUserParams struct {
+	Username     string
+	PasswordHash string
+	Role         dbsqlc.UsersRole
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createUser, arg.Username, arg.PasswordHash, arg.Role)
+}
+
+const deleteAthlete = `-- name: DeleteAthlete :execrows
+DELETE FROM athletes
+WHERE id = ?
+`
+
+func (q *Queries) DeleteAthlete(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAthlete, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCourse = `-- name: DeleteCourse :exec
+DELETE FROM courses
+WHERE id = ?
+`
+
+func (q *Queries) DeleteCourse(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteCourse, id)
+	return err
+}
+
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions
+WHERE expires_at <= ?
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredSessions, expiresAt)
+	return err
+}
+
+const deleteMeet = `-- name: DeleteMeet :exec
+DELETE FROM meets
+WHERE id = ?
+`
+
+func (q *Queries) DeleteMeet(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteMeet, id)
+	return err
+}
+
+const deletePersonalRecords = `-- name: DeletePersonalRecords :exec
+DELETE FROM personal_records
+WHERE athlete_id = ?
+`
+
+func (q *Queries) DeletePersonalRecords(ctx context.Context, athleteID int32) error {
+	_, err := q.db.ExecContext(ctx, deletePersonalRecords, athleteID)
+	return err
+}
+
+const deleteRace = `-- name: DeleteRace :exec
+DELETE FROM races
+WHERE id = ?
+`
+
+func (q *Queries) DeleteRace(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteRace, id)
+	return err
+}
+
+const deleteResult = `-- name: DeleteResult :exec
+DELETE FROM results
+WHERE id = ?
+`
+
+func (q *Queries) DeleteResult(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteResult, id)
+	return err
+}
+
+const deleteResultSplits = `-- name: DeleteResultSplits :exec
+DELETE FROM result_splits
+WHERE result_id = ?
+`
+
+func (q *Queries) DeleteResultSplits(ctx context.Context, resultID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteResultSplits, resultID)
+	return err
+}
+
+const deleteResultsByRace = `-- name: DeleteResultsByRace :exec
+DELETE FROM results
+WHERE race_id = ?
+`
+
+func (q *Queries) DeleteResultsByRace(ctx context.Context, raceID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteResultsByRace, raceID)
+	return err
+}
+
+const deleteRosterEntry = `-- name: DeleteRosterEntry :execrows
+DELETE FROM season_athletes
+WHERE season_id = ? AND athlete_id = ?
+`
+
+type DeleteRosterEntryParams struct {
+	SeasonID  int32
+	AthleteID int32
+}
+
+func (q *Queries) DeleteRosterEntry(ctx context.Context, arg DeleteRosterEntryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRosterEntry, arg.SeasonID, arg.AthleteID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE token_hash = ?
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := q.db.ExecContext(ctx, deleteSession, tokenHash)
+	return err
+}
+
+const deleteTeam = `-- name: DeleteTeam :exec
+DELETE FROM teams
+WHERE id = ?
+`
+
+func (q *Queries) DeleteTeam(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTeam, id)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+DELETE FROM users
+WHERE id = ?
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAthleteByID = `-- name: GetAthleteByID :one
+SELECT id, team_id, name, graduation_year, personal_record_ms, COALESCE(events, '') AS events
+FROM athletes
+WHERE id = ?
+`
+
+type GetAthleteByIDRow struct {
+	ID               int32
+	TeamID           int32
+	Name             string
+	GraduationYear   int32
+	PersonalRecordMs racetime.NullDuration
+	Events           string
+}
+
+func (q *Queries) GetAthleteByID(ctx context.Context, id int32) (GetAthleteByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getAthleteByID, id)
+	var i GetAthleteByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Name,
+		&i.GraduationYear,
+		&i.PersonalRecordMs,
+		&i.Events,
+	)
+	return i, err
+}
+
+const getAthleteManualPR = `-- name: GetAthleteManualPR :one
+SELECT manual_pr_ms
+FROM athletes
+WHERE id = ?
+`
+
+func (q *Queries) GetAthleteManualPR(ctx context.Context, id int32) (racetime.NullDuration, error) {
+	row := q.db.QueryRowContext(ctx, getAthleteManualPR, id)
+	var manual_pr_ms racetime.NullDuration
+	err := row.Scan(&manual_pr_ms)
+	return manual_pr_ms, err
+}
+
+const getCourseByID = `-- name: GetCourseByID :one
+SELECT id, name, COALESCE(address, '') AS address, distance_m, COALESCE(surface, '') AS surface, elevation_gain_m, COALESCE(notes, '') AS notes
+FROM courses
+WHERE id = ?
+`
+
+type GetCourseByIDRow struct {
+	ID             int32
+	Name           string
+	Address        string
+	DistanceM      int32
+	Surface        string
+	ElevationGainM sql.NullInt32
+	Notes          string
+}
+
+func (q *Queries) GetCourseByID(ctx context.Context, id int32) (GetCourseByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getCourseByID, id)
+	var i GetCourseByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.DistanceM,
+		&i.Surface,
+		&i.ElevationGainM,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const getHomeTeam = `-- name: GetHomeTeam :one
+SELECT id, name, COALESCE(short_name, '') AS short_name, is_home
+FROM teams
+WHERE is_home
+ORDER BY id
+LIMIT 1
+`
+
+type GetHomeTeamRow struct {
+	ID        int32
+	Name      string
+	ShortName string
+	IsHome    bool
+}
+
+func (q *Queries) GetHomeTeam(ctx context.Context) (GetHomeTeamRow, error) {
+	row := q.db.QueryRowContext(ctx, getHomeTeam)
+	var i GetHomeTeamRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ShortName,
+		&i.IsHome,
+	)
+	return i, err
+}
+
+const getMeetByID = `-- name: GetMeetByID :one
+SELECT id, name, date, COALESCE(location, '') AS location, COALESCE(description, '') AS description, cancelled, course_id
+FROM meets
+WHERE id = ?
+`
+
+type GetMeetByIDRow struct {
+	ID          int32
+	Name        string
+	Date        time.Time
+	Location    string
+	Description string
+	Cancelled   bool
+	CourseID    sql.NullInt32
+}
+
+func (q *Queries) GetMeetByID(ctx context.Context, id int32) (GetMeetByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getMeetByID, id)
+	var i GetMeetByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Date,
+		&i.Location,
+		&i.Description,
+		&i.Cancelled,
+		&i.CourseID,
+	)
+	return i, err
+}
+
+const getRaceByID = `-- name: GetRaceByID :one
+SELECT id, meet_id, gender, division, distance_m, start_time, course_id
+FROM races
+WHERE id = ?
+`
+
+func (q *Queries) GetRaceByID(ctx context.Context, id int32) (Race, error) {
+	row := q.db.QueryRowContext(ctx, getRaceByID, id)
+	var i Race
+	err := row.Scan(
+		&i.ID,
+		&i.MeetID,
+		&i.Gender,
+		&i.Division,
+		&i.DistanceM,
+		&i.StartTime,
+		&i.CourseID,
+	)
+	return i, err
+}
+
+const getResultByID = `-- name: GetResultByID :one
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE r.id = ?
+`
+
+type GetResultByIDRow struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) GetResultByID(ctx context.Context, id int32) (GetResultByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getResultByID, id)
+	var i GetResultByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.AthleteID,
+		&i.TeamID,
+		&i.RunnerName,
+		&i.RaceID,
+		&i.MeetID,
+		&i.TimeMs,
+		&i.Place,
+		&i.IsPr,
+		&i.IsCoursePr,
+	)
+	return i, err
+}
+
+const getSeasonByYear = `-- name: GetSeasonByYear :one
+SELECT id, year, name, start_date, end_date
+FROM seasons
+WHERE year = ?
+`
+
+func (q *Queries) GetSeasonByYear(ctx context.Context, year int32) (Season, error) {
+	row := q.db.QueryRowContext(ctx, getSeasonByYear, year)
+	var i Season
+	err := row.Scan(
+		&i.ID,
+		&i.Year,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const getSessionUser = `-- name: GetSessionUser :one
+SELECT u.id, u.username, u.role, s.expires_at
+FROM sessions s
+JOIN users u ON s.user_id = u.id
+WHERE s.token_hash = ? AND s.expires_at > ?
+`
+
+type GetSessionUserParams struct {
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type GetSessionUserRow struct {
+	ID        int32
+	Username  string
+	Role      dbsqlc.UsersRole
+	ExpiresAt time.Time
+}
+
+func (q *Queries) GetSessionUser(ctx context.Context, arg GetSessionUserParams) (GetSessionUserRow, error) {
+	row := q.db.QueryRowContext(ctx, getSessionUser, arg.TokenHash, arg.ExpiresAt)
+	var i GetSessionUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Role,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getTeamByID = `-- name: GetTeamByID :one
+SELECT id, name, COALESCE(short_name, '') AS short_name, is_home
+FROM teams
+WHERE id = ?
+`
+
+type GetTeamByIDRow struct {
+	ID        int32
+	Name      string
+	ShortName string
+	IsHome    bool
+}
+
+func (q *Queries) GetTeamByID(ctx context.Context, id int32) (GetTeamByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getTeamByID, id)
+	var i GetTeamByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ShortName,
+		&i.IsHome,
+	)
+	return i, err
+}
+
+const getUserByUsername = `-- name: GetUserByUsername :one
+SELECT id, username, password_hash, role
+FROM users
+WHERE username = ?
+`
+
+type GetUserByUsernameRow struct {
+	ID           int32
+	Username     string
+	PasswordHash string
+	Role         dbsqlc.UsersRole
+}
+
+func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByUsername, username)
+	var i GetUserByUsernameRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Role,
+	)
+	return i, err
+}
+
+const linkUserAthlete = `-- name: LinkUserAthlete :exec
+INSERT INTO user_athletes (user_id, athlete_id)
+VALUES (?, ?)
+`
+
+type LinkUserAthleteParams struct {
+	UserID    int32
+	AthleteID int32
+}
+
+func (q *Queries) LinkUserAthlete(ctx context.Context, arg LinkUserAthleteParams) error {
+	_, err := q.db.ExecContext(ctx, linkUserAthlete, arg.UserID, arg.AthleteID)
+	return err
+}
+
+const listAthleteHistory = `-- name: ListAthleteHistory :many
+SELECT r.id, m.id AS meet_id, m.name AS meet_name, m.date, ra.id AS race_id, ra.gender, ra.division, ra.distance_m,
+       COALESCE(ra.course_id, m.course_id, 0) AS course_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE r.athlete_id = ?1 AND (m.date BETWEEN ?2 AND ?3)
+ORDER BY m.date, ra.start_time, r.id
+`
+
+type ListAthleteHistoryParams struct {
+	AthleteID sql.NullInt32
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type ListAthleteHistoryRow struct {
+	ID         int32
+	MeetID     int32
+	MeetName   string
+	Date       time.Time
+	RaceID     int32
+	Gender     dbsqlc.RacesGender
+	Division   dbsqlc.RacesDivision
+	DistanceM  int32
+	CourseID   int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+// The parentheses keep sqlc's SQLite parser from losing the dates.
+func (q *Queries) ListAthleteHistory(ctx context.Context, arg ListAthleteHistoryParams) ([]ListAthleteHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAthleteHistory, arg.AthleteID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAthleteHistoryRow
+	for rows.Next() {
+		var i ListAthleteHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MeetID,
+			&i.MeetName,
+			&i.Date,
+			&i.RaceID,
+			&i.Gender,
+			&i.Division,
+			&i.DistanceM,
+			&i.CourseID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAthleteProgression = `-- name: ListAthleteProgression :many
+SELECT r.id, m.id AS meet_id, m.name AS meet_name, m.date, ra.id AS race_id, ra.gender, ra.division, ra.distance_m,
+       COALESCE(ra.course_id, m.course_id, 0) AS course_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE r.athlete_id = ?
+ORDER BY m.date, ra.start_time, r.id
+`
+
+type ListAthleteProgressionRow struct {
+	ID         int32
+	MeetID     int32
+	MeetName   string
+	Date       time.Time
+	RaceID     int32
+	Gender     dbsqlc.RacesGender
+	Division   dbsqlc.RacesDivision
+	DistanceM  int32
+	CourseID   int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) ListAthleteProgression(ctx context.Context, athleteID sql.NullInt32) ([]ListAthleteProgressionRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAthleteProgression, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAthleteProgressionRow
+	for rows.Next() {
+		var i ListAthleteProgressionRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MeetID,
+			&i.MeetName,
+			&i.Date,
+			&i.RaceID,
+			&i.Gender,
+			&i.Division,
+			&i.DistanceM,
+			&i.CourseID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAthleteTeamsIn = `-- name: ListAthleteTeamsIn :many
+SELECT id, team_id
+FROM athletes
+WHERE id IN (/*SLICE:ids*/?)
+`
+
+type ListAthleteTeamsInRow struct {
+	ID     int32
+	TeamID int32
+}
+
+func (q *Queries) ListAthleteTeamsIn(ctx context.Context, ids []int32) ([]ListAthleteTeamsInRow, error) {
+	query := listAthleteTeamsIn
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAthleteTeamsInRow
+	for rows.Next() {
+		var i ListAthleteTeamsInRow
+		if err := rows.Scan(&i.ID, &i.TeamID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAthletes = `-- name: ListAthletes :many
+
+SELECT a.id, a.team_id, a.name, sa.grade, a.graduation_year, a.personal_record_ms, COALESCE(a.events, '') AS events
+FROM athletes a
+JOIN season_athletes sa ON sa.athlete_id = a.id
+WHERE a.team_id = ? AND sa.season_id = ?
+ORDER BY sa.grade, a.name
+`
+
+type ListAthletesParams struct {
+	TeamID   int32
+	SeasonID int32
+}
+
+type ListAthletesRow struct {
+	ID               int32
+	TeamID           int32
+	Name             string
+	Grade            int32
+	GraduationYear   int32
+	PersonalRecordMs racetime.NullDuration
+	Events           string
+}
+
+// The SQLite version of db/query.sql: the same queries under the same
+// names, so store.NewSQLite can hand back the dbsqlc row types. Keep the
+// two in step.
+func (q *Queries) ListAthletes(ctx context.Context, arg ListAthletesParams) ([]ListAthletesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAthletes, arg.TeamID, arg.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAthletesRow
+	for rows.Next() {
+		var i ListAthletesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.Grade,
+			&i.GraduationYear,
+			&i.PersonalRecordMs,
+			&i.Events,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAthletesAllSeasons = `-- name: ListAthletesAllSeasons :many
+SELECT id, team_id, name, graduation_year, personal_record_ms, COALESCE(events, '') AS events
+FROM athletes
+WHERE team_id = ?
+ORDER BY graduation_year DESC, name
+`
+
+type ListAthletesAllSeasonsRow struct {
+	ID               int32
+	TeamID           int32
+	Name             string
+	GraduationYear   int32
+	PersonalRecordMs racetime.NullDuration
+	Events           string
+}
+
+func (q *Queries) ListAthletesAllSeasons(ctx context.Context, teamID int32) ([]ListAthletesAllSeasonsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAthletesAllSeasons, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAthletesAllSeasonsRow
+	for rows.Next() {
+		var i ListAthletesAllSeasonsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.GraduationYear,
+			&i.PersonalRecordMs,
+			&i.Events,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCourseResults = `-- name: ListCourseResults :many
+SELECT r.athlete_id, COALESCE(a.name, r.runner_name, '') AS runner_name, t.name AS team_name,
+       m.name AS meet_name, m.date, ra.id AS race_id, ra.gender, ra.distance_m, r.time_ms, r.place
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN teams t ON r.team_id = t.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE ra.course_id = ?1
+   OR (ra.course_id IS NULL AND m.course_id = ?1)
+ORDER BY ra.gender, ra.distance_m, r.time_ms
+`
+
+type ListCourseResultsRow struct {
+	AthleteID  sql.NullInt32
+	RunnerName string
+	TeamName   string
+	MeetName   string
+	Date       time.Time
+	RaceID     int32
+	Gender     dbsqlc.RacesGender
+	DistanceM  int32
+	TimeMs     racetime.Duration
+	Place      int32
+}
+
+// Every finish on a course, fastest first within each gender and distance.
+// A race is on the course if it names it, or names no course and its meet
+// does.
+func (q *Queries) ListCourseResults(ctx context.Context, courseID sql.NullInt32) ([]ListCourseResultsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCourseResults, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCourseResultsRow
+	for rows.Next() {
+		var i ListCourseResultsRow
+		if err := rows.Scan(
+			&i.AthleteID,
+			&i.RunnerName,
+			&i.TeamName,
+			&i.MeetName,
+			&i.Date,
+			&i.RaceID,
+			&i.Gender,
+			&i.DistanceM,
+			&i.TimeMs,
+			&i.Place,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCourses = `-- name: ListCourses :many
+SELECT id, name, COALESCE(address, '') AS address, distance_m, COALESCE(surface, '') AS surface, elevation_gain_m, COALESCE(notes, '') AS notes
+FROM courses
+ORDER BY name
+`
+
+type ListCoursesRow struct {
+	ID             int32
+	Name           string
+	Address        string
+	DistanceM      int32
+	Surface        string
+	ElevationGainM sql.NullInt32
+	Notes          string
+}
+
+func (q *Queries) ListCourses(ctx context.Context) ([]ListCoursesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCourses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCoursesRow
+	for rows.Next() {
+		var i ListCoursesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Address,
+			&i.DistanceM,
+			&i.Surface,
+			&i.ElevationGainM,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFastestAthletes = `-- name: ListFastestAthletes :many
+SELECT id, team_id, name, graduation_year, personal_record_ms, COALESCE(events, '') AS events
+FROM athletes
+WHERE personal_record_ms IS NOT NULL
+ORDER BY personal_record_ms
+LIMIT 5
+`
+
+type ListFastestAthletesRow struct {
+	ID               int32
+	TeamID           int32
+	Name             string
+	GraduationYear   int32
+	PersonalRecordMs racetime.NullDuration
+	Events           string
+}
+
+func (q *Queries) ListFastestAthletes(ctx context.Context) ([]ListFastestAthletesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFastestAthletes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFastestAthletesRow
+	for rows.Next() {
+		var i ListFastestAthletesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.GraduationYear,
+			&i.PersonalRecordMs,
+			&i.Events,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFastestAthletesInSeason = `-- name: ListFastestAthletesInSeason :many
+SELECT a.id, a.team_id, a.name, a.graduation_year, a.personal_record_ms, COALESCE(a.events, '') AS events
+FROM athletes a
+JOIN season_athletes sa ON sa.athlete_id = a.id
+WHERE a.personal_record_ms IS NOT NULL AND sa.season_id = ?
+ORDER BY a.personal_record_ms
+LIMIT 5
+`
+
+type ListFastestAthletesInSeasonRow struct {
+	ID               int32
+	TeamID           int32
+	Name             string
+	GraduationYear   int32
+	PersonalRecordMs racetime.NullDuration
+	Events           string
+}
+
+func (q *Queries) ListFastestAthletesInSeason(ctx context.Context, seasonID int32) ([]ListFastestAthletesInSeasonRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFastestAthletesInSeason, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFastestAthletesInSeasonRow
+	for rows.Next() {
+		var i ListFastestAthletesInSeasonRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.GraduationYear,
+			&i.PersonalRecordMs,
+			&i.Events,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFastestTimes = `-- name: ListFastestTimes :many
+SELECT a.name AS athlete_name, m.name AS meet_name, r.time_ms, r.place
+FROM results r
+JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE m.date BETWEEN ?1 AND ?2
+  AND ra.distance_m = ?3
+ORDER BY r.time_ms
+LIMIT 10
+`
+
+type ListFastestTimesParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+	DistanceM int32
+}
+
+type ListFastestTimesRow struct {
+	AthleteName string
+	MeetName    string
+	TimeMs      racetime.Duration
+	Place       int32
+}
+
+func (q *Queries) ListFastestTimes(ctx context.Context, arg ListFastestTimesParams) ([]ListFastestTimesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFastestTimes, arg.StartDate, arg.EndDate, arg.DistanceM)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFastestTimesRow
+	for rows.Next() {
+		var i ListFastestTimesRow
+		if err := rows.Scan(
+			&i.AthleteName,
+			&i.MeetName,
+			&i.TimeMs,
+			&i.Place,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLatestMeetResults = `-- name: ListLatestMeetResults :many
+SELECT a.name AS athlete_name, m.name AS meet_name, r.time_ms, r.place
+FROM results r
+JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE m.date = (
+    -- Not BETWEEN, whose arguments sqlc binds twice inside a subquery
+    SELECT MAX(latest.date) FROM meets latest WHERE latest.date >= ?1 AND latest.date <= ?2
+)
+ORDER BY r.place
+`
+
+type ListLatestMeetResultsParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type ListLatestMeetResultsRow struct {
+	AthleteName string
+	MeetName    string
+	TimeMs      racetime.Duration
+	Place       int32
+}
+
+// Our finishes at the most recent meet between the two dates.
+func (q *Queries) ListLatestMeetResults(ctx context.Context, arg ListLatestMeetResultsParams) ([]ListLatestMeetResultsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLatestMeetResults, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLatestMeetResultsRow
+	for rows.Next() {
+		var i ListLatestMeetResultsRow
+		if err := rows.Scan(
+			&i.AthleteName,
+			&i.MeetName,
+			&i.TimeMs,
+			&i.Place,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMeetAthleteIDs = `-- name: ListMeetAthleteIDs :many
+SELECT DISTINCT r.athlete_id
+FROM results r
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ? AND r.athlete_id IS NOT NULL
+`
+
+func (q *Queries) ListMeetAthleteIDs(ctx context.Context, meetID int32) ([]sql.NullInt32, error) {
+	rows, err := q.db.QueryContext(ctx, listMeetAthleteIDs, meetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullInt32
+	for rows.Next() {
+		var athlete_id sql.NullInt32
+		if err := rows.Scan(&athlete_id); err != nil {
+			return nil, err
+		}
+		items = append(items, athlete_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMeets = `-- name: ListMeets :many
+SELECT id, name, date, COALESCE(location, '') AS location, COALESCE(description, '') AS description, cancelled, course_id
+FROM meets
+WHERE date BETWEEN ?1 AND ?2
+ORDER BY date
+`
+
+type ListMeetsParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type ListMeetsRow struct {
+	ID          int32
+	Name        string
+	Date        time.Time
+	Location    string
+	Description string
+	Cancelled   bool
+	CourseID    sql.NullInt32
+}
+
+func (q *Queries) ListMeets(ctx context.Context, arg ListMeetsParams) ([]ListMeetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMeets, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMeetsRow
+	for rows.Next() {
+		var i ListMeetsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Date,
+			&i.Location,
+			&i.Description,
+			&i.Cancelled,
+			&i.CourseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonalRecords = `-- name: ListPersonalRecords :many
+SELECT p.distance_m, p.course_id, COALESCE(c.name, '') AS course_name, p.time_ms, p.result_id,
+       m.id AS meet_id, m.name AS meet_name, m.date
+FROM personal_records p
+LEFT JOIN courses c ON p.course_id = c.id
+JOIN results r ON p.result_id = r.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE p.athlete_id = ?
+ORDER BY p.distance_m, p.course_id IS NOT NULL, c.name
+`
+
+type ListPersonalRecordsRow struct {
+	DistanceM  int32
+	CourseID   sql.NullInt32
+	CourseName string
+	TimeMs     racetime.Duration
+	ResultID   int32
+	MeetID     int32
+	MeetName   string
+	Date       time.Time
+}
+
+// An athlete's records, overall before per-course within each distance.
+// sqlc's SQLite engine takes the LEFT JOINed c.name to be NOT NULL, so it
+// comes back as ” and store.NewSQLite restores the NULL.
+func (q *Queries) ListPersonalRecords(ctx context.Context, athleteID int32) ([]ListPersonalRecordsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPersonalRecords, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalRecordsRow
+	for rows.Next() {
+		var i ListPersonalRecordsRow
+		if err := rows.Scan(
+			&i.DistanceM,
+			&i.CourseID,
+			&i.CourseName,
+			&i.TimeMs,
+			&i.ResultID,
+			&i.MeetID,
+			&i.MeetName,
+			&i.Date,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRaceAthleteIDs = `-- name: ListRaceAthleteIDs :many
+SELECT athlete_id
+FROM results
+WHERE race_id = ? AND athlete_id IS NOT NULL
+`
+
+func (q *Queries) ListRaceAthleteIDs(ctx context.Context, raceID int32) ([]sql.NullInt32, error) {
+	rows, err := q.db.QueryContext(ctx, listRaceAthleteIDs, raceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullInt32
+	for rows.Next() {
+		var athlete_id sql.NullInt32
+		if err := rows.Scan(&athlete_id); err != nil {
+			return nil, err
+		}
+		items = append(items, athlete_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRaceFinishers = `-- name: ListRaceFinishers :many
+SELECT r.athlete_id, COALESCE(a.name, r.runner_name, '') AS runner_name, t.name AS team_name, r.place, r.time_ms
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN teams t ON r.team_id = t.id
+WHERE r.race_id = ?
+ORDER BY r.place
+`
+
+type ListRaceFinishersRow struct {
+	AthleteID  sql.NullInt32
+	RunnerName string
+	TeamName   string
+	Place      int32
+	TimeMs     racetime.Duration
+}
+
+func (q *Queries) ListRaceFinishers(ctx context.Context, raceID int32) ([]ListRaceFinishersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRaceFinishers, raceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRaceFinishersRow
+	for rows.Next() {
+		var i ListRaceFinishersRow
+		if err := rows.Scan(
+			&i.AthleteID,
+			&i.RunnerName,
+			&i.TeamName,
+			&i.Place,
+			&i.TimeMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRacesByMeet = `-- name: ListRacesByMeet :many
+SELECT id, meet_id, gender, division, distance_m, start_time, course_id
+FROM races
+WHERE meet_id = ?
+ORDER BY start_time IS NULL, start_time, id
+`
+
+func (q *Queries) ListRacesByMeet(ctx context.Context, meetID int32) ([]Race, error) {
+	rows, err := q.db.QueryContext(ctx, listRacesByMeet, meetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Race
+	for rows.Next() {
+		var i Race
+		if err := rows.Scan(
+			&i.ID,
+			&i.MeetID,
+			&i.Gender,
+			&i.Division,
+			&i.DistanceM,
+			&i.StartTime,
+			&i.CourseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResultsBetween = `-- name: ListResultsBetween :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+JOIN meets m ON ra.meet_id = m.id
+WHERE m.date BETWEEN ?1 AND ?2
+ORDER BY m.date, r.race_id, r.place
+`
+
+type ListResultsBetweenParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type ListResultsBetweenRow struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) ListResultsBetween(ctx context.Context, arg ListResultsBetweenParams) ([]ListResultsBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResultsBetween, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResultsBetweenRow
+	for rows.Next() {
+		var i ListResultsBetweenRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AthleteID,
+			&i.TeamID,
+			&i.RunnerName,
+			&i.RaceID,
+			&i.MeetID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResultsByMeet = `-- name: ListResultsByMeet :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ?
+ORDER BY r.race_id, r.place
+`
+
+type ListResultsByMeetRow struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) ListResultsByMeet(ctx context.Context, meetID int32) ([]ListResultsByMeetRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResultsByMeet, meetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResultsByMeetRow
+	for rows.Next() {
+		var i ListResultsByMeetRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AthleteID,
+			&i.TeamID,
+			&i.RunnerName,
+			&i.RaceID,
+			&i.MeetID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResultsByMeetAndTeam = `-- name: ListResultsByMeetAndTeam :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ? AND r.team_id = ?
+ORDER BY r.race_id, r.place
+`
+
+type ListResultsByMeetAndTeamParams struct {
+	MeetID int32
+	TeamID int32
+}
+
+type ListResultsByMeetAndTeamRow struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) ListResultsByMeetAndTeam(ctx context.Context, arg ListResultsByMeetAndTeamParams) ([]ListResultsByMeetAndTeamRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResultsByMeetAndTeam, arg.MeetID, arg.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResultsByMeetAndTeamRow
+	for rows.Next() {
+		var i ListResultsByMeetAndTeamRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AthleteID,
+			&i.TeamID,
+			&i.RunnerName,
+			&i.RaceID,
+			&i.MeetID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResultsByRace = `-- name: ListResultsByRace :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE r.race_id = ?
+ORDER BY r.place
+`
+
+type ListResultsByRaceRow struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) ListResultsByRace(ctx context.Context, raceID int32) ([]ListResultsByRaceRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResultsByRace, raceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResultsByRaceRow
+	for rows.Next() {
+		var i ListResultsByRaceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AthleteID,
+			&i.TeamID,
+			&i.RunnerName,
+			&i.RaceID,
+			&i.MeetID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResultsByRaceAndTeam = `-- name: ListResultsByRaceAndTeam :many
+SELECT r.id, r.athlete_id, r.team_id, COALESCE(a.name, r.runner_name, '') AS runner_name, r.race_id, ra.meet_id, r.time_ms, r.place, r.is_pr, r.is_course_pr
+FROM results r
+LEFT JOIN athletes a ON r.athlete_id = a.id
+JOIN races ra ON r.race_id = ra.id
+WHERE r.race_id = ? AND r.team_id = ?
+ORDER BY r.place
+`
+
+type ListResultsByRaceAndTeamParams struct {
+	RaceID int32
+	TeamID int32
+}
+
+type ListResultsByRaceAndTeamRow struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	IsPr       bool
+	IsCoursePr bool
+}
+
+func (q *Queries) ListResultsByRaceAndTeam(ctx context.Context, arg ListResultsByRaceAndTeamParams) ([]ListResultsByRaceAndTeamRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResultsByRaceAndTeam, arg.RaceID, arg.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResultsByRaceAndTeamRow
+	for rows.Next() {
+		var i ListResultsByRaceAndTeamRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AthleteID,
+			&i.TeamID,
+			&i.RunnerName,
+			&i.RaceID,
+			&i.MeetID,
+			&i.TimeMs,
+			&i.Place,
+			&i.IsPr,
+			&i.IsCoursePr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoster = `-- name: ListRoster :many
+SELECT sa.athlete_id, sa.grade, a.graduation_year
+FROM season_athletes sa
+JOIN athletes a ON sa.athlete_id = a.id
+WHERE sa.season_id = ?
+`
+
+type ListRosterRow struct {
+	AthleteID      int32
+	Grade          int32
+	GraduationYear int32
+}
+
+func (q *Queries) ListRoster(ctx context.Context, seasonID int32) ([]ListRosterRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRoster, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRosterRow
+	for rows.Next() {
+		var i ListRosterRow
+		if err := rows.Scan(&i.AthleteID, &i.Grade, &i.GraduationYear); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeasons = `-- name: ListSeasons :many
+SELECT id, year, name, start_date, end_date
+FROM seasons
+ORDER BY year DESC
+`
+
+func (q *Queries) ListSeasons(ctx context.Context) ([]Season, error) {
+	rows, err := q.db.QueryContext(ctx, listSeasons)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Season
+	for rows.Next() {
+		var i Season
+		if err := rows.Scan(
+			&i.ID,
+			&i.Year,
+			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSplitsByAthlete = `-- name: ListSplitsByAthlete :many
+SELECT s.result_id, s.split_index, s.distance_m, s.elapsed_ms
+FROM result_splits s
+JOIN results r ON s.result_id = r.id
+WHERE r.athlete_id = ?
+ORDER BY s.result_id, s.split_index
+`
+
+func (q *Queries) ListSplitsByAthlete(ctx context.Context, athleteID sql.NullInt32) ([]ResultSplit, error) {
+	rows, err := q.db.QueryContext(ctx, listSplitsByAthlete, athleteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResultSplit
+	for rows.Next() {
+		var i ResultSplit
+		if err := rows.Scan(
+			&i.ResultID,
+			&i.SplitIndex,
+			&i.DistanceM,
+			&i.ElapsedMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSplitsByMeet = `-- name: ListSplitsByMeet :many
+SELECT s.result_id, s.split_index, s.distance_m, s.elapsed_ms
+FROM result_splits s
+JOIN results r ON s.result_id = r.id
+JOIN races ra ON r.race_id = ra.id
+WHERE ra.meet_id = ?
+ORDER BY s.result_id, s.split_index
+`
+
+func (q *Queries) ListSplitsByMeet(ctx context.Context, meetID int32) ([]ResultSplit, error) {
+	rows, err := q.db.QueryContext(ctx, listSplitsByMeet, meetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResultSplit
+	for rows.Next() {
+		var i ResultSplit
+		if err := rows.Scan(
+			&i.ResultID,
+			&i.SplitIndex,
+			&i.DistanceM,
+			&i.ElapsedMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSplitsByRace = `-- name: ListSplitsByRace :many
+SELECT s.result_id, s.split_index, s.distance_m, s.elapsed_ms
+FROM result_splits s
+JOIN results r ON s.result_id = r.id
+WHERE r.race_id = ?
+ORDER BY s.result_id, s.split_index
+`
+
+func (q *Queries) ListSplitsByRace(ctx context.Context, raceID int32) ([]ResultSplit, error) {
+	rows, err := q.db.QueryContext(ctx, listSplitsByRace, raceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResultSplit
+	for rows.Next() {
+		var i ResultSplit
+		if err := rows.Scan(
+			&i.ResultID,
+			&i.SplitIndex,
+			&i.DistanceM,
+			&i.ElapsedMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSplitsByResult = `-- name: ListSplitsByResult :many
+SELECT result_id, split_index, distance_m, elapsed_ms
+FROM result_splits
+WHERE result_id = ?
+ORDER BY split_index
+`
+
+func (q *Queries) ListSplitsByResult(ctx context.Context, resultID int32) ([]ResultSplit, error) {
+	rows, err := q.db.QueryContext(ctx, listSplitsByResult, resultID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResultSplit
+	for rows.Next() {
+		var i ResultSplit
+		if err := rows.Scan(
+			&i.ResultID,
+			&i.SplitIndex,
+			&i.DistanceM,
+			&i.ElapsedMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeamIDsIn = `-- name: ListTeamIDsIn :many
+SELECT id
+FROM teams
+WHERE id IN (/*SLICE:ids*/?)
+`
+
+func (q *Queries) ListTeamIDsIn(ctx context.Context, ids []int32) ([]int32, error) {
+	query := listTeamIDsIn
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeams = `-- name: ListTeams :many
+SELECT id, name, COALESCE(short_name, '') AS short_name, is_home
+FROM teams
+ORDER BY is_home DESC, name
+`
+
+type ListTeamsRow struct {
+	ID        int32
+	Name      string
+	ShortName string
+	IsHome    bool
+}
+
+func (q *Queries) ListTeams(ctx context.Context) ([]ListTeamsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTeams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTeamsRow
+	for rows.Next() {
+		var i ListTeamsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ShortName,
+			&i.IsHome,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserAthleteIDs = `-- name: ListUserAthleteIDs :many
+SELECT athlete_id
+FROM user_athletes
+WHERE user_id = ?
+ORDER BY athlete_id
+`
+
+func (q *Queries) ListUserAthleteIDs(ctx context.Context, userID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listUserAthleteIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var athlete_id int32
+		if err := rows.Scan(&athlete_id); err != nil {
+			return nil, err
+		}
+		items = append(items, athlete_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, username, role
+FROM users
+ORDER BY username
+`
+
+type ListUsersRow struct {
+	ID       int32
+	Username string
+	Role     dbsqlc.UsersRole
+}
+
+func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersRow
+	for rows.Next() {
+		var i ListUsersRow
+		if err := rows.Scan(&i.ID, &i.Username, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAthletePR = `-- name: SetAthletePR :exec
+UPDATE athletes
+SET personal_record_ms = ?
+WHERE id = ?
+`
+
+type SetAthletePRParams struct {
+	PersonalRecordMs racetime.NullDuration
+	ID               int32
+}
+
+func (q *Queries) SetAthletePR(ctx context.Context, arg SetAthletePRParams) error {
+	_, err := q.db.ExecContext(ctx, setAthletePR, arg.PersonalRecordMs, arg.ID)
+	return err
+}
+
+const setResultPRFlags = `-- name: SetResultPRFlags :exec
+UPDATE results
+SET is_pr = ?, is_course_pr = ?
+WHERE id = ?
+`
+
+type SetResultPRFlagsParams struct {
+	IsPr       bool
+	IsCoursePr bool
+	ID         int32
+}
+
+func (q *Queries) SetResultPRFlags(ctx context.Context, arg SetResultPRFlagsParams) error {
+	_, err := q.db.ExecContext(ctx, setResultPRFlags, arg.IsPr, arg.IsCoursePr, arg.ID)
+	return err
+}
+
+const updateAthlete = `-- name: UpdateAthlete :exec
+UPDATE athletes
+SET team_id = ?, name = ?, graduation_year = ?, manual_pr_ms = ?
+WHERE id = ?
+`
+
+type UpdateAthleteParams struct {
+	TeamID         int32
+	Name           string
+	GraduationYear int32
+	ManualPrMs     racetime.NullDuration
+	ID             int32
+}
+
+func (q *Queries) UpdateAthlete(ctx context.Context, arg UpdateAthleteParams) error {
+	_, err := q.db.ExecContext(ctx, updateAthlete,
+		arg.TeamID,
+		arg.Name,
+		arg.GraduationYear,
+		arg.ManualPrMs,
+		arg.ID,
+	)
+	return err
+}
+
+const updateCourse = `-- name: UpdateCourse :exec
+UPDATE courses
+SET name = ?, address = ?, distance_m = ?, surface = ?, elevation_gain_m = ?, notes = ?
+WHERE id = ?
+`
+
+type UpdateCourseParams struct {
+	Name           string
+	Address        sql.NullString
+	DistanceM      int32
+	Surface        sql.NullString
+	ElevationGainM sql.NullInt32
+	Notes          sql.NullString
+	ID             int32
+}
+
+func (q *Queries) UpdateCourse(ctx context.Context, arg UpdateCourseParams) error {
+	_, err := q.db.ExecContext(ctx, updateCourse,
+		arg.Name,
+		arg.Address,
+		arg.DistanceM,
+		arg.Surface,
+		arg.ElevationGainM,
+		arg.Notes,
+		arg.ID,
+	)
+	return err
+}
+
+const updateMeet = `-- name: UpdateMeet :exec
+UPDATE meets
+SET name = ?, date = ?, location = ?, description = ?, cancelled = ?, course_id = ?
+WHERE id = ?
+`
+
+type UpdateMeetParams struct {
+	Name        string
+	Date        time.Time
+	Location    sql.NullString
+	Description sql.NullString
+	Cancelled   bool
+	CourseID    sql.NullInt32
+	ID          int32
+}
+
+func (q *Queries) UpdateMeet(ctx context.Context, arg UpdateMeetParams) error {
+	_, err := q.db.ExecContext(ctx, updateMeet,
+		arg.Name,
+		arg.Date,
+		arg.Location,
+		arg.Description,
+		arg.Cancelled,
+		arg.CourseID,
+		arg.ID,
+	)
+	return err
+}
+
+const updateRace = `-- name: UpdateRace :exec
+UPDATE races
+SET gender = ?, division = ?, distance_m = ?, start_time = ?, course_id = ?
+WHERE id = ?
+`
+
+type UpdateRaceParams struct {
+	Gender    dbsqlc.RacesGender
+	Division  dbsqlc.RacesDivision
+	DistanceM int32
+	StartTime sql.NullString
+	CourseID  sql.NullInt32
+	ID        int32
+}
+
+func (q *Queries) UpdateRace(ctx context.Context, arg UpdateRaceParams) error {
+	_, err := q.db.ExecContext(ctx, updateRace,
+		arg.Gender,
+		arg.Division,
+		arg.DistanceM,
+		arg.StartTime,
+		arg.CourseID,
+		arg.ID,
+	)
+	return err
+}
+
+const updateResult = `-- name: UpdateResult :exec
+UPDATE results
+SET athlete_id = ?, team_id = ?, runner_name = ?, race_id = ?, time_ms = ?, place = ?
+WHERE id = ?
+`
+
+type UpdateResultParams struct {
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName sql.NullString
+	RaceID     int32
+	TimeMs     racetime.Duration
+	Place      int32
+	ID         int32
+}
+
+func (q *Queries) UpdateResult(ctx context.Context, arg UpdateResultParams) error {
+	_, err := q.db.ExecContext(ctx, updateResult,
+		arg.AthleteID,
+		arg.TeamID,
+		arg.RunnerName,
+		arg.RaceID,
+		arg.TimeMs,
+		arg.Place,
+		arg.ID,
+	)
+	return err
+}
+
+const updateRosterGrade = `-- name: UpdateRosterGrade :exec
+UPDATE season_athletes
+SET grade = ?
+WHERE season_id = ? AND athlete_id = ?
+`
+
+type UpdateRosterGradeParams struct {
+	Grade     int32
+	SeasonID  int32
+	AthleteID int32
+}
+
+func (q *Queries) UpdateRosterGrade(ctx context.Context, arg UpdateRosterGradeParams) error {
+	_, err := q.db.ExecContext(ctx, updateRosterGrade, arg.Grade, arg.SeasonID, arg.AthleteID)
+	return err
+}
+
+const updateSeason = `-- name: UpdateSeason :exec
+UPDATE seasons SET name = ?
+WHERE id = ?
+`
+
+type UpdateSeasonParams struct {
+	Name string
+	ID   int32
+}
+
+func (q *Queries) UpdateSeason(ctx context.Context, arg UpdateSeasonParams) error {
+	_, err := q.db.ExecContext(ctx, updateSeason, arg.Name, arg.ID)
+	return err
+}
+
+const updateTeam = `-- name: UpdateTeam :exec
+UPDATE teams
+SET name = ?, short_name = ?
+WHERE id = ?
+`
+
+type UpdateTeamParams struct {
+	Name      string
+	ShortName sql.NullString
+	ID        int32
+}
+
+func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) error {
+	_, err := q.db.ExecContext(ctx, updateTeam, arg.Name, arg.ShortName, arg.ID)
+	return err
+}
+
+const upsertRosterEntry = `-- name: UpsertRosterEntry :exec
+INSERT INTO season_athletes (season_id, athlete_id, grade)
+VALUES (?, ?, ?)
+ON CONFLICT (season_id, athlete_id) DO UPDATE SET grade = excluded.grade
+`
+
+type UpsertRosterEntryParams struct {
+	SeasonID  int32
+	AthleteID int32
+	Grade     int32
+}
+
+func (q *Queries) UpsertRosterEntry(ctx context.Context, arg UpsertRosterEntryParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRosterEntry, arg.SeasonID, arg.AthleteID, arg.Grade)
+	return err
+}
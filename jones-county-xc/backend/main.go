@@ -1,449 +1,291 @@
 package main
 
 import (
+	"bufio"
 	"context"
-	"database/sql"
-	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
-
-	_ "github.com/go-sql-driver/mysql"
-	dbsqlc "jones-county-xc/backend/db/sqlc"
+	"syscall"
+
+	"jones-county-xc/backend/api"
+	"jones-county-xc/backend/auth"
+	"jones-county-xc/backend/config"
+	"jones-county-xc/backend/metrics"
+	"jones-county-xc/backend/migrate"
+	"jones-county-xc/backend/store"
 )
 
-type Athlete struct {
-	ID             int    `json:"id"`
-	Name           string `json:"name"`
-	Grade          int    `json:"grade"`
-	PersonalRecord string `json:"personalRecord"`
-	Events         string `json:"events"`
-}
-
-type Meet struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Date        string `json:"date"`
-	Location    string `json:"location"`
-	Description string `json:"description"`
-}
-
-type Result struct {
-	ID        int    `json:"id"`
-	AthleteID int    `json:"athleteId"`
-	MeetID    int    `json:"meetId"`
-	Time      string `json:"time"`
-	Place     int    `json:"place"`
-}
-
 func main() {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		dsn = "root@tcp(127.0.0.1:3306)/jones_county_xc?parseTime=true"
+	// Settings come from flags, the environment and an optional config
+	// file; see the config package
+	cfg, args, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
 	}
-
-	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
-	}
-	defer db.Close()
-
-	if err := db.Ping(); err != nil {
-		log.Fatal("Failed to ping database:", err)
+		log.Fatal(err)
 	}
-	log.Println("Connected to MySQL database")
-
-	queries := dbsqlc.New(db)
 
-	// Enable CORS for frontend development
-	corsMiddleware := func(next http.HandlerFunc) http.HandlerFunc {
-		return func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "http://localhost:5173")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	// Everything logs through slog from here on, anything still using the
+	// log package included
+	logger := newLogger(cfg.LogFormat, cfg.LogLevel)
+	slog.SetDefault(logger)
 
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
+	st, db, err := store.Open(context.Background(), cfg.DatabaseURL)
+	if err != nil {
+		fatal("Failed to open database", err)
+	}
+	if db != nil {
+		defer db.Close()
+		logger.Info("Connected to database")
+	} else {
+		logger.Warn("Using the in-memory store; nothing is saved when the server stops")
+	}
 
-			next(w, r)
+	// The in-memory store has no schema, so nothing to migrate
+	var migrator *migrate.Migrator
+	if db != nil {
+		if migrator, err = migrate.New(db); err != nil {
+			fatal("Failed to read migrations", err)
 		}
 	}
 
-	// Root endpoint
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/" {
-			http.NotFound(w, r)
-			return
+	// `server migrate up|down|status|baseline <version>` works on the
+	// database's migrations and exits; see runMigrate.
+	if len(args) > 0 && args[0] == "migrate" {
+		if migrator == nil {
+			fatal("Failed to migrate", errors.New("the in-memory store has no migrations"))
 		}
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte("<h1>Jones County XC API</h1><p>Endpoints:</p><ul><li><a href='/api/health'>/api/health</a></li><li><a href='/api/hello'>/api/hello</a></li><li><a href='/api/athletes'>/api/athletes</a></li><li><a href='/api/meets'>/api/meets</a></li><li><a href='/api/results'>/api/results</a></li></ul>"))
-	})
-
-	// Health check endpoint
-	http.HandleFunc("/api/health", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]string{
-			"status": "ok",
-		})
-	}))
-
-	// Example API endpoint
-	http.HandleFunc("/api/hello", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Hello from Jones County XC backend!",
-		})
-	}))
-
-	// Athletes endpoint — using sqlc generated code
-	athletesHandler := corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		// Handle POST /api/athletes — create new athlete
-		if r.Method == "POST" {
-			var body struct {
-				Name           string `json:"name"`
-				Grade          int    `json:"grade"`
-				PersonalRecord string `json:"personalRecord"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-				http.Error(w, "invalid JSON", http.StatusBadRequest)
-				return
-			}
-			if body.Name == "" || body.Grade < 9 || body.Grade > 12 {
-				http.Error(w, "name is required and grade must be 9-12", http.StatusBadRequest)
-				return
-			}
-			pr := sql.NullString{String: body.PersonalRecord, Valid: body.PersonalRecord != ""}
-			result, err := queries.CreateAthlete(context.Background(), dbsqlc.CreateAthleteParams{
-				Name:           body.Name,
-				Grade:          int32(body.Grade),
-				PersonalRecord: pr,
-			})
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			id, _ := result.LastInsertId()
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusCreated)
-			json.NewEncoder(w).Encode(Athlete{
-				ID:             int(id),
-				Name:           body.Name,
-				Grade:          body.Grade,
-				PersonalRecord: body.PersonalRecord,
-			})
-			return
+		if err := runMigrate(migrator, args[1:]); err != nil {
+			fatal("Failed to migrate", err)
 		}
+		return
+	}
 
-		// Handle /api/athletes/{id} pattern
-		if rest := strings.TrimPrefix(r.URL.Path, "/api/athletes/"); rest != r.URL.Path {
-			id, err := strconv.ParseInt(rest, 10, 32)
-			if err != nil {
-				http.Error(w, "invalid athlete id", http.StatusBadRequest)
-				return
-			}
-
-			// Handle PUT /api/athletes/{id} — update athlete
-			if r.Method == "PUT" {
-				var body struct {
-					Name           string `json:"name"`
-					Grade          int    `json:"grade"`
-					PersonalRecord string `json:"personalRecord"`
-				}
-				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-					http.Error(w, "invalid JSON", http.StatusBadRequest)
-					return
-				}
-				if body.Name == "" || body.Grade < 9 || body.Grade > 12 {
-					http.Error(w, "name is required and grade must be 9-12", http.StatusBadRequest)
-					return
-				}
-				pr := sql.NullString{String: body.PersonalRecord, Valid: body.PersonalRecord != ""}
-				err := queries.UpdateAthlete(context.Background(), dbsqlc.UpdateAthleteParams{
-					Name:           body.Name,
-					Grade:          int32(body.Grade),
-					PersonalRecord: pr,
-					ID:             int32(id),
-				})
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
-				}
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(Athlete{
-					ID:             int(id),
-					Name:           body.Name,
-					Grade:          body.Grade,
-					PersonalRecord: body.PersonalRecord,
-				})
-				return
-			}
-
-			// Handle DELETE /api/athletes/{id} — delete athlete
-			if r.Method == "DELETE" {
-				err := queries.DeleteAthlete(context.Background(), int32(id))
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
-				}
-				w.Header().Set("Content-Type", "application/json")
-				json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
-				return
+	// With MIGRATE set the server brings the database up to date itself;
+	// without it, queries against missing tables and columns would fail
+	// request by request, so warn up front.
+	if migrator != nil {
+		if cfg.Migrate {
+			done, err := migrator.Up(context.Background())
+			for _, m := range done {
+				logger.Info("Applied migration", "migration", m.String())
 			}
-
-			row, err := queries.GetAthleteByID(context.Background(), int32(id))
-			if err == sql.ErrNoRows {
-				http.Error(w, "athlete not found", http.StatusNotFound)
-				return
+			if errors.Is(err, migrate.ErrUntracked) {
+				// Restarting cannot fix this, so exit with the status the
+				// systemd service does not restart on
+				slog.Error("Failed to migrate database", "error", err)
+				os.Exit(exitConfig)
 			}
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
+				fatal("Failed to migrate database", err)
 			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(Athlete{
-				ID:             int(row.ID),
-				Name:           row.Name,
-				Grade:          int(row.Grade),
-				PersonalRecord: row.PersonalRecord,
-				Events:         row.Events,
-			})
-			return
+		} else if pending, err := migrator.Pending(context.Background()); err != nil {
+			fatal("Failed to check migrations", err)
+		} else if len(pending) > 0 {
+			logger.Warn("Database migrations are pending; run `server migrate up` or start with -migrate",
+				"pending", len(pending), "first", pending[0].String())
 		}
+	}
 
-		rows, err := queries.ListAthletes(context.Background())
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+	// `server create-user <username> [role]` reads a password from stdin and
+	// adds a login (an admin unless another role is given), then exits
+	// without starting the HTTP server.
+	if len(args) > 0 && args[0] == "create-user" {
+		if len(args) != 2 && len(args) != 3 {
+			fatal("Failed to create user", errors.New("usage: server create-user <username> [admin|coach|athlete|parent]"))
 		}
-
-		athletes := make([]Athlete, len(rows))
-		for i, row := range rows {
-			athletes[i] = Athlete{
-				ID:             int(row.ID),
-				Name:           row.Name,
-				Grade:          int(row.Grade),
-				PersonalRecord: row.PersonalRecord,
-				Events:         row.Events,
-			}
+		role := auth.RoleAdmin
+		if len(args) == 3 {
+			role = auth.Role(args[2])
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(athletes)
-	})
-	http.HandleFunc("/api/athletes", athletesHandler)
-	http.HandleFunc("/api/athletes/", athletesHandler)
-
-	// Meets endpoint — using sqlc generated code
-	http.HandleFunc("/api/meets", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		rows, err := queries.ListMeets(context.Background())
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+		if !role.Valid() {
+			fatal("Failed to create user", fmt.Errorf("unknown role %q", role))
 		}
-
-		meets := make([]Meet, len(rows))
-		for i, row := range rows {
-			meets[i] = Meet{
-				ID:          int(row.ID),
-				Name:        row.Name,
-				Date:        row.Date.Format("2006-01-02"),
-				Location:    row.Location,
-				Description: row.Description,
-			}
+		fmt.Fprint(os.Stderr, "Password: ")
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			fatal("Failed to read password", err)
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(meets)
-	}))
-
-	// Results endpoint
-	resultsHandler := corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		// Handle /api/results/meet/{id} pattern
-		if rest := strings.TrimPrefix(r.URL.Path, "/api/results/meet/"); rest != r.URL.Path {
-			meetID, err := strconv.ParseInt(rest, 10, 32)
-			if err != nil {
-				http.Error(w, "invalid meet id", http.StatusBadRequest)
-				return
-			}
-			dbResults, err := queries.ListResultsByMeet(context.Background(), int32(meetID))
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			results := make([]Result, len(dbResults))
-			for i, r := range dbResults {
-				results[i] = Result{
-					ID:        int(r.ID),
-					AthleteID: int(r.AthleteID),
-					MeetID:    int(r.MeetID),
-					Time:      r.Time,
-					Place:     int(r.Place),
-				}
-			}
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(results)
-			return
+		if err := createUser(st, args[1], strings.TrimRight(password, "\r\n"), role); err != nil {
+			fatal("Failed to create user", err)
 		}
+		logger.Info("Created user", "username", args[1], "role", role)
+		return
+	}
 
-		// Default: list all results
-		dbRows, err := db.Query("SELECT id, athlete_id, meet_id, time, place FROM results")
+	// A fresh memory store has no logins, so make an admin to sign in with
+	if db == nil && cfg.DemoSeed {
+		password, _, err := auth.NewSessionToken()
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		defer dbRows.Close()
-
-		results := []Result{}
-		for dbRows.Next() {
-			var res Result
-			if err := dbRows.Scan(&res.ID, &res.AthleteID, &res.MeetID, &res.Time, &res.Place); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			results = append(results, res)
+			fatal("Failed to make a password", err)
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(results)
-	})
-	http.HandleFunc("/api/results", resultsHandler)
-	http.HandleFunc("/api/results/", resultsHandler)
-
-	// Top 10 fastest times across all meets — using sqlc generated code
-	http.HandleFunc("/api/results/fastest", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		type FastestTime struct {
-			AthleteName string `json:"athleteName"`
-			MeetName    string `json:"meetName"`
-			Time        string `json:"time"`
-			Place       int    `json:"place"`
+		if err := createUser(st, "admin", password, auth.RoleAdmin); err != nil {
+			fatal("Failed to create user", err)
 		}
+		logger.Info("Log in as admin", "password", password)
+	}
 
-		rows, err := queries.ListFastestTimes(context.Background())
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+	// Prometheus scrapes /metrics: request and query timings, the
+	// connection pool and a few counts from the data
+	var m *metrics.Metrics
+	if cfg.Metrics {
+		m = metrics.New()
+		if db != nil {
+			m.WatchDB(db)
+			st = store.Observe(st, m.ObserveQuery)
 		}
+		m.WatchStore(st, cfg.RequestTimeout)
+	}
 
-		times := make([]FastestTime, len(rows))
-		for i, row := range rows {
-			times[i] = FastestTime{
-				AthleteName: row.AthleteName,
-				MeetName:    row.MeetName,
-				Time:        row.Time,
-				Place:       int(row.Place),
-			}
-		}
+	var accessLog *slog.Logger
+	if cfg.AccessLogFormat != config.AccessLogOff {
+		accessLog = newLogger(string(cfg.AccessLogFormat), cfg.LogLevel)
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(times)
-	}))
+	// Ready means the database answers and has the schema this server's
+	// queries were written for; the in-memory store is always ready
+	var checks []api.Check
+	if db != nil {
+		checks = append(checks,
+			api.Check{Name: "database", Run: db.PingContext},
+			api.Check{Name: "migrations", Run: migrator.CheckVersion},
+		)
+	}
 
-	// Top 5 fastest personal records — raw SQL (JOIN-style query)
-	http.HandleFunc("/api/athletes/fastest", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		rows, err := db.Query("SELECT id, name, grade, COALESCE(personal_record, ''), COALESCE(events, '') FROM athletes ORDER BY personal_record LIMIT 5")
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		defer rows.Close()
+	server := &http.Server{
+		Addr: cfg.Addr,
+		Handler: api.NewServer(st, api.Config{
+			AllowedOrigins: cfg.AllowedOrigins,
+			RequestTimeout: cfg.RequestTimeout,
+			Metrics:        m,
+			ReadyChecks:    checks,
+			AccessLog:      accessLog,
+		}, logger),
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
 
-		athletes := []Athlete{}
-		for rows.Next() {
-			var a Athlete
-			if err := rows.Scan(&a.ID, &a.Name, &a.Grade, &a.PersonalRecord, &a.Events); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			athletes = append(athletes, a)
-		}
+	// systemd stops the server with SIGTERM, and Ctrl-C sends SIGINT. Either
+	// stops new connections and lets requests in flight finish, for up to
+	// SHUTDOWN_TIMEOUT, so that a restart does not cut off a coach saving
+	// results. A second signal stops the server at once.
+	stopping, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	served := make(chan error, 1)
+	go func() { served <- server.ListenAndServe() }()
+	logger.Info("Backend server starting", "addr", cfg.Addr)
+
+	select {
+	case err := <-served:
+		fatal("Failed to serve", err)
+	case <-stopping.Done():
+	}
+	stop()
+	logger.Info("Shutting down; waiting for requests in flight")
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Warn("Dropped requests still running", "after", cfg.ShutdownTimeout, "error", err)
+		server.Close()
+	}
+	logger.Info("Server stopped")
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(athletes)
-	}))
+// newLogger returns a logger writing lines in format, text or json, at
+// level and above. It writes to stderr, which systemd sends to the journal.
+func newLogger(format string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts))
+}
 
-	// Results for the most recent meet — kept as separate handler for clarity
-	http.HandleFunc("/api/results/latest", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		type LatestResult struct {
-			AthleteName string `json:"athleteName"`
-			MeetName    string `json:"meetName"`
-			Time        string `json:"time"`
-			Place       int    `json:"place"`
-		}
+// exitConfig is the exit status for a server that cannot start until
+// someone changes its setup, EX_CONFIG from sysexits.h.
+const exitConfig = 78
 
-		rows, err := db.Query(`
-			SELECT a.name, m.name, r.time, r.place
-			FROM results r
-			JOIN athletes a ON r.athlete_id = a.id
-			JOIN meets m ON r.meet_id = m.id
-			WHERE m.date = (SELECT MAX(date) FROM meets)
-			ORDER BY r.place
-		`)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		defer rows.Close()
+// fatal logs what stopped the server and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
 
-		results := []LatestResult{}
-		for rows.Next() {
-			var res LatestResult
-			if err := rows.Scan(&res.AthleteName, &res.MeetName, &res.Time, &res.Place); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			results = append(results, res)
+// runMigrate runs `server migrate`, given the arguments after "migrate":
+//
+//	up                 apply every pending migration
+//	down               undo the most recent migration
+//	status             list migrations and whether each has been applied
+//	baseline <version> record migrations up to version as already applied,
+//	                   for a database migrated by hand before this command
+func runMigrate(m *migrate.Migrator, args []string) error {
+	ctx := context.Background()
+	usage := errors.New("usage: server migrate up|down|status|baseline <version>")
+	switch {
+	case len(args) == 1 && args[0] == "up":
+		done, err := m.Up(ctx)
+		for _, migration := range done {
+			slog.Info("Applied migration", "migration", migration.String())
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(results)
-	}))
-
-	// Athlete's complete race history — raw SQL (JOIN query)
-	http.HandleFunc("/api/athletes/history", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		athleteID := r.URL.Query().Get("id")
-		if athleteID == "" {
-			http.Error(w, "missing id query parameter", http.StatusBadRequest)
-			return
+		if err == nil && len(done) == 0 {
+			slog.Info("Already up to date", "version", m.Latest())
 		}
-
-		type RaceHistory struct {
-			MeetName string `json:"meetName"`
-			Date     string `json:"date"`
-			Time     string `json:"time"`
-			Place    int    `json:"place"`
+		return err
+	case len(args) == 1 && args[0] == "down":
+		undone, err := m.Down(ctx)
+		if err != nil {
+			return err
 		}
-
-		rows, err := db.Query(`
-			SELECT m.name, m.date, r.time, r.place
-			FROM results r
-			JOIN meets m ON r.meet_id = m.id
-			WHERE r.athlete_id = ?
-			ORDER BY m.date
-		`, athleteID)
+		slog.Info("Undid migration", "migration", undone.String())
+		return nil
+	case len(args) == 1 && args[0] == "status":
+		statuses, err := m.Status(ctx)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			return err
 		}
-		defer rows.Close()
-
-		history := []RaceHistory{}
-		for rows.Next() {
-			var h RaceHistory
-			if err := rows.Scan(&h.MeetName, &h.Date, &h.Time, &h.Place); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
+		for _, s := range statuses {
+			if s.Applied {
+				fmt.Printf("%-32s applied %s\n", s.Migration, s.AppliedAt.Local().Format("2006-01-02 15:04"))
+			} else {
+				fmt.Printf("%-32s pending\n", s.Migration)
 			}
-			history = append(history, h)
 		}
+		return nil
+	case len(args) == 2 && args[0] == "baseline":
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usage
+		}
+		if err := m.Baseline(ctx, version); err != nil {
+			return err
+		}
+		slog.Info("Recorded migrations as applied", "through", version)
+		return nil
+	}
+	return usage
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(history)
-	}))
-
-	log.Println("Backend server starting on http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+func createUser(st store.Store, username, password string, role auth.Role) error {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = st.CreateUser(context.Background(), store.User{
+		Username:     username,
+		PasswordHash: hash,
+		Role:         string(role),
+	})
+	return err
 }
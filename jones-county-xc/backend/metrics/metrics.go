@@ -0,0 +1,136 @@
+// Package metrics is the server's Prometheus metrics, served at /metrics:
+//
+//	xc_http_requests_total{route,status}            requests answered
+//	xc_http_request_duration_seconds{route,status}  how long they took
+//	xc_db_query_duration_seconds{query,outcome}     each sqlc query, by name
+//	go_sql_*{db_name="xc"}                          the connection pool
+//	xc_athletes                                     athletes on record
+//	xc_results_entered_today                        results entered since midnight
+//
+// along with the Go runtime's and the process's own. A route is the
+// pattern it was registered with, such as "GET /api/athletes/{id}", so
+// that ids do not each make a new series; requests no route matches are
+// counted under "unmatched".
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"jones-county-xc/backend/store"
+)
+
+// Metrics holds the server's metrics and the registry that serves them.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	queryDuration   *prometheus.HistogramVec
+}
+
+// New returns the request and query metrics, with the Go runtime and
+// process collectors; WatchDB and WatchStore add the rest.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "xc_http_requests_total",
+			Help: "HTTP requests answered, by route and status.",
+		}, []string{"route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "xc_http_request_duration_seconds",
+			Help:    "How long HTTP requests took to answer, by route and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "xc_db_query_duration_seconds",
+			Help:    "How long database queries took, by sqlc query name and whether they failed.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"query", "outcome"}),
+	}
+	m.registry.MustRegister(
+		m.requests,
+		m.requestDuration,
+		m.queryDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format. A gauge that
+// cannot be read, because the database is down, is left out rather than
+// failing the whole scrape.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// ObserveRequest records a request to route answered with status.
+func (m *Metrics) ObserveRequest(route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(route, code).Inc()
+	m.requestDuration.WithLabelValues(route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveQuery records a database query; it is a store.QueryObserver.
+func (m *Metrics) ObserveQuery(name string, elapsed time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.queryDuration.WithLabelValues(name, outcome).Observe(elapsed.Seconds())
+}
+
+// WatchDB adds db's connection pool statistics, from db.Stats.
+func (m *Metrics) WatchDB(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, "xc"))
+}
+
+// WatchStore adds the gauges counted from st's data, which are read from
+// it at each scrape, giving up after timeout.
+func (m *Metrics) WatchStore(st store.Store, timeout time.Duration) {
+	m.registry.MustRegister(storeCollector{st: st, timeout: timeout})
+}
+
+var (
+	athletesDesc = prometheus.NewDesc("xc_athletes",
+		"Athletes on record.", nil, nil)
+	resultsTodayDesc = prometheus.NewDesc("xc_results_entered_today",
+		"Results entered since midnight, server time.", nil, nil)
+)
+
+// storeCollector counts things in the store when Prometheus scrapes.
+type storeCollector struct {
+	st      store.Store
+	timeout time.Duration
+}
+
+func (c storeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- athletesDesc
+	ch <- resultsTodayDesc
+}
+
+func (c storeCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	y, mo, d := time.Now().Date()
+	midnight := time.Date(y, mo, d, 0, 0, 0, 0, time.Local)
+
+	gauge := func(desc *prometheus.Desc, n int64, err error) {
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(desc, err)
+			return
+		}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(n))
+	}
+	n, err := c.st.CountAthletes(ctx)
+	gauge(athletesDesc, n, err)
+	n, err = c.st.CountResultsSince(ctx, midnight)
+	gauge(resultsTodayDesc, n, err)
+}
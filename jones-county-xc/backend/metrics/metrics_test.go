@@ -0,0 +1,69 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"jones-county-xc/backend/metrics"
+	"jones-county-xc/backend/store"
+)
+
+// scrape returns what Prometheus would read from m.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestObserve(t *testing.T) {
+	m := metrics.New()
+	m.ObserveRequest("GET /api/athletes/{id}", 200, 30*time.Millisecond)
+	m.ObserveRequest("GET /api/athletes/{id}", 200, 10*time.Millisecond)
+	m.ObserveRequest("unmatched", 404, time.Millisecond)
+	m.ObserveQuery("GetAthleteByID", 2*time.Millisecond, nil)
+	m.ObserveQuery("GetAthleteByID", time.Second, errors.New("timeout"))
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`xc_http_requests_total{route="GET /api/athletes/{id}",status="200"} 2`,
+		`xc_http_requests_total{route="unmatched",status="404"} 1`,
+		`xc_http_request_duration_seconds_sum{route="GET /api/athletes/{id}",status="200"} 0.04`,
+		`xc_db_query_duration_seconds_count{outcome="ok",query="GetAthleteByID"} 1`,
+		`xc_db_query_duration_seconds_count{outcome="error",query="GetAthleteByID"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not include %s", want)
+		}
+	}
+}
+
+func TestWatchStore(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	home, err := st.HomeTeam(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Sam Runner", "Alex Strider"} {
+		if _, err := st.CreateAthlete(ctx, store.Athlete{TeamID: home.ID, Name: name, GraduationYear: 2027}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := metrics.New()
+	m.WatchStore(st, time.Second)
+	body := scrape(t, m)
+	for _, want := range []string{"xc_athletes 2", "xc_results_entered_today 0"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not include %s", want)
+		}
+	}
+}
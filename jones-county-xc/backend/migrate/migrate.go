@@ -0,0 +1,388 @@
+// Package migrate brings a database's schema up to date by running the
+// numbered migrations in db/migrations, recording each one it runs in a
+// schema_migrations table so that it runs only once.
+//
+// A migration is a file of SQL statements, each ending with a semicolon at
+// the end of a line. SQLite runs each migration in a transaction. MySQL
+// commits every schema change as it runs, so a MySQL migration that fails
+// partway leaves its earlier statements in place and is not recorded; undo
+// those by hand before trying again.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"jones-county-xc/backend/db"
+	"modernc.org/sqlite"
+)
+
+// Migration is one numbered step in a database's schema history.
+type Migration struct {
+	Version int
+	Name    string
+	// Up makes the change and Down undoes it.
+	Up, Down string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%03d_%s", m.Version, m.Name)
+}
+
+// Status is a migration and whether, and when, it has been applied.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator runs the migrations for one database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []Migration
+}
+
+// New returns a Migrator for db, a MySQL or SQLite database, with the
+// migrations embedded for its kind of database.
+func New(db *sql.DB) (*Migrator, error) {
+	var dialect string
+	switch db.Driver().(type) {
+	case *mysql.MySQLDriver:
+		dialect = "mysql"
+	case *sqlite.Driver:
+		dialect = "sqlite"
+	default:
+		return nil, fmt.Errorf("migrate: no migrations for a %T database", db.Driver())
+	}
+	migrations, err := Load(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads the migrations embedded for dialect, "mysql" or "sqlite", in
+// version order. Every version must have both an up and a down file.
+func Load(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(db.Migrations, dir)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrate: %s/%s is not named like 001_name.up.sql", dir, entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migrate: %s has version %d twice, as %s and %s", dir, version, m.Name, match[2])
+		}
+		script, err := fs.ReadFile(db.Migrations, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("migrate: %w", err)
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	var migrations []Migration
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrate: %s/%s needs both an up and a down file", dir, m)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// Latest is the version the newest migration brings the database to.
+func (m *Migrator) Latest() int {
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status lists every migration, oldest first, with whether it has been
+// applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(conn *sql.Conn, applied map[int]time.Time) error {
+		for _, migration := range m.migrations {
+			appliedAt, ok := applied[migration.Version]
+			statuses = append(statuses, Status{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Pending returns the migrations Up would apply.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Version returns the newest migration applied to the database, or 0 when
+// none has been. Unlike Status it only reads, neither creating the
+// schema_migrations table nor waiting for a migration in progress.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var version int
+	err := m.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+// CheckVersion returns an error unless the database is at Latest, the
+// version the running server's queries were written for.
+func (m *Migrator) CheckVersion(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version != m.Latest() {
+		return fmt.Errorf("migrate: database is at version %d but the server needs %d", version, m.Latest())
+	}
+	return nil
+}
+
+// ErrUntracked is returned by Up for a database that has tables but no
+// migrations recorded, whose schema was built before they were. Running
+// the first migration on it would fail, or worse; Baseline records the
+// migrations its schema already has.
+var ErrUntracked = errors.New("migrate: the database has tables but records no migrations; " +
+	"record those its schema already has with `server migrate baseline <version>`, " +
+	"0 for the schema the site started with")
+
+// Up applies every migration not yet applied, oldest first, and returns
+// those it applied. It stops at the first that fails.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(ctx, func(conn *sql.Conn, applied map[int]time.Time) error {
+		if len(applied) == 0 {
+			tables, err := m.countTables(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if tables > 0 {
+				return ErrUntracked
+			}
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err := m.exec(ctx, conn, migration.Up,
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now().UTC())
+			if err != nil {
+				return fmt.Errorf("migrate: %s: %w", migration, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// ErrNothingApplied is returned by Down when no migration has been
+// applied.
+var ErrNothingApplied = errors.New("migrate: no migrations have been applied")
+
+// Down undoes the most recently applied migration and returns it.
+func (m *Migrator) Down(ctx context.Context) (Migration, error) {
+	var undone Migration
+	err := m.locked(ctx, func(conn *sql.Conn, applied map[int]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			err := m.exec(ctx, conn, migration.Down,
+				"DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+			if err != nil {
+				return fmt.Errorf("migrate: undoing %s: %w", migration, err)
+			}
+			undone = migration
+			return nil
+		}
+		return ErrNothingApplied
+	})
+	return undone, err
+}
+
+// Baseline records every migration up to and including version as applied
+// without running any of them. It is for databases whose schema was built
+// before migrations were recorded here, such as the site's first, which has
+// migration 0's schema, and fails if any migration is already recorded.
+func (m *Migrator) Baseline(ctx context.Context, version int) error {
+	return m.locked(ctx, func(conn *sql.Conn, applied map[int]time.Time) error {
+		if len(applied) > 0 {
+			return errors.New("migrate: the database already records applied migrations")
+		}
+		if !slices.ContainsFunc(m.migrations, func(migration Migration) bool { return migration.Version == version }) {
+			return fmt.Errorf("migrate: there is no migration %d", version)
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, err := conn.ExecContext(ctx,
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now().UTC()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// locked runs f on a connection holding the migration lock, after making
+// sure schema_migrations exists, with the versions it records as applied.
+func (m *Migrator) locked(ctx context.Context, f func(conn *sql.Conn, applied map[int]time.Time) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Two servers starting together must not both migrate. SQLite needs no
+	// lock of its own, as store.OpenSQLite gives it a single connection.
+	if m.dialect == "mysql" {
+		var locked sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK('schema_migrations', 60)").Scan(&locked); err != nil {
+			return err
+		}
+		if locked.Int64 != 1 {
+			return errors.New("migrate: timed out waiting for another server to finish migrating")
+		}
+		defer conn.QueryRowContext(context.Background(), "SELECT RELEASE_LOCK('schema_migrations')").Scan(&locked)
+	}
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)`); err != nil {
+		return err
+	}
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return err
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for version := range applied {
+		if version > m.Latest() {
+			return fmt.Errorf("migrate: the database has migration %d applied, newer than this server knows (%d); run a newer server", version, m.Latest())
+		}
+	}
+	return f(conn, applied)
+}
+
+// countTables counts the database's tables other than schema_migrations.
+func (m *Migrator) countTables(ctx context.Context, conn *sql.Conn) (int, error) {
+	query := `SELECT COUNT(*) FROM information_schema.tables
+WHERE table_schema = DATABASE() AND table_name <> 'schema_migrations'`
+	if m.dialect == "sqlite" {
+		query = `SELECT COUNT(*) FROM sqlite_master
+WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name <> 'schema_migrations'`
+	}
+	var n int
+	err := conn.QueryRowContext(ctx, query).Scan(&n)
+	return n, err
+}
+
+// exec runs script and then record, which notes in schema_migrations that
+// it ran, in one transaction where the database allows it.
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, script, record string, args ...any) error {
+	type execer interface {
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	}
+	var e execer = conn
+	var tx *sql.Tx
+	if m.dialect == "sqlite" {
+		var err error
+		if tx, err = conn.BeginTx(ctx, nil); err != nil {
+			return err
+		}
+		defer tx.Rollback()
+		e = tx
+	}
+	for _, statement := range Statements(script) {
+		if _, err := e.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if _, err := e.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	if tx != nil {
+		return tx.Commit()
+	}
+	return nil
+}
+
+// Statements splits a migration script into its statements, leaving out
+// comment lines. A statement ends at a line ending with a semicolon.
+func Statements(script string) []string {
+	var statements []string
+	var statement strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(statement.String()), ";"))
+			statement.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(statement.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,211 @@
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jones-county-xc/backend/migrate"
+	"jones-county-xc/backend/store"
+)
+
+func newSQLite(t *testing.T) (*sql.DB, *migrate.Migrator) {
+	t.Helper()
+	db, err := store.OpenSQLite(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	m, err := migrate.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, m
+}
+
+// tables lists the tables in a SQLite database other than
+// schema_migrations.
+func tables(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence') ORDER BY name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestLoad(t *testing.T) {
+	latest := map[string]int{}
+	for _, dialect := range []string{"mysql", "sqlite"} {
+		migrations, err := migrate.Load(dialect)
+		if err != nil {
+			t.Fatalf("%s: %v", dialect, err)
+		}
+		for i := 1; i < len(migrations); i++ {
+			if migrations[i].Version <= migrations[i-1].Version {
+				t.Errorf("%s: %s follows %s", dialect, migrations[i], migrations[i-1])
+			}
+		}
+		latest[dialect] = migrations[len(migrations)-1].Version
+	}
+	if latest["mysql"] != latest["sqlite"] {
+		t.Errorf("latest MySQL migration is %d but SQLite's is %d; add each new version to both", latest["mysql"], latest["sqlite"])
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	db, m := newSQLite(t)
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, err := m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) == 0 || !reflect.DeepEqual(done, pending) {
+		t.Fatalf("Up applied %v, want the pending %v", done, pending)
+	}
+	migrated := tables(t, db)
+
+	if done, err := m.Up(ctx); err != nil || len(done) != 0 {
+		t.Fatalf("second Up = %v, %v; want nothing applied", done, err)
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if !s.Applied || s.AppliedAt.IsZero() {
+			t.Errorf("after Up, %s: applied %v at %v", s.Migration, s.Applied, s.AppliedAt)
+		}
+	}
+
+	for range done {
+		if _, err := m.Down(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.Down(ctx); !errors.Is(err, migrate.ErrNothingApplied) {
+		t.Fatalf("Down with nothing applied: err = %v, want ErrNothingApplied", err)
+	}
+	if got := tables(t, db); len(got) != 0 {
+		t.Fatalf("after undoing every migration, tables %v remain", got)
+	}
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := tables(t, db); !reflect.DeepEqual(got, migrated) {
+		t.Errorf("migrating again made tables %v, want %v", got, migrated)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	ctx := context.Background()
+	db, m := newSQLite(t)
+	// A database built before its migrations were recorded.
+	migrations, err := migrate.Load("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, migration := range migrations {
+		for _, statement := range migrate.Statements(migration.Up) {
+			if _, err := db.Exec(statement); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if done, err := m.Up(ctx); !errors.Is(err, migrate.ErrUntracked) || len(done) != 0 {
+		t.Errorf("Up before Baseline = %v, %v; want nothing applied and ErrUntracked", done, err)
+	}
+	if err := m.Baseline(ctx, 999); err == nil {
+		t.Error("Baseline to a version with no migration succeeded")
+	}
+	if err := m.Baseline(ctx, m.Latest()); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := m.Pending(ctx); err != nil || len(pending) != 0 {
+		t.Errorf("after Baseline, Pending = %v, %v; want none", pending, err)
+	}
+	if err := m.Baseline(ctx, m.Latest()); err == nil {
+		t.Error("second Baseline succeeded")
+	}
+}
+
+func TestNewerDatabase(t *testing.T) {
+	ctx := context.Background()
+	db, m := newSQLite(t)
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'from_the_future', CURRENT_TIMESTAMP)", m.Latest()+1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); err == nil {
+		t.Error("Up on a database migrated by a newer server succeeded")
+	}
+	if _, err := m.Down(ctx); err == nil {
+		t.Error("Down on a database migrated by a newer server succeeded")
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	ctx := context.Background()
+	db, m := newSQLite(t)
+	if err := m.CheckVersion(ctx); err == nil {
+		t.Error("CheckVersion on an empty database succeeded")
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CheckVersion(ctx); err != nil {
+		t.Errorf("CheckVersion after Up: %v", err)
+	}
+	if _, err := m.Down(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CheckVersion(ctx); err == nil || !strings.Contains(err.Error(), fmt.Sprintf("needs %d", m.Latest())) {
+		t.Errorf("CheckVersion after Down = %v, want the version the server needs", err)
+	}
+	if _, err := db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'from_the_future', CURRENT_TIMESTAMP)", m.Latest()+1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CheckVersion(ctx); err == nil {
+		t.Error("CheckVersion on a database migrated by a newer server succeeded")
+	}
+}
+
+func TestStatements(t *testing.T) {
+	script := `-- Adds a column.
+ALTER TABLE meets
+    ADD COLUMN cancelled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Then fills it.
+UPDATE meets SET cancelled = TRUE WHERE name LIKE '%;%';
+UPDATE meets SET cancelled = FALSE`
+	want := []string{
+		"ALTER TABLE meets\n    ADD COLUMN cancelled BOOLEAN NOT NULL DEFAULT FALSE",
+		"UPDATE meets SET cancelled = TRUE WHERE name LIKE '%;%'",
+		"UPDATE meets SET cancelled = FALSE",
+	}
+	if got := migrate.Statements(script); !reflect.DeepEqual(got, want) {
+		t.Errorf("Statements = %q, want %q", got, want)
+	}
+}
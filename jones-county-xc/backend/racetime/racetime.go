@@ -0,0 +1,235 @@
+// Package racetime parses, formats and stores race times.
+//
+// Times are kept as whole milliseconds so they sort and compare numerically
+// in both Go and SQL, but are rendered as the familiar "mm:ss" style strings
+// everywhere a human (or the frontend) sees them.
+package racetime
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MetersPerMile is the length of a mile in meters.
+const MetersPerMile = 1609.344
+
+// Duration is a race time in milliseconds.
+type Duration int64
+
+// Parse accepts "mm:ss", "mm:ss.f" (any number of fractional digits, rounded
+// to the millisecond) and "h:mm:ss[.f]". Seconds always take two digits, as
+// do minutes after an hour, so that "16:4" is not taken for 16:04.
+func Parse(s string) (Duration, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid race time %q: want mm:ss or h:mm:ss", s)
+	}
+
+	secs, frac, hasFrac := strings.Cut(parts[len(parts)-1], ".")
+	wholeSecs, err := parseWhole(secs)
+	if err != nil || len(secs) != 2 || wholeSecs >= 60 {
+		return 0, fmt.Errorf("invalid race time %q: bad seconds", s)
+	}
+	fracMs, err := parseFraction(frac)
+	if err != nil || (hasFrac && frac == "") {
+		return 0, fmt.Errorf("invalid race time %q: bad fractional seconds", s)
+	}
+
+	var hours, mins int64
+	if len(parts) == 3 {
+		if hours, err = parseWhole(parts[0]); err != nil {
+			return 0, fmt.Errorf("invalid race time %q: bad hours", s)
+		}
+		if mins, err = parseWhole(parts[1]); err != nil || len(parts[1]) != 2 || mins >= 60 {
+			return 0, fmt.Errorf("invalid race time %q: bad minutes", s)
+		}
+	} else if mins, err = parseWhole(parts[0]); err != nil {
+		return 0, fmt.Errorf("invalid race time %q: bad minutes", s)
+	}
+
+	ms := (hours*3600+mins*60+wholeSecs)*1000 + fracMs
+	return Duration(ms), nil
+}
+
+func parseWhole(s string) (int64, error) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, fmt.Errorf("not a whole number: %q", s)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// parseFraction turns the digits after the decimal point into milliseconds,
+// rounding anything finer than a millisecond.
+func parseFraction(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if strings.TrimLeft(s, "0123456789") != "" {
+		return 0, fmt.Errorf("not a fraction: %q", s)
+	}
+	f, err := strconv.ParseFloat("0."+s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(f*1000 + 0.5), nil
+}
+
+// String formats d as "m:ss" or "h:mm:ss", adding only as many fractional
+// digits as are needed (e.g. "16:42", "16:42.3", "1:02:10.25").
+func (d Duration) String() string {
+	ms := int64(d)
+	sign := ""
+	if ms < 0 {
+		sign = "-"
+		ms = -ms
+	}
+
+	hours := ms / 3600000
+	mins := ms / 60000 % 60
+	secs := ms / 1000 % 60
+	frac := ms % 1000
+
+	var out string
+	if hours > 0 {
+		out = fmt.Sprintf("%s%d:%02d:%02d", sign, hours, mins, secs)
+	} else {
+		out = fmt.Sprintf("%s%d:%02d", sign, mins, secs)
+	}
+	if frac > 0 {
+		out += "." + strings.TrimRight(fmt.Sprintf("%03d", frac), "0")
+	}
+	return out
+}
+
+// Milliseconds returns d as a plain integer count of milliseconds.
+func (d Duration) Milliseconds() int64 {
+	return int64(d)
+}
+
+// PerMile returns the per-mile pace of covering meters in d, rounded to a
+// tenth of a second as paces are read, or 0 when meters is not positive.
+func (d Duration) PerMile(meters int) Duration {
+	if meters <= 0 {
+		return 0
+	}
+	return 100 * Duration(math.Round(float64(d)*MetersPerMile/float64(meters)/100))
+}
+
+// MarshalJSON emits the formatted string, e.g. "16:42.3".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts the formatted string form.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Scan implements sql.Scanner for integer millisecond columns.
+func (d *Duration) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*d = Duration(v)
+	case []byte:
+		n, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("racetime: cannot scan %q", v)
+		}
+		*d = Duration(n)
+	case nil:
+		return fmt.Errorf("racetime: cannot scan NULL into Duration")
+	default:
+		return fmt.Errorf("racetime: cannot scan %T", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (d Duration) Value() (driver.Value, error) {
+	return int64(d), nil
+}
+
+// NullDuration is a Duration that may be NULL, such as an athlete who has
+// not yet set a personal record.
+type NullDuration struct {
+	Duration Duration
+	Valid    bool
+}
+
+// ParseNull is Parse, except an empty string yields an invalid (NULL) value.
+func ParseNull(s string) (NullDuration, error) {
+	if strings.TrimSpace(s) == "" {
+		return NullDuration{}, nil
+	}
+	d, err := Parse(s)
+	if err != nil {
+		return NullDuration{}, err
+	}
+	return NullDuration{Duration: d, Valid: true}, nil
+}
+
+// String returns the formatted time, or "" when n is NULL.
+func (n NullDuration) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return n.Duration.String()
+}
+
+// MarshalJSON emits "" for NULL so existing clients keep seeing a string.
+func (n NullDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.String())
+}
+
+// UnmarshalJSON accepts "" or null as NULL and otherwise a formatted time.
+func (n *NullDuration) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*n = NullDuration{}
+		return nil
+	}
+	v, err := ParseNull(*s)
+	if err != nil {
+		return err
+	}
+	*n = v
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (n *NullDuration) Scan(src any) error {
+	if src == nil {
+		*n = NullDuration{}
+		return nil
+	}
+	if err := n.Duration.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Duration), nil
+}
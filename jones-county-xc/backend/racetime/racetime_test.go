@@ -0,0 +1,174 @@
+package racetime
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Duration
+		format string // what String gives back
+	}{
+		{"16:42", 1002000, "16:42"},
+		{" 16:42 ", 1002000, "16:42"},
+		{"5:07", 307000, "5:07"},
+		{"0:59", 59000, "0:59"},
+		{"16:42.3", 1002300, "16:42.3"},
+		{"16:42.30", 1002300, "16:42.3"},
+		{"16:42.25", 1002250, "16:42.25"},
+		{"16:42.1234", 1002123, "16:42.123"},
+		{"16:42.1235", 1002124, "16:42.124"},
+		{"16:59.9996", 1020000, "17:00"},
+		{"75:00", 4500000, "1:15:00"},
+		{"1:02:10", 3730000, "1:02:10"},
+		{"1:02:10.25", 3730250, "1:02:10.25"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+			continue
+		}
+		if s := got.String(); s != tt.format {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, s, tt.format)
+		}
+		if again, err := Parse(got.String()); err != nil || again != got {
+			t.Errorf("Parse(%q) = %d, %v; want it to round-trip to %d", got.String(), again, err, got)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", "16", "16:4", "16:420", "1:2:3", "1:2:03", "1:02:3", "16:60", "1:60:00",
+		"16:42.", "16:42.x", "16:4.2", "-16:42", "16:-4", "1:02:03:04", "ab:cd", ":42",
+	} {
+		if got, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %d, want an error", in, got)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		d    Duration
+		want string
+	}{
+		{0, "0:00"},
+		{1000, "0:01"},
+		{3600000, "1:00:00"},
+		{-61500, "-1:01.5"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("Duration(%d).String() = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Time Duration     `json:"time"`
+		PR   NullDuration `json:"pr"`
+		None NullDuration `json:"none"`
+	}{1002300, NullDuration{Duration: 1002000, Valid: true}, NullDuration{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"time":"16:42.3","pr":"16:42","none":""}`; string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+
+	var got struct {
+		Time  Duration     `json:"time"`
+		PR    NullDuration `json:"pr"`
+		Empty NullDuration `json:"empty"`
+		Null  NullDuration `json:"null"`
+	}
+	got.Null = NullDuration{Duration: 1, Valid: true}
+	err = json.Unmarshal([]byte(`{"time":"16:42.3","pr":"1:02:10","empty":"","null":null}`), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Time != 1002300 || got.PR != (NullDuration{Duration: 3730000, Valid: true}) || got.Empty.Valid || got.Null.Valid {
+		t.Errorf("Unmarshal = %+v", got)
+	}
+
+	for _, in := range []string{`{"time":"16:4"}`, `{"time":1002300}`, `{"pr":"fast"}`} {
+		var v struct {
+			Time Duration     `json:"time"`
+			PR   NullDuration `json:"pr"`
+		}
+		if err := json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", in)
+		}
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	var d Duration
+	if err := d.Scan(int64(1002300)); err != nil || d != 1002300 {
+		t.Errorf("Scan(int64) = %d, %v", d, err)
+	}
+	if err := d.Scan([]byte("960000")); err != nil || d != 960000 {
+		t.Errorf("Scan([]byte) = %d, %v", d, err)
+	}
+	for _, src := range []any{nil, "16:42", []byte("x"), 1.5} {
+		if err := d.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) succeeded", src)
+		}
+	}
+	if v, err := d.Value(); err != nil || v != int64(960000) {
+		t.Errorf("Value = %v, %v", v, err)
+	}
+
+	var n NullDuration
+	if err := n.Scan(int64(1002000)); err != nil || n != (NullDuration{Duration: 1002000, Valid: true}) {
+		t.Errorf("NullDuration.Scan(int64) = %+v, %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != int64(1002000) {
+		t.Errorf("NullDuration.Value = %v, %v", v, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("NullDuration.Scan(nil) = %+v, %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("NULL NullDuration.Value = %v, %v; want nil", v, err)
+	}
+	if err := n.Scan("soon"); err == nil {
+		t.Error("NullDuration.Scan(string) succeeded")
+	}
+}
+
+func TestParseNull(t *testing.T) {
+	if n, err := ParseNull("  "); err != nil || n.Valid {
+		t.Errorf("ParseNull(blank) = %+v, %v; want NULL", n, err)
+	}
+	if n, err := ParseNull("16:42"); err != nil || n != (NullDuration{Duration: 1002000, Valid: true}) {
+		t.Errorf("ParseNull(16:42) = %+v, %v", n, err)
+	}
+	if _, err := ParseNull("16:4"); err == nil {
+		t.Error("ParseNull(16:4) succeeded")
+	}
+}
+
+func TestPerMile(t *testing.T) {
+	tests := []struct {
+		d      Duration
+		meters int
+		want   string
+	}{
+		{960000, 5000, "5:09"},   // 5:08.994
+		{350000, 1000, "9:23.3"}, // 9:23.270
+		{300000, 1600, "5:01.8"}, // 5:01.752
+		{360000, 1609, "6:00.1"}, // 6:00.077 over a 1609 m split
+		{960000, 0, "0:00"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.PerMile(tt.meters).String(); got != tt.want {
+			t.Errorf("Duration(%d).PerMile(%d) = %q, want %q", tt.d, tt.meters, got, tt.want)
+		}
+	}
+}
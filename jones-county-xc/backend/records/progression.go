@@ -0,0 +1,77 @@
+package records
+
+import (
+	"math"
+	"sort"
+
+	"jones-county-xc/backend/racetime"
+)
+
+// Race is a result and the season it was run in.
+type Race struct {
+	Result
+	Season int
+}
+
+// Point is one race in a progression, with the athlete's bests as they
+// stood once it was run.
+type Point struct {
+	Race
+	SeasonBest racetime.Duration
+	// PR is the all-time best at the distance, over every season so far.
+	PR racetime.Duration
+	// First is set on a season's first race at the distance, which has
+	// nothing to compare against; Delta and Improvement are then zero.
+	First bool
+	// Delta is the change from the previous race; negative is faster.
+	Delta racetime.Duration
+	// Improvement is how much faster than the previous race this one was,
+	// as a percentage of the previous time; negative is slower.
+	Improvement float64
+}
+
+// Series is an athlete's races at one distance in one season.
+type Series struct {
+	Season   int
+	Distance int
+	Points   []Point
+}
+
+// Progression groups races by season and distance, sorted by both, and
+// works out the running bests. Races must be in the order they were run.
+func Progression(races []Race) []Series {
+	var out []Series
+	index := map[[2]int]int{}
+	pr := map[int]racetime.Duration{}
+
+	for _, race := range races {
+		k := [2]int{race.Season, race.Distance}
+		i, ok := index[k]
+		if !ok {
+			i = len(out)
+			index[k] = i
+			out = append(out, Series{Season: race.Season, Distance: race.Distance})
+		}
+		s := &out[i]
+
+		if best, ok := pr[race.Distance]; !ok || race.Time < best {
+			pr[race.Distance] = race.Time
+		}
+		p := Point{Race: race, SeasonBest: race.Time, PR: pr[race.Distance], First: len(s.Points) == 0}
+		if !p.First {
+			prev := s.Points[len(s.Points)-1]
+			p.SeasonBest = min(prev.SeasonBest, race.Time)
+			p.Delta = race.Time - prev.Time
+			p.Improvement = math.Round(float64(prev.Time-race.Time)/float64(prev.Time)*10000) / 100
+		}
+		s.Points = append(s.Points, p)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Season != out[j].Season {
+			return out[i].Season < out[j].Season
+		}
+		return out[i].Distance < out[j].Distance
+	})
+	return out
+}
@@ -0,0 +1,90 @@
+// Package records derives an athlete's personal records from their results.
+//
+// A personal record (PR) is the fastest time an athlete has run at a race
+// distance. A course PR is the fastest at a distance on one course, since
+// hills and footing make times on different courses hard to compare. A
+// result "sets" a record when it is strictly faster than everything the
+// athlete ran before it under the same key; an athlete's first race at a
+// distance always sets one.
+package records
+
+import (
+	"sort"
+
+	"jones-county-xc/backend/racetime"
+)
+
+// Result is one of an athlete's finishes. CourseID is 0 when the course is
+// not known, in which case the result only counts toward the overall PR.
+type Result struct {
+	ID       int
+	Distance int
+	CourseID int
+	Time     racetime.Duration
+}
+
+// Record is the best time at a distance, either overall (CourseID 0) or on
+// one course, and the result it was run in.
+type Record struct {
+	Distance int
+	CourseID int
+	ResultID int
+	Time     racetime.Duration
+}
+
+// Flags says which records a result set when it was run.
+type Flags struct {
+	PR       bool
+	CoursePR bool
+}
+
+type key struct{ distance, course int }
+
+// Compute returns an athlete's current records, sorted by distance with the
+// overall record before course records, and the flags for every result
+// that set one. Results must be in the order they were run.
+func Compute(results []Result) ([]Record, map[int]Flags) {
+	best := map[key]Record{}
+	flags := map[int]Flags{}
+
+	improve := func(k key, r Result) bool {
+		if cur, ok := best[k]; ok && cur.Time <= r.Time {
+			return false
+		}
+		best[k] = Record{Distance: k.distance, CourseID: k.course, ResultID: r.ID, Time: r.Time}
+		return true
+	}
+
+	for _, r := range results {
+		var f Flags
+		f.PR = improve(key{r.Distance, 0}, r)
+		if r.CourseID != 0 {
+			f.CoursePR = improve(key{r.Distance, r.CourseID}, r)
+		}
+		if f.PR || f.CoursePR {
+			flags[r.ID] = f
+		}
+	}
+
+	out := make([]Record, 0, len(best))
+	for _, rec := range best {
+		out = append(out, rec)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Distance != out[j].Distance {
+			return out[i].Distance < out[j].Distance
+		}
+		return out[i].CourseID < out[j].CourseID
+	})
+	return out, flags
+}
+
+// Best returns the overall record at distance, if the athlete has run it.
+func Best(recs []Record, distance int) (Record, bool) {
+	for _, rec := range recs {
+		if rec.Distance == distance && rec.CourseID == 0 {
+			return rec, true
+		}
+	}
+	return Record{}, false
+}
@@ -0,0 +1,119 @@
+package records
+
+import (
+	"testing"
+
+	"jones-county-xc/backend/racetime"
+)
+
+func sec(s int) racetime.Duration { return racetime.Duration(s * 1000) }
+
+func TestComputeFlagsOnlyImprovements(t *testing.T) {
+	// Results in the order they were run: two courses at 5K and one 2-mile.
+	results := []Result{
+		{ID: 1, Distance: 5000, CourseID: 1, Time: sec(1200)},
+		{ID: 2, Distance: 5000, CourseID: 2, Time: sec(1230)},
+		{ID: 3, Distance: 5000, CourseID: 1, Time: sec(1190)},
+		{ID: 4, Distance: 3218, CourseID: 1, Time: sec(700)},
+		{ID: 5, Distance: 5000, CourseID: 2, Time: sec(1210)},
+		{ID: 6, Distance: 5000, CourseID: 1, Time: sec(1190)},
+		{ID: 7, Distance: 5000, CourseID: 0, Time: sec(1180)},
+	}
+	recs, flags := Compute(results)
+
+	wantFlags := map[int]Flags{
+		1: {PR: true, CoursePR: true},
+		2: {CoursePR: true},
+		3: {PR: true, CoursePR: true},
+		4: {PR: true, CoursePR: true},
+		5: {CoursePR: true},
+		// 6 ties 3 on the same course, which is not a new record.
+		7: {PR: true},
+	}
+	if len(flags) != len(wantFlags) {
+		t.Errorf("got flags for %d results, want %d: %v", len(flags), len(wantFlags), flags)
+	}
+	for id, want := range wantFlags {
+		if flags[id] != want {
+			t.Errorf("result %d flags = %+v, want %+v", id, flags[id], want)
+		}
+	}
+
+	wantRecs := []Record{
+		{Distance: 3218, CourseID: 0, ResultID: 4, Time: sec(700)},
+		{Distance: 3218, CourseID: 1, ResultID: 4, Time: sec(700)},
+		{Distance: 5000, CourseID: 0, ResultID: 7, Time: sec(1180)},
+		{Distance: 5000, CourseID: 1, ResultID: 3, Time: sec(1190)},
+		{Distance: 5000, CourseID: 2, ResultID: 5, Time: sec(1210)},
+	}
+	if len(recs) != len(wantRecs) {
+		t.Fatalf("got %d records, want %d: %+v", len(recs), len(wantRecs), recs)
+	}
+	for i, want := range wantRecs {
+		if recs[i] != want {
+			t.Errorf("record %d = %+v, want %+v", i, recs[i], want)
+		}
+	}
+}
+
+func TestBest(t *testing.T) {
+	recs, _ := Compute([]Result{
+		{ID: 1, Distance: 5000, CourseID: 1, Time: sec(1200)},
+		{ID: 2, Distance: 4000, CourseID: 1, Time: sec(950)},
+	})
+	if rec, ok := Best(recs, 5000); !ok || rec.ResultID != 1 {
+		t.Errorf("Best(5000) = %+v, %v; want result 1", rec, ok)
+	}
+	if _, ok := Best(recs, 3218); ok {
+		t.Error("Best(3218) found a record for a distance never run")
+	}
+	if _, ok := Best(nil, 5000); ok {
+		t.Error("Best on no records found one")
+	}
+}
+
+func TestProgression(t *testing.T) {
+	race := func(id, season, distance, s int) Race {
+		return Race{Result: Result{ID: id, Distance: distance, CourseID: 1, Time: sec(s)}, Season: season}
+	}
+	series := Progression([]Race{
+		race(1, 2024, 5000, 1250),
+		race(2, 2024, 5000, 1200),
+		race(3, 2024, 3218, 720),
+		race(4, 2024, 5000, 1224),
+		race(5, 2025, 5000, 1210),
+		race(6, 2025, 5000, 1180),
+	})
+
+	want := []struct {
+		season, distance int
+		seasonBest, pr   []int
+		delta            []int
+		improvement      []float64
+	}{
+		{2024, 3218, []int{720}, []int{720}, []int{0}, []float64{0}},
+		{2024, 5000, []int{1250, 1200, 1200}, []int{1250, 1200, 1200}, []int{0, -50, 24}, []float64{0, 4, -2}},
+		// The new season starts its own best but not its own PR.
+		{2025, 5000, []int{1210, 1180}, []int{1200, 1180}, []int{0, -30}, []float64{0, 2.48}},
+	}
+	if len(series) != len(want) {
+		t.Fatalf("got %d series, want %d", len(series), len(want))
+	}
+	for i, w := range want {
+		s := series[i]
+		if s.Season != w.season || s.Distance != w.distance || len(s.Points) != len(w.seasonBest) {
+			t.Fatalf("series %d = %d %dm with %d races, want %d %dm with %d",
+				i, s.Season, s.Distance, len(s.Points), w.season, w.distance, len(w.seasonBest))
+		}
+		for j, p := range s.Points {
+			if p.First != (j == 0) {
+				t.Errorf("%d %dm race %d First = %v", s.Season, s.Distance, j, p.First)
+			}
+			if p.SeasonBest != sec(w.seasonBest[j]) || p.PR != sec(w.pr[j]) || p.Delta != sec(w.delta[j]) || p.Improvement != w.improvement[j] {
+				t.Errorf("%d %dm race %d = best %v pr %v delta %v improvement %v, want %v %v %v %v",
+					s.Season, s.Distance, j, p.SeasonBest, p.PR, p.Delta, p.Improvement,
+					sec(w.seasonBest[j]), sec(w.pr[j]), sec(w.delta[j]), w.improvement[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package scoring computes cross-country team scores from a race's finish
+// list using the standard (NFHS) rules:
+//
+//   - Only teams with at least five finishers are scored. Runners from
+//     incomplete teams, and anyone running as an individual, are removed
+//     before team places are handed out.
+//   - A team's first five finishers score; their team places are summed and
+//     the lowest total wins.
+//   - The sixth and seventh finishers do not score but still take a team
+//     place, pushing back ("displacing") runners from other teams.
+//   - Eighth and later finishers neither score nor displace.
+//   - Tied totals are broken by the sixth runners: the better-placed sixth
+//     runner wins, and a team with a sixth runner beats one without. If
+//     neither team has a sixth runner the tie stands. Three or more teams
+//     on one score are ranked by their sixth runners all together.
+package scoring
+
+import (
+	"sort"
+
+	"jones-county-xc/backend/racetime"
+)
+
+const (
+	// Scorers is how many runners count toward a team's score.
+	Scorers = 5
+	// Displacers is how many runners per team receive team places.
+	Displacers = 7
+)
+
+// Finisher is one runner's overall finish in a race.
+type Finisher struct {
+	AthleteID int
+	Name      string
+	// Team is the runner's school; empty means an unattached individual.
+	Team  string
+	Place int
+	Time  racetime.Duration
+}
+
+// Runner is a finisher as seen by team scoring.
+type Runner struct {
+	AthleteID int               `json:"athleteId"`
+	Name      string            `json:"name"`
+	Place     int               `json:"place"`
+	Time      racetime.Duration `json:"time"`
+	// TeamPlace is the place used for scoring, or 0 if the runner was
+	// removed (incomplete team or beyond the seventh runner).
+	TeamPlace int `json:"teamPlace"`
+	// Scoring is true for a team's first five finishers.
+	Scoring bool `json:"scoring"`
+}
+
+// TeamScore is one school's result.
+type TeamScore struct {
+	Team string `json:"team"`
+	// Place is the team's finishing position, or 0 for an incomplete team.
+	Place    int  `json:"place"`
+	Score    int  `json:"score"`
+	Complete bool `json:"complete"`
+	// Tiebreak explains how a tied score was settled, if it was.
+	Tiebreak string   `json:"tiebreak,omitempty"`
+	Runners  []Runner `json:"runners"`
+}
+
+// Score computes team scores for a finish list. Finishers may be given in
+// any order; they are ranked by Place. Complete teams come first, ordered
+// by team place, followed by incomplete teams alphabetically.
+func Score(finishers []Finisher) []TeamScore {
+	ordered := append([]Finisher(nil), finishers...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Place < ordered[j].Place })
+
+	counts := map[string]int{}
+	for _, f := range ordered {
+		if f.Team != "" {
+			counts[f.Team]++
+		}
+	}
+
+	teams := map[string]*TeamScore{}
+	var names []string
+	teamPlace := 0
+	for _, f := range ordered {
+		if f.Team == "" {
+			continue
+		}
+		t, ok := teams[f.Team]
+		if !ok {
+			t = &TeamScore{Team: f.Team, Complete: counts[f.Team] >= Scorers}
+			teams[f.Team] = t
+			names = append(names, f.Team)
+		}
+
+		r := Runner{AthleteID: f.AthleteID, Name: f.Name, Place: f.Place, Time: f.Time}
+		if t.Complete && len(t.Runners) < Displacers {
+			teamPlace++
+			r.TeamPlace = teamPlace
+			if len(t.Runners) < Scorers {
+				r.Scoring = true
+				t.Score += teamPlace
+			}
+		}
+		t.Runners = append(t.Runners, r)
+	}
+
+	out := make([]TeamScore, 0, len(names))
+	for _, name := range names {
+		t := teams[name]
+		if !t.Complete {
+			t.Score = 0
+		}
+		out = append(out, *t)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Complete != b.Complete {
+			return a.Complete
+		}
+		if !a.Complete {
+			return a.Team < b.Team
+		}
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return sixthBeats(a, b)
+	})
+
+	// Teams on the same score are settled together: the sort has put
+	// them in order of their sixth runners, and those the sixth runner
+	// cannot separate share a place.
+	for start := 0; start < len(out) && out[start].Complete; {
+		end := start + 1
+		for end < len(out) && out[end].Complete && out[end].Score == out[start].Score {
+			end++
+		}
+		tied := out[start:end]
+		for i := range tied {
+			tied[i].Place = start + i + 1
+			if i > 0 && !sixthBeats(tied[i-1], tied[i]) {
+				tied[i].Place = tied[i-1].Place
+			}
+		}
+		for i := range tied {
+			if len(tied) == 1 {
+				break
+			}
+			shared := i > 0 && tied[i-1].Place == tied[i].Place ||
+				i+1 < len(tied) && tied[i+1].Place == tied[i].Place
+			if shared {
+				tied[i].Tiebreak = "tie stands"
+			} else {
+				tied[i].Tiebreak = "sixth runner"
+			}
+		}
+		start = end
+	}
+	return out
+}
+
+// sixthBeats reports whether a wins a tie with b on sixth runners.
+func sixthBeats(a, b TeamScore) bool {
+	as, bs := sixthPlace(a), sixthPlace(b)
+	switch {
+	case as == 0:
+		return false
+	case bs == 0:
+		return true
+	default:
+		return as < bs
+	}
+}
+
+// sixthPlace is the team place of t's sixth runner, or 0 if it has none.
+func sixthPlace(t TeamScore) int {
+	if len(t.Runners) < Scorers+1 {
+		return 0
+	}
+	return t.Runners[Scorers].TeamPlace
+}
@@ -0,0 +1,158 @@
+package scoring
+
+import (
+	"testing"
+)
+
+// finishList builds finishers from a string of team letters in finish
+// order; '-' is an unattached individual.
+func finishList(order string) []Finisher {
+	out := make([]Finisher, len(order))
+	for i, c := range order {
+		team := string(c)
+		if c == '-' {
+			team = ""
+		}
+		out[i] = Finisher{AthleteID: i + 1, Team: team, Place: i + 1}
+	}
+	return out
+}
+
+func teamPlaces(t TeamScore) []int {
+	var places []int
+	for _, r := range t.Runners {
+		places = append(places, r.TeamPlace)
+	}
+	return places
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScoreDisplacersAndIncompleteTeams(t *testing.T) {
+	// A has eight runners, B seven, C only three, plus one individual.
+	got := Score(finishList("ABCABA-BABABACABABC"))
+
+	want := []struct {
+		team       string
+		place      int
+		score      int
+		complete   bool
+		teamPlaces []int
+	}{
+		{"A", 1, 25, true, []int{1, 3, 5, 7, 9, 11, 12, 0}},
+		{"B", 2, 30, true, []int{2, 4, 6, 8, 10, 13, 14}},
+		{"C", 0, 0, false, []int{0, 0, 0}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d teams, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Team != w.team || g.Place != w.place || g.Score != w.score || g.Complete != w.complete {
+			t.Errorf("team %d = %s place %d score %d complete %v, want %s place %d score %d complete %v",
+				i, g.Team, g.Place, g.Score, g.Complete, w.team, w.place, w.score, w.complete)
+		}
+		if tp := teamPlaces(g); !equalInts(tp, w.teamPlaces) {
+			t.Errorf("%s team places = %v, want %v", g.Team, tp, w.teamPlaces)
+		}
+	}
+
+	for i, r := range got[0].Runners {
+		if r.Scoring != (i < Scorers) {
+			t.Errorf("A runner %d scoring = %v", i+1, r.Scoring)
+		}
+	}
+}
+
+func TestScoreSixthRunnerTiebreak(t *testing.T) {
+	tests := []struct {
+		name   string
+		order  string
+		winner string
+	}{
+		// A: 1+2+5+9+11 = 28, sixth 12. B: 3+4+6+7+8 = 28, sixth 10.
+		{"better sixth runner", "AABBABBBABAA", "B"},
+		// Same totals, but A has no sixth runner at all.
+		{"missing sixth runner", "AABBABBBABA", "B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Score(finishList(tt.order))
+			if len(got) != 2 {
+				t.Fatalf("got %d teams, want 2", len(got))
+			}
+			if got[0].Score != got[1].Score {
+				t.Fatalf("scores %d and %d are not tied", got[0].Score, got[1].Score)
+			}
+			if got[0].Team != tt.winner || got[0].Place != 1 || got[1].Place != 2 {
+				t.Errorf("got %s 1st (place %d), %s (place %d); want %s to win",
+					got[0].Team, got[0].Place, got[1].Team, got[1].Place, tt.winner)
+			}
+			if got[0].Tiebreak != "sixth runner" {
+				t.Errorf("tiebreak = %q, want %q", got[0].Tiebreak, "sixth runner")
+			}
+		})
+	}
+}
+
+func TestScoreUnsortedInput(t *testing.T) {
+	finishers := finishList("AAAAABBBBB")
+	finishers[0], finishers[9] = finishers[9], finishers[0]
+
+	got := Score(finishers)
+	if got[0].Team != "A" || got[0].Score != 15 || got[1].Score != 40 {
+		t.Errorf("got %s %d / %s %d, want A 15 / B 40", got[0].Team, got[0].Score, got[1].Team, got[1].Score)
+	}
+}
+
+func TestScoreThreeWayTie(t *testing.T) {
+	type team struct {
+		team     string
+		place    int
+		tiebreak string
+	}
+	tests := []struct {
+		name  string
+		order string
+		want  []team
+	}{
+		// A, B and C all score 40. Only A has a sixth runner, so A wins
+		// and B and C cannot be separated; C, which finished first, is
+		// listed first.
+		{"two still tied", "CBCAAABBBCCAABCA", []team{
+			{"A", 1, "sixth runner"}, {"C", 2, "tie stands"}, {"B", 2, "tie stands"},
+		}},
+		// A's sixth runner beats B's, and B has one where C has none.
+		{"all settled", "CBCAAABBBCCAABCAB", []team{
+			{"A", 1, "sixth runner"}, {"B", 2, "sixth runner"}, {"C", 3, "sixth runner"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Score(finishList(tt.order))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d teams, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Score != 40 {
+					t.Fatalf("%s scored %d, want 40", g.Team, g.Score)
+				}
+				if g.Team != w.team || g.Place != w.place || g.Tiebreak != w.tiebreak {
+					t.Errorf("team %d = %s place %d %q, want %s place %d %q",
+						i, g.Team, g.Place, g.Tiebreak, w.team, w.place, w.tiebreak)
+				}
+			}
+		})
+	}
+}
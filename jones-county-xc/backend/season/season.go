@@ -0,0 +1,42 @@
+// Package season works out which cross-country season a date falls in and
+// what grade an athlete is in during a given season.
+//
+// A season is named by the calendar year its fall races are run in and spans
+// the school year: 1 July of that year to 30 June of the next. Seniors in
+// the 2025 season graduate in 2026.
+package season
+
+import "time"
+
+// YearOf returns the season year a date falls in.
+func YearOf(t time.Time) int {
+	if t.Month() >= time.July {
+		return t.Year()
+	}
+	return t.Year() - 1
+}
+
+// Bounds returns the first and last day of a season year.
+func Bounds(year int) (start, end time.Time) {
+	start = time.Date(year, time.July, 1, 0, 0, 0, 0, time.UTC)
+	end = time.Date(year+1, time.June, 30, 0, 0, 0, 0, time.UTC)
+	return start, end
+}
+
+// Grade returns the grade an athlete graduating in gradYear is in during
+// the season year. The result may fall outside 9-12 for athletes who have
+// already graduated or not yet reached high school.
+func Grade(gradYear, year int) int {
+	return 12 - (gradYear - (year + 1))
+}
+
+// GraduationYear is the inverse of Grade: the class of an athlete who is in
+// grade during the season year.
+func GraduationYear(grade, year int) int {
+	return year + 1 + 12 - grade
+}
+
+// InHighSchool reports whether grade is 9-12.
+func InHighSchool(grade int) bool {
+	return grade >= 9 && grade <= 12
+}
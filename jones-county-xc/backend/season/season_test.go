@@ -0,0 +1,43 @@
+package season
+
+import (
+	"testing"
+	"time"
+)
+
+func TestYearOf(t *testing.T) {
+	tests := []struct {
+		date string
+		want int
+	}{
+		{"2025-08-15", 2025},
+		{"2025-11-01", 2025},
+		{"2026-03-10", 2025},
+		{"2026-06-30", 2025},
+		{"2026-07-01", 2026},
+	}
+	for _, tt := range tests {
+		d, _ := time.Parse("2006-01-02", tt.date)
+		if got := YearOf(d); got != tt.want {
+			t.Errorf("YearOf(%s) = %d, want %d", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestGradeRoundTrip(t *testing.T) {
+	// The class of 2026 are seniors in the 2025 season and freshmen in 2022.
+	if got := Grade(2026, 2025); got != 12 {
+		t.Errorf("Grade(2026, 2025) = %d, want 12", got)
+	}
+	if got := Grade(2026, 2022); got != 9 {
+		t.Errorf("Grade(2026, 2022) = %d, want 9", got)
+	}
+	if got := Grade(2026, 2026); InHighSchool(got) {
+		t.Errorf("Grade(2026, 2026) = %d, want graduated", got)
+	}
+	for grade := 9; grade <= 12; grade++ {
+		if got := Grade(GraduationYear(grade, 2025), 2025); got != grade {
+			t.Errorf("round trip grade %d = %d", grade, got)
+		}
+	}
+}
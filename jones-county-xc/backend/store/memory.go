@@ -0,0 +1,226 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+)
+
+// Memory is a Store that keeps everything in process memory and loses it
+// on exit. It enforces the same keys, unique constraints and foreign keys
+// as the migrated schema, reporting violations with this package's errors,
+// and is safe for concurrent use.
+//
+// A transaction holds the store's lock from BeginTx until Commit or
+// Rollback, so other callers wait for it, and it works on a copy of the
+// tables that Commit puts in place.
+type Memory struct {
+	sqlcQueries
+	mu     sync.Mutex
+	tables *tables
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory returns an empty Memory store holding only the home team, as a
+// fresh database does.
+func NewMemory() *Memory {
+	m := &Memory{tables: newTables()}
+	m.sqlcQueries = sqlcQueries{memQueries{with: m.with}}
+	return m
+}
+
+func (m *Memory) with(ctx context.Context, f func(t *tables) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return f(m.tables)
+}
+
+func (m *Memory) BeginTx(ctx context.Context) (Tx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	tx := &memTx{store: m, tables: m.tables.clone()}
+	tx.sqlcQueries = sqlcQueries{memQueries{with: tx.with}}
+	return tx, nil
+}
+
+type memTx struct {
+	sqlcQueries
+	store  *Memory
+	tables *tables
+	done   bool
+}
+
+func (tx *memTx) with(ctx context.Context, f func(t *tables) error) error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f(tx.tables)
+}
+
+func (tx *memTx) Commit() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.store.tables = tx.tables
+	tx.done = true
+	tx.store.mu.Unlock()
+	return nil
+}
+
+func (tx *memTx) Rollback() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	tx.store.mu.Unlock()
+	return nil
+}
+
+// memQueries answers the sqlc queries against the tables with gives it,
+// which holds whatever lock they need.
+type memQueries struct {
+	with func(ctx context.Context, f func(t *tables) error) error
+}
+
+type rosterKey struct{ seasonID, athleteID int32 }
+
+type userAthleteKey struct{ userID, athleteID int32 }
+
+// tables is one copy of every table. Rows are stored by value and the split
+// lists are replaced rather than changed in place, so clone need only copy
+// the maps.
+type tables struct {
+	lastID       map[string]int32
+	teams        map[int32]dbsqlc.Team
+	athletes     map[int32]dbsqlc.Athlete
+	seasons      map[int32]dbsqlc.Season
+	roster       map[rosterKey]int32 // grade
+	courses      map[int32]dbsqlc.Course
+	meets        map[int32]dbsqlc.Meet
+	races        map[int32]dbsqlc.Race
+	results      map[int32]dbsqlc.Result
+	splits       map[int32][]dbsqlc.ResultSplit // by result, in split order
+	records      map[int32]dbsqlc.PersonalRecord
+	users        map[int32]dbsqlc.User
+	userAthletes map[userAthleteKey]bool
+	sessions     map[string]dbsqlc.Session
+}
+
+func newTables() *tables {
+	t := &tables{
+		lastID:       map[string]int32{},
+		teams:        map[int32]dbsqlc.Team{},
+		athletes:     map[int32]dbsqlc.Athlete{},
+		seasons:      map[int32]dbsqlc.Season{},
+		roster:       map[rosterKey]int32{},
+		courses:      map[int32]dbsqlc.Course{},
+		meets:        map[int32]dbsqlc.Meet{},
+		races:        map[int32]dbsqlc.Race{},
+		results:      map[int32]dbsqlc.Result{},
+		splits:       map[int32][]dbsqlc.ResultSplit{},
+		records:      map[int32]dbsqlc.PersonalRecord{},
+		users:        map[int32]dbsqlc.User{},
+		userAthletes: map[userAthleteKey]bool{},
+		sessions:     map[string]dbsqlc.Session{},
+	}
+	id := t.nextID("teams")
+	t.teams[id] = dbsqlc.Team{ID: id, Name: "Jones County", ShortName: sql.NullString{String: "JC", Valid: true}, IsHome: true}
+	return t
+}
+
+func (t *tables) clone() *tables {
+	return &tables{
+		lastID:       maps.Clone(t.lastID),
+		teams:        maps.Clone(t.teams),
+		athletes:     maps.Clone(t.athletes),
+		seasons:      maps.Clone(t.seasons),
+		roster:       maps.Clone(t.roster),
+		courses:      maps.Clone(t.courses),
+		meets:        maps.Clone(t.meets),
+		races:        maps.Clone(t.races),
+		results:      maps.Clone(t.results),
+		splits:       maps.Clone(t.splits),
+		records:      maps.Clone(t.records),
+		users:        maps.Clone(t.users),
+		userAthletes: maps.Clone(t.userAthletes),
+		sessions:     maps.Clone(t.sessions),
+	}
+}
+
+// nextID is the next AUTO_INCREMENT value for table.
+func (t *tables) nextID(table string) int32 {
+	t.lastID[table]++
+	return t.lastID[table]
+}
+
+// insertResult is the sql.Result of an INSERT.
+type insertResult struct{ id int32 }
+
+func (r insertResult) LastInsertId() (int64, error) { return int64(r.id), nil }
+func (r insertResult) RowsAffected() (int64, error) { return 1, nil }
+
+// sortedValues returns a map's values ordered by compare.
+func sortedValues[K comparable, V any](m map[K]V, compare func(a, b V) int) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	slices.SortStableFunc(values, compare)
+	return values
+}
+
+// sameText compares names the way MySQL's default case-insensitive
+// collation does for unique keys and lookups.
+func sameText(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// compareText orders names case-insensitively, as ORDER BY name does.
+func compareText(a, b string) int {
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// compareNullLast orders NULLs after everything else, as the races query's
+// ORDER BY start_time IS NULL, start_time does; compareNullFirst orders them
+// before, as a plain ORDER BY does.
+func compareNullLast(a, b sql.NullString) int {
+	if a.Valid != b.Valid {
+		if a.Valid {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(a.String, b.String)
+}
+
+func compareNullFirst(a, b sql.NullString) int {
+	if a.Valid != b.Valid {
+		if a.Valid {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Compare(a.String, b.String)
+}
+
+// between reports whether date d falls in [start, end], as SQL's BETWEEN
+// does for DATE columns.
+func between(d, start, end time.Time) bool {
+	return !d.Before(start) && !d.After(end)
+}
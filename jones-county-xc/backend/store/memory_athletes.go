@@ -0,0 +1,425 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+	"jones-county-xc/backend/racetime"
+)
+
+func athleteRow(a dbsqlc.Athlete) dbsqlc.GetAthleteByIDRow {
+	return dbsqlc.GetAthleteByIDRow{
+		ID:               a.ID,
+		TeamID:           a.TeamID,
+		Name:             a.Name,
+		GraduationYear:   a.GraduationYear,
+		PersonalRecordMs: a.PersonalRecordMs,
+		Events:           a.Events.String,
+	}
+}
+
+// fastestFirst orders athletes by PR, leaving out those with none, and keeps
+// the first five.
+func fastestFirst(athletes []dbsqlc.Athlete) []dbsqlc.GetAthleteByIDRow {
+	athletes = slices.DeleteFunc(athletes, func(a dbsqlc.Athlete) bool { return !a.PersonalRecordMs.Valid })
+	slices.SortStableFunc(athletes, func(a, b dbsqlc.Athlete) int {
+		return cmp.Or(cmp.Compare(a.PersonalRecordMs.Duration, b.PersonalRecordMs.Duration), cmp.Compare(a.ID, b.ID))
+	})
+	rows := make([]dbsqlc.GetAthleteByIDRow, 0, 5)
+	for _, a := range athletes[:min(len(athletes), 5)] {
+		rows = append(rows, athleteRow(a))
+	}
+	return rows
+}
+
+// athleteResults is an athlete's results in the order they were run: by
+// meet date, then race start time, then entry.
+func (t *tables) athleteResults(athleteID sql.NullInt32) []dbsqlc.Result {
+	var results []dbsqlc.Result
+	if !athleteID.Valid {
+		return nil
+	}
+	for _, r := range t.results {
+		if r.AthleteID == athleteID {
+			results = append(results, r)
+		}
+	}
+	slices.SortFunc(results, func(a, b dbsqlc.Result) int {
+		raceA, raceB := t.races[a.RaceID], t.races[b.RaceID]
+		return cmp.Or(
+			t.meets[raceA.MeetID].Date.Compare(t.meets[raceB.MeetID].Date),
+			compareNullFirst(raceA.StartTime, raceB.StartTime),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return results
+}
+
+// raceCourse is the course a race is run on: its own, else its meet's, else
+// 0.
+func (t *tables) raceCourse(race dbsqlc.Race) int32 {
+	if race.CourseID.Valid {
+		return race.CourseID.Int32
+	}
+	return t.meets[race.MeetID].CourseID.Int32
+}
+
+func (q memQueries) ListAthletes(ctx context.Context, arg dbsqlc.ListAthletesParams) ([]dbsqlc.ListAthletesRow, error) {
+	var rows []dbsqlc.ListAthletesRow
+	err := q.with(ctx, func(t *tables) error {
+		for key, grade := range t.roster {
+			a := t.athletes[key.athleteID]
+			if key.seasonID != arg.SeasonID || a.TeamID != arg.TeamID {
+				continue
+			}
+			rows = append(rows, dbsqlc.ListAthletesRow{
+				ID:               a.ID,
+				TeamID:           a.TeamID,
+				Name:             a.Name,
+				Grade:            grade,
+				GraduationYear:   a.GraduationYear,
+				PersonalRecordMs: a.PersonalRecordMs,
+				Events:           a.Events.String,
+			})
+		}
+		slices.SortFunc(rows, func(a, b dbsqlc.ListAthletesRow) int {
+			return cmp.Or(cmp.Compare(a.Grade, b.Grade), compareText(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListAthletesAllSeasons(ctx context.Context, teamID int32) ([]dbsqlc.ListAthletesAllSeasonsRow, error) {
+	var rows []dbsqlc.ListAthletesAllSeasonsRow
+	err := q.with(ctx, func(t *tables) error {
+		athletes := sortedValues(t.athletes, func(a, b dbsqlc.Athlete) int {
+			return cmp.Or(cmp.Compare(b.GraduationYear, a.GraduationYear), compareText(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		for _, a := range athletes {
+			if a.TeamID == teamID {
+				rows = append(rows, dbsqlc.ListAthletesAllSeasonsRow(athleteRow(a)))
+			}
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) GetAthleteByID(ctx context.Context, id int32) (dbsqlc.GetAthleteByIDRow, error) {
+	var row dbsqlc.GetAthleteByIDRow
+	err := q.with(ctx, func(t *tables) error {
+		a, ok := t.athletes[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		row = athleteRow(a)
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) CreateAthlete(ctx context.Context, arg dbsqlc.CreateAthleteParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		if _, ok := t.teams[arg.TeamID]; !ok {
+			return ErrNoReferencedRow
+		}
+		id := t.nextID("athletes")
+		t.athletes[id] = dbsqlc.Athlete{
+			ID:               id,
+			TeamID:           arg.TeamID,
+			Name:             arg.Name,
+			GraduationYear:   arg.GraduationYear,
+			PersonalRecordMs: arg.ManualPrMs,
+			ManualPrMs:       arg.ManualPrMs,
+		}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateAthlete(ctx context.Context, arg dbsqlc.UpdateAthleteParams) error {
+	return q.with(ctx, func(t *tables) error {
+		a, ok := t.athletes[arg.ID]
+		if !ok {
+			return nil
+		}
+		if _, ok := t.teams[arg.TeamID]; !ok {
+			return ErrNoReferencedRow
+		}
+		a.TeamID, a.Name, a.GraduationYear, a.ManualPrMs = arg.TeamID, arg.Name, arg.GraduationYear, arg.ManualPrMs
+		t.athletes[arg.ID] = a
+		return nil
+	})
+}
+
+func (q memQueries) DeleteAthlete(ctx context.Context, id int32) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		if _, ok := t.athletes[id]; !ok {
+			return nil
+		}
+		for _, r := range t.results {
+			if r.AthleteID.Valid && r.AthleteID.Int32 == id {
+				return ErrReferenced
+			}
+		}
+		n = 1
+		delete(t.athletes, id)
+		for key := range t.roster {
+			if key.athleteID == id {
+				delete(t.roster, key)
+			}
+		}
+		for pid, p := range t.records {
+			if p.AthleteID == id {
+				delete(t.records, pid)
+			}
+		}
+		for key := range t.userAthletes {
+			if key.athleteID == id {
+				delete(t.userAthletes, key)
+			}
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (q memQueries) ListAthleteTeamsIn(ctx context.Context, ids []int32) ([]dbsqlc.ListAthleteTeamsInRow, error) {
+	var rows []dbsqlc.ListAthleteTeamsInRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, a := range sortedValues(t.athletes, func(a, b dbsqlc.Athlete) int { return cmp.Compare(a.ID, b.ID) }) {
+			if slices.Contains(ids, a.ID) {
+				rows = append(rows, dbsqlc.ListAthleteTeamsInRow{ID: a.ID, TeamID: a.TeamID})
+			}
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) GetAthleteManualPR(ctx context.Context, id int32) (racetime.NullDuration, error) {
+	var pr racetime.NullDuration
+	err := q.with(ctx, func(t *tables) error {
+		a, ok := t.athletes[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		pr = a.ManualPrMs
+		return nil
+	})
+	return pr, err
+}
+
+func (q memQueries) SetAthletePR(ctx context.Context, arg dbsqlc.SetAthletePRParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if a, ok := t.athletes[arg.ID]; ok {
+			a.PersonalRecordMs = arg.PersonalRecordMs
+			t.athletes[arg.ID] = a
+		}
+		return nil
+	})
+}
+
+func (q memQueries) ListFastestAthletes(ctx context.Context) ([]dbsqlc.ListFastestAthletesRow, error) {
+	var rows []dbsqlc.ListFastestAthletesRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, row := range fastestFirst(sortedValues(t.athletes, func(a, b dbsqlc.Athlete) int { return cmp.Compare(a.ID, b.ID) })) {
+			rows = append(rows, dbsqlc.ListFastestAthletesRow(row))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListFastestAthletesInSeason(ctx context.Context, seasonID int32) ([]dbsqlc.ListFastestAthletesInSeasonRow, error) {
+	var rows []dbsqlc.ListFastestAthletesInSeasonRow
+	err := q.with(ctx, func(t *tables) error {
+		var athletes []dbsqlc.Athlete
+		for key := range t.roster {
+			if key.seasonID == seasonID {
+				athletes = append(athletes, t.athletes[key.athleteID])
+			}
+		}
+		for _, row := range fastestFirst(athletes) {
+			rows = append(rows, dbsqlc.ListFastestAthletesInSeasonRow(row))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListAthleteHistory(ctx context.Context, arg dbsqlc.ListAthleteHistoryParams) ([]dbsqlc.ListAthleteHistoryRow, error) {
+	var rows []dbsqlc.ListAthleteHistoryRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range t.athleteResults(arg.AthleteID) {
+			race := t.races[r.RaceID]
+			meet := t.meets[race.MeetID]
+			if !between(meet.Date, arg.StartDate, arg.EndDate) {
+				continue
+			}
+			rows = append(rows, dbsqlc.ListAthleteHistoryRow{
+				ID:         r.ID,
+				MeetID:     meet.ID,
+				MeetName:   meet.Name,
+				Date:       meet.Date,
+				RaceID:     race.ID,
+				Gender:     race.Gender,
+				Division:   race.Division,
+				DistanceM:  race.DistanceM,
+				CourseID:   t.raceCourse(race),
+				TimeMs:     r.TimeMs,
+				Place:      r.Place,
+				IsPr:       r.IsPr,
+				IsCoursePr: r.IsCoursePr,
+			})
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) CountAthletes(ctx context.Context) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		n = int64(len(t.athletes))
+		return nil
+	})
+	return n, err
+}
+
+func (q memQueries) ListAthleteProgression(ctx context.Context, athleteID sql.NullInt32) ([]dbsqlc.ListAthleteProgressionRow, error) {
+	var rows []dbsqlc.ListAthleteProgressionRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range t.athleteResults(athleteID) {
+			race := t.races[r.RaceID]
+			meet := t.meets[race.MeetID]
+			rows = append(rows, dbsqlc.ListAthleteProgressionRow{
+				ID:         r.ID,
+				MeetID:     meet.ID,
+				MeetName:   meet.Name,
+				Date:       meet.Date,
+				RaceID:     race.ID,
+				Gender:     race.Gender,
+				Division:   race.Division,
+				DistanceM:  race.DistanceM,
+				CourseID:   t.raceCourse(race),
+				TimeMs:     r.TimeMs,
+				Place:      r.Place,
+				IsPr:       r.IsPr,
+				IsCoursePr: r.IsCoursePr,
+			})
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListSeasons(ctx context.Context) ([]dbsqlc.Season, error) {
+	var seasons []dbsqlc.Season
+	err := q.with(ctx, func(t *tables) error {
+		seasons = sortedValues(t.seasons, func(a, b dbsqlc.Season) int { return cmp.Compare(b.Year, a.Year) })
+		return nil
+	})
+	return seasons, err
+}
+
+func (q memQueries) GetSeasonByYear(ctx context.Context, year int32) (dbsqlc.Season, error) {
+	var season dbsqlc.Season
+	err := q.with(ctx, func(t *tables) error {
+		for _, s := range t.seasons {
+			if s.Year == year {
+				season = s
+				return nil
+			}
+		}
+		return sql.ErrNoRows
+	})
+	return season, err
+}
+
+func (q memQueries) CreateSeason(ctx context.Context, arg dbsqlc.CreateSeasonParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		for _, s := range t.seasons {
+			if s.Year == arg.Year {
+				return ErrDuplicate
+			}
+		}
+		id := t.nextID("seasons")
+		t.seasons[id] = dbsqlc.Season{ID: id, Year: arg.Year, Name: arg.Name, StartDate: arg.StartDate, EndDate: arg.EndDate}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateSeason(ctx context.Context, arg dbsqlc.UpdateSeasonParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if s, ok := t.seasons[arg.ID]; ok {
+			s.Name = arg.Name
+			t.seasons[arg.ID] = s
+		}
+		return nil
+	})
+}
+
+func (q memQueries) ListRoster(ctx context.Context, seasonID int32) ([]dbsqlc.ListRosterRow, error) {
+	var rows []dbsqlc.ListRosterRow
+	err := q.with(ctx, func(t *tables) error {
+		for key, grade := range t.roster {
+			if key.seasonID == seasonID {
+				rows = append(rows, dbsqlc.ListRosterRow{
+					AthleteID:      key.athleteID,
+					Grade:          grade,
+					GraduationYear: t.athletes[key.athleteID].GraduationYear,
+				})
+			}
+		}
+		slices.SortFunc(rows, func(a, b dbsqlc.ListRosterRow) int { return cmp.Compare(a.AthleteID, b.AthleteID) })
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) UpsertRosterEntry(ctx context.Context, arg dbsqlc.UpsertRosterEntryParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if _, ok := t.seasons[arg.SeasonID]; !ok {
+			return ErrNoReferencedRow
+		}
+		if _, ok := t.athletes[arg.AthleteID]; !ok {
+			return ErrNoReferencedRow
+		}
+		t.roster[rosterKey{arg.SeasonID, arg.AthleteID}] = arg.Grade
+		return nil
+	})
+}
+
+func (q memQueries) UpdateRosterGrade(ctx context.Context, arg dbsqlc.UpdateRosterGradeParams) error {
+	return q.with(ctx, func(t *tables) error {
+		key := rosterKey{arg.SeasonID, arg.AthleteID}
+		if _, ok := t.roster[key]; ok {
+			t.roster[key] = arg.Grade
+		}
+		return nil
+	})
+}
+
+func (q memQueries) DeleteRosterEntry(ctx context.Context, arg dbsqlc.DeleteRosterEntryParams) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		key := rosterKey{arg.SeasonID, arg.AthleteID}
+		if _, ok := t.roster[key]; ok {
+			n = 1
+			delete(t.roster, key)
+		}
+		return nil
+	})
+	return n, err
+}
@@ -0,0 +1,455 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+)
+
+func courseRow(c dbsqlc.Course) dbsqlc.GetCourseByIDRow {
+	return dbsqlc.GetCourseByIDRow{
+		ID:             c.ID,
+		Name:           c.Name,
+		Address:        c.Address.String,
+		DistanceM:      c.DistanceM,
+		Surface:        c.Surface.String,
+		ElevationGainM: c.ElevationGainM,
+		Notes:          c.Notes.String,
+	}
+}
+
+func meetRow(m dbsqlc.Meet) dbsqlc.GetMeetByIDRow {
+	return dbsqlc.GetMeetByIDRow{
+		ID:          m.ID,
+		Name:        m.Name,
+		Date:        m.Date,
+		Location:    m.Location.String,
+		Description: m.Description.String,
+		Cancelled:   m.Cancelled,
+		CourseID:    m.CourseID,
+	}
+}
+
+// courseNameTaken reports whether a course other than id already has name.
+func (t *tables) courseNameTaken(name string, id int32) bool {
+	for _, c := range t.courses {
+		if c.ID != id && sameText(c.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tables) courseReferences(id sql.NullInt32) int32 {
+	var refs int32
+	for _, m := range t.meets {
+		if id.Valid && m.CourseID == id {
+			refs++
+		}
+	}
+	for _, r := range t.races {
+		if id.Valid && r.CourseID == id {
+			refs++
+		}
+	}
+	return refs
+}
+
+// courseExists checks a nullable course_id foreign key.
+func (t *tables) courseExists(id sql.NullInt32) bool {
+	_, ok := t.courses[id.Int32]
+	return !id.Valid || ok
+}
+
+// raceTaken reports whether a race other than id already runs at meetID
+// for gender and division.
+func (t *tables) raceTaken(meetID int32, gender dbsqlc.RacesGender, division dbsqlc.RacesDivision, id int32) bool {
+	for _, r := range t.races {
+		if r.ID != id && r.MeetID == meetID && r.Gender == gender && r.Division == division {
+			return true
+		}
+	}
+	return false
+}
+
+// startTime stores a start time as MySQL's TIME column hands it back,
+// HH:MM:SS.
+func startTime(s sql.NullString) sql.NullString {
+	if s.Valid && len(s.String) == len("15:04") {
+		s.String += ":00"
+	}
+	return s
+}
+
+func (t *tables) countResults(match func(r dbsqlc.Result) bool) int64 {
+	var n int64
+	for _, r := range t.results {
+		if match(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func (q memQueries) ListCourses(ctx context.Context) ([]dbsqlc.ListCoursesRow, error) {
+	var rows []dbsqlc.ListCoursesRow
+	err := q.with(ctx, func(t *tables) error {
+		courses := sortedValues(t.courses, func(a, b dbsqlc.Course) int {
+			return cmp.Or(compareText(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		for _, c := range courses {
+			rows = append(rows, dbsqlc.ListCoursesRow(courseRow(c)))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) GetCourseByID(ctx context.Context, id int32) (dbsqlc.GetCourseByIDRow, error) {
+	var row dbsqlc.GetCourseByIDRow
+	err := q.with(ctx, func(t *tables) error {
+		c, ok := t.courses[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		row = courseRow(c)
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) CreateCourse(ctx context.Context, arg dbsqlc.CreateCourseParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		if t.courseNameTaken(arg.Name, 0) {
+			return ErrDuplicate
+		}
+		id := t.nextID("courses")
+		t.courses[id] = dbsqlc.Course{
+			ID:             id,
+			Name:           arg.Name,
+			Address:        arg.Address,
+			DistanceM:      arg.DistanceM,
+			Surface:        arg.Surface,
+			ElevationGainM: arg.ElevationGainM,
+			Notes:          arg.Notes,
+		}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateCourse(ctx context.Context, arg dbsqlc.UpdateCourseParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if _, ok := t.courses[arg.ID]; !ok {
+			return nil
+		}
+		if t.courseNameTaken(arg.Name, arg.ID) {
+			return ErrDuplicate
+		}
+		t.courses[arg.ID] = dbsqlc.Course{
+			ID:             arg.ID,
+			Name:           arg.Name,
+			Address:        arg.Address,
+			DistanceM:      arg.DistanceM,
+			Surface:        arg.Surface,
+			ElevationGainM: arg.ElevationGainM,
+			Notes:          arg.Notes,
+		}
+		return nil
+	})
+}
+
+func (q memQueries) DeleteCourse(ctx context.Context, id int32) error {
+	return q.with(ctx, func(t *tables) error {
+		if t.courseReferences(sql.NullInt32{Int32: id, Valid: true}) > 0 {
+			return ErrReferenced
+		}
+		delete(t.courses, id)
+		for pid, p := range t.records {
+			if p.CourseID.Valid && p.CourseID.Int32 == id {
+				delete(t.records, pid)
+			}
+		}
+		return nil
+	})
+}
+
+func (q memQueries) CountCourseReferences(ctx context.Context, arg dbsqlc.CountCourseReferencesParams) (int32, error) {
+	var refs int32
+	err := q.with(ctx, func(t *tables) error {
+		refs = t.courseReferences(arg.ID)
+		return nil
+	})
+	return refs, err
+}
+
+func (q memQueries) ListCourseResults(ctx context.Context, arg dbsqlc.ListCourseResultsParams) ([]dbsqlc.ListCourseResultsRow, error) {
+	var rows []dbsqlc.ListCourseResultsRow
+	err := q.with(ctx, func(t *tables) error {
+		if !arg.CourseID.Valid {
+			return nil
+		}
+		results := sortedValues(t.results, func(a, b dbsqlc.Result) int { return cmp.Compare(a.ID, b.ID) })
+		for _, r := range results {
+			race := t.races[r.RaceID]
+			if t.raceCourse(race) != arg.CourseID.Int32 {
+				continue
+			}
+			meet := t.meets[race.MeetID]
+			rows = append(rows, dbsqlc.ListCourseResultsRow{
+				AthleteID:  r.AthleteID,
+				RunnerName: t.runnerName(r),
+				TeamName:   t.teams[r.TeamID].Name,
+				MeetName:   meet.Name,
+				Date:       meet.Date,
+				RaceID:     race.ID,
+				Gender:     race.Gender,
+				DistanceM:  race.DistanceM,
+				TimeMs:     r.TimeMs,
+				Place:      r.Place,
+			})
+		}
+		slices.SortStableFunc(rows, func(a, b dbsqlc.ListCourseResultsRow) int {
+			return cmp.Or(cmp.Compare(a.Gender, b.Gender), cmp.Compare(a.DistanceM, b.DistanceM), cmp.Compare(a.TimeMs, b.TimeMs))
+		})
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListMeets(ctx context.Context, arg dbsqlc.ListMeetsParams) ([]dbsqlc.ListMeetsRow, error) {
+	var rows []dbsqlc.ListMeetsRow
+	err := q.with(ctx, func(t *tables) error {
+		meets := sortedValues(t.meets, func(a, b dbsqlc.Meet) int {
+			return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+		})
+		for _, m := range meets {
+			if between(m.Date, arg.StartDate, arg.EndDate) {
+				rows = append(rows, dbsqlc.ListMeetsRow(meetRow(m)))
+			}
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) GetMeetByID(ctx context.Context, id int32) (dbsqlc.GetMeetByIDRow, error) {
+	var row dbsqlc.GetMeetByIDRow
+	err := q.with(ctx, func(t *tables) error {
+		m, ok := t.meets[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		row = meetRow(m)
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) CreateMeet(ctx context.Context, arg dbsqlc.CreateMeetParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		if !t.courseExists(arg.CourseID) {
+			return ErrNoReferencedRow
+		}
+		id := t.nextID("meets")
+		t.meets[id] = dbsqlc.Meet{
+			ID:          id,
+			Name:        arg.Name,
+			Date:        arg.Date,
+			Location:    arg.Location,
+			Description: arg.Description,
+			Cancelled:   arg.Cancelled,
+			CourseID:    arg.CourseID,
+		}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateMeet(ctx context.Context, arg dbsqlc.UpdateMeetParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if _, ok := t.meets[arg.ID]; !ok {
+			return nil
+		}
+		if !t.courseExists(arg.CourseID) {
+			return ErrNoReferencedRow
+		}
+		t.meets[arg.ID] = dbsqlc.Meet{
+			ID:          arg.ID,
+			Name:        arg.Name,
+			Date:        arg.Date,
+			Location:    arg.Location,
+			Description: arg.Description,
+			Cancelled:   arg.Cancelled,
+			CourseID:    arg.CourseID,
+		}
+		return nil
+	})
+}
+
+// DeleteMeet deletes the meet's races along with it, unless one has
+// results.
+func (q memQueries) DeleteMeet(ctx context.Context, id int32) error {
+	return q.with(ctx, func(t *tables) error {
+		if t.countResults(func(r dbsqlc.Result) bool { return t.races[r.RaceID].MeetID == id }) > 0 {
+			return ErrReferenced
+		}
+		delete(t.meets, id)
+		for raceID, race := range t.races {
+			if race.MeetID == id {
+				delete(t.races, raceID)
+			}
+		}
+		return nil
+	})
+}
+
+func (q memQueries) CountResultsByMeet(ctx context.Context, meetID int32) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		n = t.countResults(func(r dbsqlc.Result) bool { return t.races[r.RaceID].MeetID == meetID })
+		return nil
+	})
+	return n, err
+}
+
+func (q memQueries) ListMeetAthleteIDs(ctx context.Context, meetID int32) ([]sql.NullInt32, error) {
+	var ids []sql.NullInt32
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range sortedValues(t.results, func(a, b dbsqlc.Result) int { return cmp.Compare(a.ID, b.ID) }) {
+			if r.AthleteID.Valid && t.races[r.RaceID].MeetID == meetID && !slices.Contains(ids, r.AthleteID) {
+				ids = append(ids, r.AthleteID)
+			}
+		}
+		return nil
+	})
+	return ids, err
+}
+
+func (q memQueries) ListRacesByMeet(ctx context.Context, meetID int32) ([]dbsqlc.Race, error) {
+	var races []dbsqlc.Race
+	err := q.with(ctx, func(t *tables) error {
+		all := sortedValues(t.races, func(a, b dbsqlc.Race) int {
+			return cmp.Or(compareNullLast(a.StartTime, b.StartTime), cmp.Compare(a.ID, b.ID))
+		})
+		for _, race := range all {
+			if race.MeetID == meetID {
+				races = append(races, race)
+			}
+		}
+		return nil
+	})
+	return races, err
+}
+
+func (q memQueries) GetRaceByID(ctx context.Context, id int32) (dbsqlc.Race, error) {
+	var race dbsqlc.Race
+	err := q.with(ctx, func(t *tables) error {
+		var ok bool
+		if race, ok = t.races[id]; !ok {
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+	return race, err
+}
+
+func (q memQueries) CreateRace(ctx context.Context, arg dbsqlc.CreateRaceParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		if _, ok := t.meets[arg.MeetID]; !ok || !t.courseExists(arg.CourseID) {
+			return ErrNoReferencedRow
+		}
+		if t.raceTaken(arg.MeetID, arg.Gender, arg.Division, 0) {
+			return ErrDuplicate
+		}
+		id := t.nextID("races")
+		t.races[id] = dbsqlc.Race{
+			ID:        id,
+			MeetID:    arg.MeetID,
+			Gender:    arg.Gender,
+			Division:  arg.Division,
+			DistanceM: arg.DistanceM,
+			StartTime: startTime(arg.StartTime),
+			CourseID:  arg.CourseID,
+		}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateRace(ctx context.Context, arg dbsqlc.UpdateRaceParams) error {
+	return q.with(ctx, func(t *tables) error {
+		race, ok := t.races[arg.ID]
+		if !ok {
+			return nil
+		}
+		if !t.courseExists(arg.CourseID) {
+			return ErrNoReferencedRow
+		}
+		if t.raceTaken(race.MeetID, arg.Gender, arg.Division, arg.ID) {
+			return ErrDuplicate
+		}
+		race.Gender, race.Division, race.DistanceM = arg.Gender, arg.Division, arg.DistanceM
+		race.StartTime, race.CourseID = startTime(arg.StartTime), arg.CourseID
+		t.races[arg.ID] = race
+		return nil
+	})
+}
+
+func (q memQueries) DeleteRace(ctx context.Context, id int32) error {
+	return q.with(ctx, func(t *tables) error {
+		if t.countResults(func(r dbsqlc.Result) bool { return r.RaceID == id }) > 0 {
+			return ErrReferenced
+		}
+		delete(t.races, id)
+		return nil
+	})
+}
+
+func (q memQueries) CountResultsByRace(ctx context.Context, raceID int32) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		n = t.countResults(func(r dbsqlc.Result) bool { return r.RaceID == raceID })
+		return nil
+	})
+	return n, err
+}
+
+func (q memQueries) ListRaceAthleteIDs(ctx context.Context, raceID int32) ([]sql.NullInt32, error) {
+	var ids []sql.NullInt32
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range sortedValues(t.results, func(a, b dbsqlc.Result) int { return cmp.Compare(a.ID, b.ID) }) {
+			if r.RaceID == raceID && r.AthleteID.Valid {
+				ids = append(ids, r.AthleteID)
+			}
+		}
+		return nil
+	})
+	return ids, err
+}
+
+func (q memQueries) ListRaceFinishers(ctx context.Context, raceID int32) ([]dbsqlc.ListRaceFinishersRow, error) {
+	var rows []dbsqlc.ListRaceFinishersRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range t.resultsWhere(func(r dbsqlc.Result) bool { return r.RaceID == raceID }) {
+			rows = append(rows, dbsqlc.ListRaceFinishersRow{
+				AthleteID:  r.AthleteID,
+				RunnerName: t.runnerName(r),
+				TeamName:   t.teams[r.TeamID].Name,
+				Place:      r.Place,
+				TimeMs:     r.TimeMs,
+			})
+		}
+		return nil
+	})
+	return rows, err
+}
@@ -0,0 +1,439 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+)
+
+// runnerName is the name a result is listed under: the athlete's name for
+// our runners, the entered name for opponents.
+func (t *tables) runnerName(r dbsqlc.Result) string {
+	if a, ok := t.athletes[r.AthleteID.Int32]; ok && r.AthleteID.Valid {
+		return a.Name
+	}
+	return r.RunnerName.String
+}
+
+func (t *tables) resultRow(r dbsqlc.Result) dbsqlc.GetResultByIDRow {
+	return dbsqlc.GetResultByIDRow{
+		ID:         r.ID,
+		AthleteID:  r.AthleteID,
+		TeamID:     r.TeamID,
+		RunnerName: t.runnerName(r),
+		RaceID:     r.RaceID,
+		MeetID:     t.races[r.RaceID].MeetID,
+		TimeMs:     r.TimeMs,
+		Place:      r.Place,
+		IsPr:       r.IsPr,
+		IsCoursePr: r.IsCoursePr,
+	}
+}
+
+// resultsWhere is the results that match, ordered by race and then place.
+func (t *tables) resultsWhere(match func(r dbsqlc.Result) bool) []dbsqlc.Result {
+	var results []dbsqlc.Result
+	for _, r := range t.results {
+		if match(r) {
+			results = append(results, r)
+		}
+	}
+	slices.SortFunc(results, func(a, b dbsqlc.Result) int {
+		return cmp.Or(cmp.Compare(a.RaceID, b.RaceID), cmp.Compare(a.Place, b.Place))
+	})
+	return results
+}
+
+// checkResult checks a result's foreign keys and unique keys, ignoring the
+// result being updated.
+func (t *tables) checkResult(r dbsqlc.Result) error {
+	if _, ok := t.athletes[r.AthleteID.Int32]; r.AthleteID.Valid && !ok {
+		return ErrNoReferencedRow
+	}
+	if _, ok := t.teams[r.TeamID]; !ok {
+		return ErrNoReferencedRow
+	}
+	if _, ok := t.races[r.RaceID]; !ok {
+		return ErrNoReferencedRow
+	}
+	for _, other := range t.results {
+		if other.ID == r.ID || other.RaceID != r.RaceID {
+			continue
+		}
+		if other.Place == r.Place || (r.AthleteID.Valid && other.AthleteID == r.AthleteID) {
+			return ErrDuplicate
+		}
+	}
+	return nil
+}
+
+// deleteResult deletes a result with its splits and the records it set.
+func (t *tables) deleteResult(id int32) {
+	delete(t.results, id)
+	delete(t.splits, id)
+	for pid, p := range t.records {
+		if p.ResultID == id {
+			delete(t.records, pid)
+		}
+	}
+}
+
+// splitsWhere is the splits of the results that match, by result and then
+// split.
+func (t *tables) splitsWhere(match func(r dbsqlc.Result) bool) []dbsqlc.ResultSplit {
+	var splits []dbsqlc.ResultSplit
+	for _, r := range sortedValues(t.results, func(a, b dbsqlc.Result) int { return cmp.Compare(a.ID, b.ID) }) {
+		if match(r) {
+			splits = append(splits, t.splits[r.ID]...)
+		}
+	}
+	return splits
+}
+
+func (q memQueries) GetResultByID(ctx context.Context, id int32) (dbsqlc.GetResultByIDRow, error) {
+	var row dbsqlc.GetResultByIDRow
+	err := q.with(ctx, func(t *tables) error {
+		r, ok := t.results[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		row = t.resultRow(r)
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) CreateResult(ctx context.Context, arg dbsqlc.CreateResultParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		r := dbsqlc.Result{
+			AthleteID:  arg.AthleteID,
+			TeamID:     arg.TeamID,
+			RunnerName: arg.RunnerName,
+			RaceID:     arg.RaceID,
+			TimeMs:     arg.TimeMs,
+			Place:      arg.Place,
+			CreatedAt:  time.Now(),
+		}
+		if err := t.checkResult(r); err != nil {
+			return err
+		}
+		r.ID = t.nextID("results")
+		t.results[r.ID] = r
+		result = insertResult{r.ID}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateResult(ctx context.Context, arg dbsqlc.UpdateResultParams) error {
+	return q.with(ctx, func(t *tables) error {
+		r, ok := t.results[arg.ID]
+		if !ok {
+			return nil
+		}
+		r.AthleteID, r.TeamID, r.RunnerName = arg.AthleteID, arg.TeamID, arg.RunnerName
+		r.RaceID, r.TimeMs, r.Place = arg.RaceID, arg.TimeMs, arg.Place
+		if err := t.checkResult(r); err != nil {
+			return err
+		}
+		t.results[arg.ID] = r
+		return nil
+	})
+}
+
+func (q memQueries) DeleteResult(ctx context.Context, id int32) error {
+	return q.with(ctx, func(t *tables) error {
+		t.deleteResult(id)
+		return nil
+	})
+}
+
+func (q memQueries) DeleteResultsByRace(ctx context.Context, raceID int32) error {
+	return q.with(ctx, func(t *tables) error {
+		for id, r := range t.results {
+			if r.RaceID == raceID {
+				t.deleteResult(id)
+			}
+		}
+		return nil
+	})
+}
+
+func (q memQueries) ListResultsBetween(ctx context.Context, arg dbsqlc.ListResultsBetweenParams) ([]dbsqlc.ListResultsBetweenRow, error) {
+	var rows []dbsqlc.ListResultsBetweenRow
+	err := q.with(ctx, func(t *tables) error {
+		results := t.resultsWhere(func(r dbsqlc.Result) bool {
+			return between(t.meets[t.races[r.RaceID].MeetID].Date, arg.StartDate, arg.EndDate)
+		})
+		for _, r := range results {
+			rows = append(rows, dbsqlc.ListResultsBetweenRow(t.resultRow(r)))
+		}
+		slices.SortStableFunc(rows, func(a, b dbsqlc.ListResultsBetweenRow) int {
+			return t.meets[a.MeetID].Date.Compare(t.meets[b.MeetID].Date)
+		})
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListResultsByMeet(ctx context.Context, meetID int32) ([]dbsqlc.ListResultsByMeetRow, error) {
+	var rows []dbsqlc.ListResultsByMeetRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range t.resultsWhere(func(r dbsqlc.Result) bool { return t.races[r.RaceID].MeetID == meetID }) {
+			rows = append(rows, dbsqlc.ListResultsByMeetRow(t.resultRow(r)))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListResultsByMeetAndTeam(ctx context.Context, arg dbsqlc.ListResultsByMeetAndTeamParams) ([]dbsqlc.ListResultsByMeetAndTeamRow, error) {
+	var rows []dbsqlc.ListResultsByMeetAndTeamRow
+	err := q.with(ctx, func(t *tables) error {
+		results := t.resultsWhere(func(r dbsqlc.Result) bool {
+			return t.races[r.RaceID].MeetID == arg.MeetID && r.TeamID == arg.TeamID
+		})
+		for _, r := range results {
+			rows = append(rows, dbsqlc.ListResultsByMeetAndTeamRow(t.resultRow(r)))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListResultsByRace(ctx context.Context, raceID int32) ([]dbsqlc.ListResultsByRaceRow, error) {
+	var rows []dbsqlc.ListResultsByRaceRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, r := range t.resultsWhere(func(r dbsqlc.Result) bool { return r.RaceID == raceID }) {
+			rows = append(rows, dbsqlc.ListResultsByRaceRow(t.resultRow(r)))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListResultsByRaceAndTeam(ctx context.Context, arg dbsqlc.ListResultsByRaceAndTeamParams) ([]dbsqlc.ListResultsByRaceAndTeamRow, error) {
+	var rows []dbsqlc.ListResultsByRaceAndTeamRow
+	err := q.with(ctx, func(t *tables) error {
+		results := t.resultsWhere(func(r dbsqlc.Result) bool { return r.RaceID == arg.RaceID && r.TeamID == arg.TeamID })
+		for _, r := range results {
+			rows = append(rows, dbsqlc.ListResultsByRaceAndTeamRow(t.resultRow(r)))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListFastestTimes(ctx context.Context, arg dbsqlc.ListFastestTimesParams) ([]dbsqlc.ListFastestTimesRow, error) {
+	var rows []dbsqlc.ListFastestTimesRow
+	err := q.with(ctx, func(t *tables) error {
+		results := t.resultsWhere(func(r dbsqlc.Result) bool {
+			race := t.races[r.RaceID]
+			_, ours := t.athletes[r.AthleteID.Int32]
+			return r.AthleteID.Valid && ours && race.DistanceM == arg.DistanceM &&
+				between(t.meets[race.MeetID].Date, arg.StartDate, arg.EndDate)
+		})
+		slices.SortStableFunc(results, func(a, b dbsqlc.Result) int { return cmp.Compare(a.TimeMs, b.TimeMs) })
+		for _, r := range results[:min(len(results), 10)] {
+			rows = append(rows, dbsqlc.ListFastestTimesRow{
+				AthleteName: t.athletes[r.AthleteID.Int32].Name,
+				MeetName:    t.meets[t.races[r.RaceID].MeetID].Name,
+				TimeMs:      r.TimeMs,
+				Place:       r.Place,
+			})
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) ListLatestMeetResults(ctx context.Context, arg dbsqlc.ListLatestMeetResultsParams) ([]dbsqlc.ListLatestMeetResultsRow, error) {
+	var rows []dbsqlc.ListLatestMeetResultsRow
+	err := q.with(ctx, func(t *tables) error {
+		var latest dbsqlc.Meet
+		for _, m := range t.meets {
+			if between(m.Date, arg.StartDate, arg.EndDate) && m.Date.After(latest.Date) {
+				latest = m
+			}
+		}
+		if latest.ID == 0 {
+			return nil
+		}
+		results := t.resultsWhere(func(r dbsqlc.Result) bool {
+			_, ours := t.athletes[r.AthleteID.Int32]
+			return r.AthleteID.Valid && ours && t.meets[t.races[r.RaceID].MeetID].Date.Equal(latest.Date)
+		})
+		slices.SortStableFunc(results, func(a, b dbsqlc.Result) int { return cmp.Compare(a.Place, b.Place) })
+		for _, r := range results {
+			rows = append(rows, dbsqlc.ListLatestMeetResultsRow{
+				AthleteName: t.athletes[r.AthleteID.Int32].Name,
+				MeetName:    t.meets[t.races[r.RaceID].MeetID].Name,
+				TimeMs:      r.TimeMs,
+				Place:       r.Place,
+			})
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) CreateResultSplit(ctx context.Context, arg dbsqlc.CreateResultSplitParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if _, ok := t.results[arg.ResultID]; !ok {
+			return ErrNoReferencedRow
+		}
+		splits := t.splits[arg.ResultID]
+		if slices.ContainsFunc(splits, func(s dbsqlc.ResultSplit) bool { return s.SplitIndex == arg.SplitIndex }) {
+			return ErrDuplicate
+		}
+		splits = append(slices.Clip(splits), dbsqlc.ResultSplit(arg))
+		slices.SortFunc(splits, func(a, b dbsqlc.ResultSplit) int { return cmp.Compare(a.SplitIndex, b.SplitIndex) })
+		t.splits[arg.ResultID] = splits
+		return nil
+	})
+}
+
+func (q memQueries) DeleteResultSplits(ctx context.Context, resultID int32) error {
+	return q.with(ctx, func(t *tables) error {
+		delete(t.splits, resultID)
+		return nil
+	})
+}
+
+func (q memQueries) ListSplitsByResult(ctx context.Context, resultID int32) ([]dbsqlc.ResultSplit, error) {
+	var splits []dbsqlc.ResultSplit
+	err := q.with(ctx, func(t *tables) error {
+		splits = slices.Clone(t.splits[resultID])
+		return nil
+	})
+	return splits, err
+}
+
+func (q memQueries) ListSplitsByMeet(ctx context.Context, meetID int32) ([]dbsqlc.ResultSplit, error) {
+	var splits []dbsqlc.ResultSplit
+	err := q.with(ctx, func(t *tables) error {
+		splits = t.splitsWhere(func(r dbsqlc.Result) bool { return t.races[r.RaceID].MeetID == meetID })
+		return nil
+	})
+	return splits, err
+}
+
+func (q memQueries) ListSplitsByRace(ctx context.Context, raceID int32) ([]dbsqlc.ResultSplit, error) {
+	var splits []dbsqlc.ResultSplit
+	err := q.with(ctx, func(t *tables) error {
+		splits = t.splitsWhere(func(r dbsqlc.Result) bool { return r.RaceID == raceID })
+		return nil
+	})
+	return splits, err
+}
+
+func (q memQueries) ListSplitsByAthlete(ctx context.Context, athleteID sql.NullInt32) ([]dbsqlc.ResultSplit, error) {
+	var splits []dbsqlc.ResultSplit
+	err := q.with(ctx, func(t *tables) error {
+		splits = t.splitsWhere(func(r dbsqlc.Result) bool { return athleteID.Valid && r.AthleteID == athleteID })
+		return nil
+	})
+	return splits, err
+}
+
+func (q memQueries) CountResultsCreatedSince(ctx context.Context, createdAt time.Time) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		n = t.countResults(func(r dbsqlc.Result) bool { return !r.CreatedAt.Before(createdAt) })
+		return nil
+	})
+	return n, err
+}
+
+func (q memQueries) DeletePersonalRecords(ctx context.Context, athleteID int32) error {
+	return q.with(ctx, func(t *tables) error {
+		for id, p := range t.records {
+			if p.AthleteID == athleteID {
+				delete(t.records, id)
+			}
+		}
+		return nil
+	})
+}
+
+func (q memQueries) CreatePersonalRecord(ctx context.Context, arg dbsqlc.CreatePersonalRecordParams) error {
+	return q.with(ctx, func(t *tables) error {
+		_, athleteOK := t.athletes[arg.AthleteID]
+		_, resultOK := t.results[arg.ResultID]
+		if !athleteOK || !resultOK || !t.courseExists(arg.CourseID) {
+			return ErrNoReferencedRow
+		}
+		id := t.nextID("personal_records")
+		t.records[id] = dbsqlc.PersonalRecord{
+			ID:        id,
+			AthleteID: arg.AthleteID,
+			DistanceM: arg.DistanceM,
+			CourseID:  arg.CourseID,
+			ResultID:  arg.ResultID,
+			TimeMs:    arg.TimeMs,
+		}
+		return nil
+	})
+}
+
+func (q memQueries) ClearResultPRFlags(ctx context.Context, athleteID sql.NullInt32) error {
+	return q.with(ctx, func(t *tables) error {
+		for id, r := range t.results {
+			if athleteID.Valid && r.AthleteID == athleteID {
+				r.IsPr, r.IsCoursePr = false, false
+				t.results[id] = r
+			}
+		}
+		return nil
+	})
+}
+
+func (q memQueries) SetResultPRFlags(ctx context.Context, arg dbsqlc.SetResultPRFlagsParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if r, ok := t.results[arg.ID]; ok {
+			r.IsPr, r.IsCoursePr = arg.IsPr, arg.IsCoursePr
+			t.results[arg.ID] = r
+		}
+		return nil
+	})
+}
+
+func (q memQueries) ListPersonalRecords(ctx context.Context, athleteID int32) ([]dbsqlc.ListPersonalRecordsRow, error) {
+	var rows []dbsqlc.ListPersonalRecordsRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, p := range sortedValues(t.records, func(a, b dbsqlc.PersonalRecord) int { return cmp.Compare(a.ID, b.ID) }) {
+			if p.AthleteID != athleteID {
+				continue
+			}
+			meet := t.meets[t.races[t.results[p.ResultID].RaceID].MeetID]
+			var courseName sql.NullString
+			if c, ok := t.courses[p.CourseID.Int32]; ok && p.CourseID.Valid {
+				courseName = sql.NullString{String: c.Name, Valid: true}
+			}
+			rows = append(rows, dbsqlc.ListPersonalRecordsRow{
+				DistanceM:  p.DistanceM,
+				CourseID:   p.CourseID,
+				CourseName: courseName,
+				TimeMs:     p.TimeMs,
+				ResultID:   p.ResultID,
+				MeetID:     meet.ID,
+				MeetName:   meet.Name,
+				Date:       meet.Date,
+			})
+		}
+		slices.SortStableFunc(rows, func(a, b dbsqlc.ListPersonalRecordsRow) int {
+			return cmp.Or(
+				cmp.Compare(a.DistanceM, b.DistanceM),
+				compareNullFirst(sql.NullString{Valid: a.CourseID.Valid}, sql.NullString{Valid: b.CourseID.Valid}),
+				compareText(a.CourseName.String, b.CourseName.String),
+			)
+		})
+		return nil
+	})
+	return rows, err
+}
@@ -0,0 +1,148 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+)
+
+func teamRow(team dbsqlc.Team) dbsqlc.GetTeamByIDRow {
+	return dbsqlc.GetTeamByIDRow{ID: team.ID, Name: team.Name, ShortName: team.ShortName.String, IsHome: team.IsHome}
+}
+
+// teamNameTaken reports whether a team other than id already has name.
+func (t *tables) teamNameTaken(name string, id int32) bool {
+	for _, team := range t.teams {
+		if team.ID != id && sameText(team.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tables) teamReferences(id int32) int32 {
+	var refs int32
+	for _, a := range t.athletes {
+		if a.TeamID == id {
+			refs++
+		}
+	}
+	for _, r := range t.results {
+		if r.TeamID == id {
+			refs++
+		}
+	}
+	return refs
+}
+
+func (q memQueries) ListTeams(ctx context.Context) ([]dbsqlc.ListTeamsRow, error) {
+	var rows []dbsqlc.ListTeamsRow
+	err := q.with(ctx, func(t *tables) error {
+		teams := sortedValues(t.teams, func(a, b dbsqlc.Team) int {
+			if a.IsHome != b.IsHome {
+				if a.IsHome {
+					return -1
+				}
+				return 1
+			}
+			return compareText(a.Name, b.Name)
+		})
+		for _, team := range teams {
+			rows = append(rows, dbsqlc.ListTeamsRow(teamRow(team)))
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) GetTeamByID(ctx context.Context, id int32) (dbsqlc.GetTeamByIDRow, error) {
+	var row dbsqlc.GetTeamByIDRow
+	err := q.with(ctx, func(t *tables) error {
+		team, ok := t.teams[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		row = teamRow(team)
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) GetHomeTeam(ctx context.Context) (dbsqlc.GetHomeTeamRow, error) {
+	var row dbsqlc.GetHomeTeamRow
+	err := q.with(ctx, func(t *tables) error {
+		teams := sortedValues(t.teams, func(a, b dbsqlc.Team) int { return cmp.Compare(a.ID, b.ID) })
+		i := slices.IndexFunc(teams, func(team dbsqlc.Team) bool { return team.IsHome })
+		if i < 0 {
+			return sql.ErrNoRows
+		}
+		row = dbsqlc.GetHomeTeamRow(teamRow(teams[i]))
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) CreateTeam(ctx context.Context, arg dbsqlc.CreateTeamParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		if t.teamNameTaken(arg.Name, 0) {
+			return ErrDuplicate
+		}
+		id := t.nextID("teams")
+		t.teams[id] = dbsqlc.Team{ID: id, Name: arg.Name, ShortName: arg.ShortName}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) UpdateTeam(ctx context.Context, arg dbsqlc.UpdateTeamParams) error {
+	return q.with(ctx, func(t *tables) error {
+		team, ok := t.teams[arg.ID]
+		if !ok {
+			return nil
+		}
+		if t.teamNameTaken(arg.Name, arg.ID) {
+			return ErrDuplicate
+		}
+		team.Name, team.ShortName = arg.Name, arg.ShortName
+		t.teams[arg.ID] = team
+		return nil
+	})
+}
+
+func (q memQueries) DeleteTeam(ctx context.Context, id int32) error {
+	return q.with(ctx, func(t *tables) error {
+		if t.teamReferences(id) > 0 {
+			return ErrReferenced
+		}
+		delete(t.teams, id)
+		return nil
+	})
+}
+
+func (q memQueries) CountTeamReferences(ctx context.Context, arg dbsqlc.CountTeamReferencesParams) (int32, error) {
+	var refs int32
+	err := q.with(ctx, func(t *tables) error {
+		refs = t.teamReferences(arg.ID)
+		return nil
+	})
+	return refs, err
+}
+
+func (q memQueries) ListTeamIDsIn(ctx context.Context, ids []int32) ([]int32, error) {
+	var found []int32
+	err := q.with(ctx, func(t *tables) error {
+		for _, id := range ids {
+			if _, ok := t.teams[id]; ok && !slices.Contains(found, id) {
+				found = append(found, id)
+			}
+		}
+		slices.Sort(found)
+		return nil
+	})
+	return found, err
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+)
+
+func (q memQueries) CreateUser(ctx context.Context, arg dbsqlc.CreateUserParams) (sql.Result, error) {
+	var result sql.Result
+	err := q.with(ctx, func(t *tables) error {
+		for _, u := range t.users {
+			if sameText(u.Username, arg.Username) {
+				return ErrDuplicate
+			}
+		}
+		id := t.nextID("users")
+		t.users[id] = dbsqlc.User{
+			ID:           id,
+			Username:     arg.Username,
+			PasswordHash: arg.PasswordHash,
+			Role:         arg.Role,
+			CreatedAt:    time.Now(),
+		}
+		result = insertResult{id}
+		return nil
+	})
+	return result, err
+}
+
+func (q memQueries) GetUserByUsername(ctx context.Context, username string) (dbsqlc.GetUserByUsernameRow, error) {
+	var row dbsqlc.GetUserByUsernameRow
+	err := q.with(ctx, func(t *tables) error {
+		for _, u := range t.users {
+			if sameText(u.Username, username) {
+				row = dbsqlc.GetUserByUsernameRow{ID: u.ID, Username: u.Username, PasswordHash: u.PasswordHash, Role: u.Role}
+				return nil
+			}
+		}
+		return sql.ErrNoRows
+	})
+	return row, err
+}
+
+func (q memQueries) ListUsers(ctx context.Context) ([]dbsqlc.ListUsersRow, error) {
+	var rows []dbsqlc.ListUsersRow
+	err := q.with(ctx, func(t *tables) error {
+		users := sortedValues(t.users, func(a, b dbsqlc.User) int { return compareText(a.Username, b.Username) })
+		for _, u := range users {
+			rows = append(rows, dbsqlc.ListUsersRow{ID: u.ID, Username: u.Username, Role: u.Role})
+		}
+		return nil
+	})
+	return rows, err
+}
+
+func (q memQueries) DeleteUser(ctx context.Context, id int32) (int64, error) {
+	var n int64
+	err := q.with(ctx, func(t *tables) error {
+		if _, ok := t.users[id]; !ok {
+			return nil
+		}
+		n = 1
+		delete(t.users, id)
+		for key := range t.userAthletes {
+			if key.userID == id {
+				delete(t.userAthletes, key)
+			}
+		}
+		for hash, s := range t.sessions {
+			if s.UserID == id {
+				delete(t.sessions, hash)
+			}
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (q memQueries) LinkUserAthlete(ctx context.Context, arg dbsqlc.LinkUserAthleteParams) error {
+	return q.with(ctx, func(t *tables) error {
+		_, userOK := t.users[arg.UserID]
+		_, athleteOK := t.athletes[arg.AthleteID]
+		if !userOK || !athleteOK {
+			return ErrNoReferencedRow
+		}
+		key := userAthleteKey{arg.UserID, arg.AthleteID}
+		if t.userAthletes[key] {
+			return ErrDuplicate
+		}
+		t.userAthletes[key] = true
+		return nil
+	})
+}
+
+func (q memQueries) ListUserAthleteIDs(ctx context.Context, userID int32) ([]int32, error) {
+	var ids []int32
+	err := q.with(ctx, func(t *tables) error {
+		for key := range t.userAthletes {
+			if key.userID == userID {
+				ids = append(ids, key.athleteID)
+			}
+		}
+		slices.Sort(ids)
+		return nil
+	})
+	return ids, err
+}
+
+func (q memQueries) CreateSession(ctx context.Context, arg dbsqlc.CreateSessionParams) error {
+	return q.with(ctx, func(t *tables) error {
+		if _, ok := t.users[arg.UserID]; !ok {
+			return ErrNoReferencedRow
+		}
+		if _, ok := t.sessions[arg.TokenHash]; ok {
+			return ErrDuplicate
+		}
+		t.sessions[arg.TokenHash] = dbsqlc.Session{
+			TokenHash: arg.TokenHash,
+			UserID:    arg.UserID,
+			ExpiresAt: arg.ExpiresAt,
+			CreatedAt: time.Now(),
+		}
+		return nil
+	})
+}
+
+func (q memQueries) GetSessionUser(ctx context.Context, arg dbsqlc.GetSessionUserParams) (dbsqlc.GetSessionUserRow, error) {
+	var row dbsqlc.GetSessionUserRow
+	err := q.with(ctx, func(t *tables) error {
+		s, ok := t.sessions[arg.TokenHash]
+		if !ok || !s.ExpiresAt.After(arg.ExpiresAt) {
+			return sql.ErrNoRows
+		}
+		u := t.users[s.UserID]
+		row = dbsqlc.GetSessionUserRow{ID: u.ID, Username: u.Username, Role: u.Role, ExpiresAt: s.ExpiresAt}
+		return nil
+	})
+	return row, err
+}
+
+func (q memQueries) DeleteSession(ctx context.Context, tokenHash string) error {
+	return q.with(ctx, func(t *tables) error {
+		delete(t.sessions, tokenHash)
+		return nil
+	})
+}
+
+func (q memQueries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error {
+	return q.with(ctx, func(t *tables) error {
+		for hash, s := range t.sessions {
+			if !s.ExpiresAt.After(expiresAt) {
+				delete(t.sessions, hash)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	"jones-county-xc/backend/racetime"
+)
+
+// Team is a school. There is always exactly one home team, our own; the
+// rest are opponents whose runners finish in our races.
+type Team struct {
+	ID        int32
+	Name      string
+	ShortName string
+	IsHome    bool
+}
+
+// Athlete is a runner we keep records for. PersonalRecord is the 5K PR
+// the records are kept up to; ManualPR is the hand-entered one it started
+// from, which only CreateAthlete, UpdateAthlete and AthleteManualPR use.
+// Grade is filled in only by TeamRoster, from the season asked about.
+type Athlete struct {
+	ID             int32
+	TeamID         int32
+	Name           string
+	GraduationYear int32
+	Grade          int32
+	PersonalRecord racetime.NullDuration
+	ManualPR       racetime.NullDuration
+	Events         string
+}
+
+// Season is a school year's cross-country season.
+type Season struct {
+	ID    int32
+	Year  int32
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// RosterEntry puts an athlete on a season's roster in a grade.
+// GraduationYear is the athlete's, filled in by Roster.
+type RosterEntry struct {
+	SeasonID       int32
+	AthleteID      int32
+	Grade          int32
+	GraduationYear int32
+}
+
+// Course is a venue's measured loop. ElevationGainM is null when nobody
+// has measured it.
+type Course struct {
+	ID             int32
+	Name           string
+	Address        string
+	DistanceM      int32
+	Surface        string
+	ElevationGainM sql.NullInt32
+	Notes          string
+}
+
+// Meet is a day of racing, on CourseID unless that is null.
+type Meet struct {
+	ID          int32
+	Name        string
+	Date        time.Time
+	Location    string
+	Description string
+	Cancelled   bool
+	CourseID    sql.NullInt32
+}
+
+// Gender is who a race is for.
+type Gender string
+
+const (
+	GenderBoys  Gender = "boys"
+	GenderGirls Gender = "girls"
+	GenderMixed Gender = "mixed"
+)
+
+// Division is the level a race is run at.
+type Division string
+
+const (
+	DivisionVarsity      Division = "varsity"
+	DivisionJV           Division = "jv"
+	DivisionMiddleSchool Division = "middle_school"
+	DivisionOpen         Division = "open"
+)
+
+// Race is one start at a meet. StartTime is HH:MM:SS, or empty when it is
+// not scheduled yet, and CourseID is set only when the race is not run on
+// the meet's course.
+type Race struct {
+	ID        int32
+	MeetID    int32
+	Gender    Gender
+	Division  Division
+	DistanceM int32
+	StartTime string
+	CourseID  sql.NullInt32
+}
+
+// Result is one finish. Our own runners have an AthleteID; opponents have
+// none and are known by RunnerName and TeamID. RunnerName is filled in
+// from the athlete for our own runners on reads. MeetID and the PR flags
+// are read back, and ignored by CreateResult and UpdateResult.
+type Result struct {
+	ID         int32
+	AthleteID  sql.NullInt32
+	TeamID     int32
+	RunnerName string
+	RaceID     int32
+	MeetID     int32
+	Time       racetime.Duration
+	Place      int32
+	IsPR       bool
+	IsCoursePR bool
+}
+
+// Split is a runner's elapsed time at a marker, Index counting from 1.
+type Split struct {
+	ResultID  int32
+	Index     int32
+	DistanceM int32
+	Elapsed   racetime.Duration
+}
+
+// PRFlags says whether a result set a personal record at its distance,
+// overall or on its course.
+type PRFlags struct {
+	ResultID int32
+	PR       bool
+	CoursePR bool
+}
+
+// PersonalRecord is an athlete's best time at a distance, overall when
+// CourseID is null or on that course. The course and meet names and the
+// date are filled in by PersonalRecords.
+type PersonalRecord struct {
+	AthleteID  int32
+	DistanceM  int32
+	CourseID   sql.NullInt32
+	CourseName string
+	ResultID   int32
+	Time       racetime.Duration
+	MeetID     int32
+	MeetName   string
+	Date       time.Time
+}
+
+// AthleteRace is one of an athlete's finishes with the meet and race it was
+// in. CourseID is the course actually run, the race's own or the meet's,
+// and 0 when neither names one.
+type AthleteRace struct {
+	ResultID   int32
+	MeetID     int32
+	MeetName   string
+	Date       time.Time
+	RaceID     int32
+	Gender     Gender
+	Division   Division
+	DistanceM  int32
+	CourseID   int32
+	Time       racetime.Duration
+	Place      int32
+	IsPR       bool
+	IsCoursePR bool
+}
+
+// Finisher is a runner's place in a race and the team it scores for.
+type Finisher struct {
+	AthleteID  sql.NullInt32
+	RunnerName string
+	TeamName   string
+	Place      int32
+	Time       racetime.Duration
+}
+
+// Finish is one of our athletes' times at a meet, for the leaderboards.
+type Finish struct {
+	AthleteName string
+	MeetName    string
+	Time        racetime.Duration
+	Place       int32
+}
+
+// CourseResult is a finish on a course, with what the course records are
+// grouped by.
+type CourseResult struct {
+	Finisher
+	MeetName  string
+	Date      time.Time
+	RaceID    int32
+	Gender    Gender
+	DistanceM int32
+}
+
+// User is a login. Role is one of package auth's roles; PasswordHash is
+// filled in only by UserByUsername.
+type User struct {
+	ID           int32
+	Username     string
+	PasswordHash string
+	Role         string
+}
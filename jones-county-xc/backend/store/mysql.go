@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+)
+
+// NewMySQL returns a Store backed by db, a MySQL database migrated to the
+// latest version.
+func NewMySQL(db *sql.DB) Store {
+	return sqlStore{sqlcQueries: sqlcQueries{dbsqlc.New(db)}, db: db}
+}
+
+type sqlStore struct {
+	sqlcQueries
+	db       *sql.DB
+	observer QueryObserver
+}
+
+func (s sqlStore) observe(observe QueryObserver) Store {
+	s.observer = observe
+	s.sqlcQueries = sqlcQueries{dbsqlc.New(observedBy(s.db, observe))}
+	return s
+}
+
+func (s sqlStore) BeginTx(ctx context.Context) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sqlTx{sqlcQueries: sqlcQueries{dbsqlc.New(observedBy(tx, s.observer))}, tx: tx}, nil
+}
+
+type sqlTx struct {
+	sqlcQueries
+	tx *sql.Tx
+}
+
+func (t sqlTx) Commit() error   { return t.tx.Commit() }
+func (t sqlTx) Rollback() error { return t.tx.Rollback() }
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// QueryObserver is told about each query a store runs: its sqlc name, such
+// as "ListAthletes", how long it took and the error it returned, if any.
+type QueryObserver func(name string, elapsed time.Duration, err error)
+
+// Observe returns st with every query it runs, in or out of a transaction,
+// reported to observe. The Memory store runs no queries, so it comes back
+// as it is.
+func Observe(st Store, observe QueryObserver) Store {
+	if o, ok := st.(interface{ observe(QueryObserver) Store }); ok {
+		return o.observe(observe)
+	}
+	return st
+}
+
+// dbtx is what the sqlc packages each call their DBTX, the methods they
+// run queries with.
+type dbtx interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...any) *sql.Row
+}
+
+// observed runs queries on db, reporting each to observe. It times a query
+// until its first row is ready, not until the caller has read them all.
+type observed struct {
+	db      dbtx
+	observe QueryObserver
+}
+
+// observedBy wraps db when there is an observer to report to.
+func observedBy(db dbtx, observe QueryObserver) dbtx {
+	if observe == nil {
+		return db
+	}
+	return observed{db, observe}
+}
+
+func (o observed) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := o.db.ExecContext(ctx, query, args...)
+	o.observe(queryName(query), time.Since(start), err)
+	return result, err
+}
+
+func (o observed) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return o.db.PrepareContext(ctx, query)
+}
+
+func (o observed) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	o.observe(queryName(query), time.Since(start), err)
+	return rows, err
+}
+
+func (o observed) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := o.db.QueryRowContext(ctx, query, args...)
+	o.observe(queryName(query), time.Since(start), row.Err())
+	return row
+}
+
+// queryName reads the name sqlc puts in the comment it starts each query
+// with, "-- name: ListAthletes :many".
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "unnamed"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
@@ -0,0 +1,703 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+	"jones-county-xc/backend/racetime"
+)
+
+// sqlcQueries answers Queries with a store's answers to the sqlc queries:
+// the MySQL target's generated code, the SQLite store's conversion of its
+// own, or the Memory store's tables. It is where the generated rows and
+// parameters become this package's types.
+type sqlcQueries struct {
+	q dbsqlc.Querier
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// insertedID is the id an INSERT assigned.
+func insertedID(result sql.Result, err error) (int32, error) {
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	return int32(id), err
+}
+
+// athleteIDs drops the NULLs from a column of athlete ids.
+func athleteIDs(ids []sql.NullInt32, err error) ([]int32, error) {
+	if err != nil {
+		return nil, err
+	}
+	var valid []int32
+	for _, id := range ids {
+		if id.Valid {
+			valid = append(valid, id.Int32)
+		}
+	}
+	return valid, nil
+}
+
+func (q sqlcQueries) Teams(ctx context.Context) ([]Team, error) {
+	rows, err := q.q.ListTeams(ctx)
+	return convertRows(rows, err, func(r dbsqlc.ListTeamsRow) Team { return Team(r) })
+}
+
+func (q sqlcQueries) Team(ctx context.Context, id int32) (Team, error) {
+	row, err := q.q.GetTeamByID(ctx, id)
+	return Team(row), err
+}
+
+func (q sqlcQueries) HomeTeam(ctx context.Context) (Team, error) {
+	row, err := q.q.GetHomeTeam(ctx)
+	return Team(row), err
+}
+
+func (q sqlcQueries) CreateTeam(ctx context.Context, team Team) (int32, error) {
+	return insertedID(q.q.CreateTeam(ctx, dbsqlc.CreateTeamParams{
+		Name:      team.Name,
+		ShortName: nullString(team.ShortName),
+	}))
+}
+
+func (q sqlcQueries) UpdateTeam(ctx context.Context, team Team) error {
+	return q.q.UpdateTeam(ctx, dbsqlc.UpdateTeamParams{
+		Name:      team.Name,
+		ShortName: nullString(team.ShortName),
+		ID:        team.ID,
+	})
+}
+
+func (q sqlcQueries) DeleteTeam(ctx context.Context, id int32) error {
+	return q.q.DeleteTeam(ctx, id)
+}
+
+func (q sqlcQueries) TeamInUse(ctx context.Context, id int32) (bool, error) {
+	refs, err := q.q.CountTeamReferences(ctx, dbsqlc.CountTeamReferencesParams{ID: id})
+	return refs > 0, err
+}
+
+func (q sqlcQueries) KnownTeamIDs(ctx context.Context, ids []int32) ([]int32, error) {
+	return q.q.ListTeamIDsIn(ctx, ids)
+}
+
+// newAthlete makes an Athlete of any of the athlete rows without a grade,
+// which all have these fields.
+func newAthlete(r dbsqlc.GetAthleteByIDRow) Athlete {
+	return Athlete{
+		ID:             r.ID,
+		TeamID:         r.TeamID,
+		Name:           r.Name,
+		GraduationYear: r.GraduationYear,
+		PersonalRecord: r.PersonalRecordMs,
+		Events:         r.Events,
+	}
+}
+
+func (q sqlcQueries) TeamRoster(ctx context.Context, teamID, seasonID int32) ([]Athlete, error) {
+	rows, err := q.q.ListAthletes(ctx, dbsqlc.ListAthletesParams{TeamID: teamID, SeasonID: seasonID})
+	return convertRows(rows, err, func(r dbsqlc.ListAthletesRow) Athlete {
+		return Athlete{
+			ID:             r.ID,
+			TeamID:         r.TeamID,
+			Name:           r.Name,
+			GraduationYear: r.GraduationYear,
+			Grade:          r.Grade,
+			PersonalRecord: r.PersonalRecordMs,
+			Events:         r.Events,
+		}
+	})
+}
+
+func (q sqlcQueries) TeamAthletes(ctx context.Context, teamID int32) ([]Athlete, error) {
+	rows, err := q.q.ListAthletesAllSeasons(ctx, teamID)
+	return convertRows(rows, err, func(r dbsqlc.ListAthletesAllSeasonsRow) Athlete {
+		return newAthlete(dbsqlc.GetAthleteByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) Athlete(ctx context.Context, id int32) (Athlete, error) {
+	row, err := q.q.GetAthleteByID(ctx, id)
+	return newAthlete(row), err
+}
+
+func (q sqlcQueries) CreateAthlete(ctx context.Context, athlete Athlete) (int32, error) {
+	return insertedID(q.q.CreateAthlete(ctx, dbsqlc.CreateAthleteParams{
+		TeamID:         athlete.TeamID,
+		Name:           athlete.Name,
+		GraduationYear: athlete.GraduationYear,
+		ManualPrMs:     athlete.ManualPR,
+	}))
+}
+
+func (q sqlcQueries) UpdateAthlete(ctx context.Context, athlete Athlete) error {
+	return q.q.UpdateAthlete(ctx, dbsqlc.UpdateAthleteParams{
+		TeamID:         athlete.TeamID,
+		Name:           athlete.Name,
+		GraduationYear: athlete.GraduationYear,
+		ManualPrMs:     athlete.ManualPR,
+		ID:             athlete.ID,
+	})
+}
+
+func (q sqlcQueries) DeleteAthlete(ctx context.Context, id int32) (bool, error) {
+	n, err := q.q.DeleteAthlete(ctx, id)
+	return n > 0, err
+}
+
+func (q sqlcQueries) AthleteTeams(ctx context.Context, ids []int32) (map[int32]int32, error) {
+	rows, err := q.q.ListAthleteTeamsIn(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	teams := make(map[int32]int32, len(rows))
+	for _, row := range rows {
+		teams[row.ID] = row.TeamID
+	}
+	return teams, nil
+}
+
+func (q sqlcQueries) AthleteManualPR(ctx context.Context, id int32) (racetime.NullDuration, error) {
+	return q.q.GetAthleteManualPR(ctx, id)
+}
+
+func (q sqlcQueries) SetAthletePR(ctx context.Context, id int32, pr racetime.NullDuration) error {
+	return q.q.SetAthletePR(ctx, dbsqlc.SetAthletePRParams{PersonalRecordMs: pr, ID: id})
+}
+
+func (q sqlcQueries) FastestAthletes(ctx context.Context) ([]Athlete, error) {
+	rows, err := q.q.ListFastestAthletes(ctx)
+	return convertRows(rows, err, func(r dbsqlc.ListFastestAthletesRow) Athlete {
+		return newAthlete(dbsqlc.GetAthleteByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) FastestAthletesInSeason(ctx context.Context, seasonID int32) ([]Athlete, error) {
+	rows, err := q.q.ListFastestAthletesInSeason(ctx, seasonID)
+	return convertRows(rows, err, func(r dbsqlc.ListFastestAthletesInSeasonRow) Athlete {
+		return newAthlete(dbsqlc.GetAthleteByIDRow(r))
+	})
+}
+
+func newAthleteRace(r dbsqlc.ListAthleteProgressionRow) AthleteRace {
+	return AthleteRace{
+		ResultID:   r.ID,
+		MeetID:     r.MeetID,
+		MeetName:   r.MeetName,
+		Date:       r.Date,
+		RaceID:     r.RaceID,
+		Gender:     Gender(r.Gender),
+		Division:   Division(r.Division),
+		DistanceM:  r.DistanceM,
+		CourseID:   r.CourseID,
+		Time:       r.TimeMs,
+		Place:      r.Place,
+		IsPR:       r.IsPr,
+		IsCoursePR: r.IsCoursePr,
+	}
+}
+
+func (q sqlcQueries) AthleteRaces(ctx context.Context, athleteID int32) ([]AthleteRace, error) {
+	rows, err := q.q.ListAthleteProgression(ctx, sql.NullInt32{Int32: athleteID, Valid: true})
+	return convertRows(rows, err, newAthleteRace)
+}
+
+func (q sqlcQueries) AthleteRacesBetween(ctx context.Context, athleteID int32, start, end time.Time) ([]AthleteRace, error) {
+	rows, err := q.q.ListAthleteHistory(ctx, dbsqlc.ListAthleteHistoryParams{
+		AthleteID: sql.NullInt32{Int32: athleteID, Valid: true},
+		StartDate: start,
+		EndDate:   end,
+	})
+	return convertRows(rows, err, func(r dbsqlc.ListAthleteHistoryRow) AthleteRace {
+		return newAthleteRace(dbsqlc.ListAthleteProgressionRow(r))
+	})
+}
+
+func (q sqlcQueries) CountAthletes(ctx context.Context) (int64, error) {
+	return q.q.CountAthletes(ctx)
+}
+
+func newSeason(r dbsqlc.Season) Season {
+	return Season{ID: r.ID, Year: r.Year, Name: r.Name, Start: r.StartDate, End: r.EndDate}
+}
+
+func (q sqlcQueries) Seasons(ctx context.Context) ([]Season, error) {
+	rows, err := q.q.ListSeasons(ctx)
+	return convertRows(rows, err, newSeason)
+}
+
+func (q sqlcQueries) Season(ctx context.Context, year int32) (Season, error) {
+	row, err := q.q.GetSeasonByYear(ctx, year)
+	return newSeason(row), err
+}
+
+func (q sqlcQueries) CreateSeason(ctx context.Context, season Season) (int32, error) {
+	return insertedID(q.q.CreateSeason(ctx, dbsqlc.CreateSeasonParams{
+		Year:      season.Year,
+		Name:      season.Name,
+		StartDate: season.Start,
+		EndDate:   season.End,
+	}))
+}
+
+func (q sqlcQueries) RenameSeason(ctx context.Context, id int32, name string) error {
+	return q.q.UpdateSeason(ctx, dbsqlc.UpdateSeasonParams{Name: name, ID: id})
+}
+
+func (q sqlcQueries) Roster(ctx context.Context, seasonID int32) ([]RosterEntry, error) {
+	rows, err := q.q.ListRoster(ctx, seasonID)
+	return convertRows(rows, err, func(r dbsqlc.ListRosterRow) RosterEntry {
+		return RosterEntry{SeasonID: seasonID, AthleteID: r.AthleteID, Grade: r.Grade, GraduationYear: r.GraduationYear}
+	})
+}
+
+func (q sqlcQueries) PutOnRoster(ctx context.Context, entry RosterEntry) error {
+	return q.q.UpsertRosterEntry(ctx, dbsqlc.UpsertRosterEntryParams{
+		SeasonID:  entry.SeasonID,
+		AthleteID: entry.AthleteID,
+		Grade:     entry.Grade,
+	})
+}
+
+func (q sqlcQueries) SetRosterGrade(ctx context.Context, entry RosterEntry) error {
+	return q.q.UpdateRosterGrade(ctx, dbsqlc.UpdateRosterGradeParams{
+		Grade:     entry.Grade,
+		SeasonID:  entry.SeasonID,
+		AthleteID: entry.AthleteID,
+	})
+}
+
+func (q sqlcQueries) TakeOffRoster(ctx context.Context, seasonID, athleteID int32) (bool, error) {
+	n, err := q.q.DeleteRosterEntry(ctx, dbsqlc.DeleteRosterEntryParams{SeasonID: seasonID, AthleteID: athleteID})
+	return n > 0, err
+}
+
+func (q sqlcQueries) Courses(ctx context.Context) ([]Course, error) {
+	rows, err := q.q.ListCourses(ctx)
+	return convertRows(rows, err, func(r dbsqlc.ListCoursesRow) Course { return Course(r) })
+}
+
+func (q sqlcQueries) Course(ctx context.Context, id int32) (Course, error) {
+	row, err := q.q.GetCourseByID(ctx, id)
+	return Course(row), err
+}
+
+func (q sqlcQueries) CreateCourse(ctx context.Context, course Course) (int32, error) {
+	return insertedID(q.q.CreateCourse(ctx, dbsqlc.CreateCourseParams{
+		Name:           course.Name,
+		Address:        nullString(course.Address),
+		DistanceM:      course.DistanceM,
+		Surface:        nullString(course.Surface),
+		ElevationGainM: course.ElevationGainM,
+		Notes:          nullString(course.Notes),
+	}))
+}
+
+func (q sqlcQueries) UpdateCourse(ctx context.Context, course Course) error {
+	return q.q.UpdateCourse(ctx, dbsqlc.UpdateCourseParams{
+		Name:           course.Name,
+		Address:        nullString(course.Address),
+		DistanceM:      course.DistanceM,
+		Surface:        nullString(course.Surface),
+		ElevationGainM: course.ElevationGainM,
+		Notes:          nullString(course.Notes),
+		ID:             course.ID,
+	})
+}
+
+func (q sqlcQueries) DeleteCourse(ctx context.Context, id int32) error {
+	return q.q.DeleteCourse(ctx, id)
+}
+
+func (q sqlcQueries) CourseInUse(ctx context.Context, id int32) (bool, error) {
+	refs, err := q.q.CountCourseReferences(ctx, dbsqlc.CountCourseReferencesParams{ID: sql.NullInt32{Int32: id, Valid: true}})
+	return refs > 0, err
+}
+
+func (q sqlcQueries) CourseResults(ctx context.Context, courseID int32) ([]CourseResult, error) {
+	rows, err := q.q.ListCourseResults(ctx, dbsqlc.ListCourseResultsParams{CourseID: sql.NullInt32{Int32: courseID, Valid: true}})
+	return convertRows(rows, err, func(r dbsqlc.ListCourseResultsRow) CourseResult {
+		return CourseResult{
+			Finisher: Finisher{
+				AthleteID:  r.AthleteID,
+				RunnerName: r.RunnerName,
+				TeamName:   r.TeamName,
+				Place:      r.Place,
+				Time:       r.TimeMs,
+			},
+			MeetName:  r.MeetName,
+			Date:      r.Date,
+			RaceID:    r.RaceID,
+			Gender:    Gender(r.Gender),
+			DistanceM: r.DistanceM,
+		}
+	})
+}
+
+func (q sqlcQueries) Meets(ctx context.Context, start, end time.Time) ([]Meet, error) {
+	rows, err := q.q.ListMeets(ctx, dbsqlc.ListMeetsParams{StartDate: start, EndDate: end})
+	return convertRows(rows, err, func(r dbsqlc.ListMeetsRow) Meet { return Meet(r) })
+}
+
+func (q sqlcQueries) Meet(ctx context.Context, id int32) (Meet, error) {
+	row, err := q.q.GetMeetByID(ctx, id)
+	return Meet(row), err
+}
+
+func (q sqlcQueries) CreateMeet(ctx context.Context, meet Meet) (int32, error) {
+	return insertedID(q.q.CreateMeet(ctx, dbsqlc.CreateMeetParams{
+		Name:        meet.Name,
+		Date:        meet.Date,
+		Location:    nullString(meet.Location),
+		Description: nullString(meet.Description),
+		Cancelled:   meet.Cancelled,
+		CourseID:    meet.CourseID,
+	}))
+}
+
+func (q sqlcQueries) UpdateMeet(ctx context.Context, meet Meet) error {
+	return q.q.UpdateMeet(ctx, dbsqlc.UpdateMeetParams{
+		Name:        meet.Name,
+		Date:        meet.Date,
+		Location:    nullString(meet.Location),
+		Description: nullString(meet.Description),
+		Cancelled:   meet.Cancelled,
+		CourseID:    meet.CourseID,
+		ID:          meet.ID,
+	})
+}
+
+func (q sqlcQueries) DeleteMeet(ctx context.Context, id int32) error {
+	return q.q.DeleteMeet(ctx, id)
+}
+
+func (q sqlcQueries) MeetHasResults(ctx context.Context, id int32) (bool, error) {
+	n, err := q.q.CountResultsByMeet(ctx, id)
+	return n > 0, err
+}
+
+func (q sqlcQueries) MeetAthleteIDs(ctx context.Context, id int32) ([]int32, error) {
+	return athleteIDs(q.q.ListMeetAthleteIDs(ctx, id))
+}
+
+func newRace(r dbsqlc.Race) Race {
+	return Race{
+		ID:        r.ID,
+		MeetID:    r.MeetID,
+		Gender:    Gender(r.Gender),
+		Division:  Division(r.Division),
+		DistanceM: r.DistanceM,
+		StartTime: r.StartTime.String,
+		CourseID:  r.CourseID,
+	}
+}
+
+func (q sqlcQueries) MeetRaces(ctx context.Context, meetID int32) ([]Race, error) {
+	rows, err := q.q.ListRacesByMeet(ctx, meetID)
+	return convertRows(rows, err, newRace)
+}
+
+func (q sqlcQueries) Race(ctx context.Context, id int32) (Race, error) {
+	row, err := q.q.GetRaceByID(ctx, id)
+	return newRace(row), err
+}
+
+func (q sqlcQueries) CreateRace(ctx context.Context, race Race) (int32, error) {
+	return insertedID(q.q.CreateRace(ctx, dbsqlc.CreateRaceParams{
+		MeetID:    race.MeetID,
+		Gender:    dbsqlc.RacesGender(race.Gender),
+		Division:  dbsqlc.RacesDivision(race.Division),
+		DistanceM: race.DistanceM,
+		StartTime: nullString(race.StartTime),
+		CourseID:  race.CourseID,
+	}))
+}
+
+func (q sqlcQueries) UpdateRace(ctx context.Context, race Race) error {
+	return q.q.UpdateRace(ctx, dbsqlc.UpdateRaceParams{
+		Gender:    dbsqlc.RacesGender(race.Gender),
+		Division:  dbsqlc.RacesDivision(race.Division),
+		DistanceM: race.DistanceM,
+		StartTime: nullString(race.StartTime),
+		CourseID:  race.CourseID,
+		ID:        race.ID,
+	})
+}
+
+func (q sqlcQueries) DeleteRace(ctx context.Context, id int32) error {
+	return q.q.DeleteRace(ctx, id)
+}
+
+func (q sqlcQueries) RaceHasResults(ctx context.Context, id int32) (bool, error) {
+	n, err := q.q.CountResultsByRace(ctx, id)
+	return n > 0, err
+}
+
+func (q sqlcQueries) RaceAthleteIDs(ctx context.Context, id int32) ([]int32, error) {
+	return athleteIDs(q.q.ListRaceAthleteIDs(ctx, id))
+}
+
+func (q sqlcQueries) RaceFinishers(ctx context.Context, id int32) ([]Finisher, error) {
+	rows, err := q.q.ListRaceFinishers(ctx, id)
+	return convertRows(rows, err, func(r dbsqlc.ListRaceFinishersRow) Finisher {
+		return Finisher{
+			AthleteID:  r.AthleteID,
+			RunnerName: r.RunnerName,
+			TeamName:   r.TeamName,
+			Place:      r.Place,
+			Time:       r.TimeMs,
+		}
+	})
+}
+
+// newResult makes a Result of any of the result rows, which all have the
+// same fields.
+func newResult(r dbsqlc.GetResultByIDRow) Result {
+	return Result{
+		ID:         r.ID,
+		AthleteID:  r.AthleteID,
+		TeamID:     r.TeamID,
+		RunnerName: r.RunnerName,
+		RaceID:     r.RaceID,
+		MeetID:     r.MeetID,
+		Time:       r.TimeMs,
+		Place:      r.Place,
+		IsPR:       r.IsPr,
+		IsCoursePR: r.IsCoursePr,
+	}
+}
+
+func (q sqlcQueries) Result(ctx context.Context, id int32) (Result, error) {
+	row, err := q.q.GetResultByID(ctx, id)
+	return newResult(row), err
+}
+
+func (q sqlcQueries) CreateResult(ctx context.Context, result Result) (int32, error) {
+	return insertedID(q.q.CreateResult(ctx, dbsqlc.CreateResultParams{
+		AthleteID:  result.AthleteID,
+		TeamID:     result.TeamID,
+		RunnerName: nullString(result.RunnerName),
+		RaceID:     result.RaceID,
+		TimeMs:     result.Time,
+		Place:      result.Place,
+	}))
+}
+
+func (q sqlcQueries) UpdateResult(ctx context.Context, result Result) error {
+	return q.q.UpdateResult(ctx, dbsqlc.UpdateResultParams{
+		AthleteID:  result.AthleteID,
+		TeamID:     result.TeamID,
+		RunnerName: nullString(result.RunnerName),
+		RaceID:     result.RaceID,
+		TimeMs:     result.Time,
+		Place:      result.Place,
+		ID:         result.ID,
+	})
+}
+
+func (q sqlcQueries) DeleteResult(ctx context.Context, id int32) error {
+	return q.q.DeleteResult(ctx, id)
+}
+
+func (q sqlcQueries) DeleteRaceResults(ctx context.Context, raceID int32) error {
+	return q.q.DeleteResultsByRace(ctx, raceID)
+}
+
+func (q sqlcQueries) ResultsBetween(ctx context.Context, start, end time.Time) ([]Result, error) {
+	rows, err := q.q.ListResultsBetween(ctx, dbsqlc.ListResultsBetweenParams{StartDate: start, EndDate: end})
+	return convertRows(rows, err, func(r dbsqlc.ListResultsBetweenRow) Result {
+		return newResult(dbsqlc.GetResultByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) MeetResults(ctx context.Context, meetID int32) ([]Result, error) {
+	rows, err := q.q.ListResultsByMeet(ctx, meetID)
+	return convertRows(rows, err, func(r dbsqlc.ListResultsByMeetRow) Result {
+		return newResult(dbsqlc.GetResultByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) MeetTeamResults(ctx context.Context, meetID, teamID int32) ([]Result, error) {
+	rows, err := q.q.ListResultsByMeetAndTeam(ctx, dbsqlc.ListResultsByMeetAndTeamParams{MeetID: meetID, TeamID: teamID})
+	return convertRows(rows, err, func(r dbsqlc.ListResultsByMeetAndTeamRow) Result {
+		return newResult(dbsqlc.GetResultByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) RaceResults(ctx context.Context, raceID int32) ([]Result, error) {
+	rows, err := q.q.ListResultsByRace(ctx, raceID)
+	return convertRows(rows, err, func(r dbsqlc.ListResultsByRaceRow) Result {
+		return newResult(dbsqlc.GetResultByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) RaceTeamResults(ctx context.Context, raceID, teamID int32) ([]Result, error) {
+	rows, err := q.q.ListResultsByRaceAndTeam(ctx, dbsqlc.ListResultsByRaceAndTeamParams{RaceID: raceID, TeamID: teamID})
+	return convertRows(rows, err, func(r dbsqlc.ListResultsByRaceAndTeamRow) Result {
+		return newResult(dbsqlc.GetResultByIDRow(r))
+	})
+}
+
+func (q sqlcQueries) FastestTimes(ctx context.Context, start, end time.Time, distanceM int32) ([]Finish, error) {
+	rows, err := q.q.ListFastestTimes(ctx, dbsqlc.ListFastestTimesParams{StartDate: start, EndDate: end, DistanceM: distanceM})
+	return convertRows(rows, err, func(r dbsqlc.ListFastestTimesRow) Finish {
+		return Finish{AthleteName: r.AthleteName, MeetName: r.MeetName, Time: r.TimeMs, Place: r.Place}
+	})
+}
+
+func (q sqlcQueries) LatestMeetResults(ctx context.Context, start, end time.Time) ([]Finish, error) {
+	rows, err := q.q.ListLatestMeetResults(ctx, dbsqlc.ListLatestMeetResultsParams{StartDate: start, EndDate: end})
+	return convertRows(rows, err, func(r dbsqlc.ListLatestMeetResultsRow) Finish {
+		return Finish{AthleteName: r.AthleteName, MeetName: r.MeetName, Time: r.TimeMs, Place: r.Place}
+	})
+}
+
+func (q sqlcQueries) SetSplits(ctx context.Context, resultID int32, splits []Split) error {
+	if err := q.q.DeleteResultSplits(ctx, resultID); err != nil {
+		return err
+	}
+	for i, split := range splits {
+		if err := q.q.CreateResultSplit(ctx, dbsqlc.CreateResultSplitParams{
+			ResultID:   resultID,
+			SplitIndex: int32(i + 1),
+			DistanceM:  split.DistanceM,
+			ElapsedMs:  split.Elapsed,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newSplits(rows []dbsqlc.ResultSplit, err error) ([]Split, error) {
+	return convertRows(rows, err, func(r dbsqlc.ResultSplit) Split {
+		return Split{ResultID: r.ResultID, Index: r.SplitIndex, DistanceM: r.DistanceM, Elapsed: r.ElapsedMs}
+	})
+}
+
+func (q sqlcQueries) ResultSplits(ctx context.Context, resultID int32) ([]Split, error) {
+	return newSplits(q.q.ListSplitsByResult(ctx, resultID))
+}
+
+func (q sqlcQueries) MeetSplits(ctx context.Context, meetID int32) ([]Split, error) {
+	return newSplits(q.q.ListSplitsByMeet(ctx, meetID))
+}
+
+func (q sqlcQueries) RaceSplits(ctx context.Context, raceID int32) ([]Split, error) {
+	return newSplits(q.q.ListSplitsByRace(ctx, raceID))
+}
+
+func (q sqlcQueries) AthleteSplits(ctx context.Context, athleteID int32) ([]Split, error) {
+	return newSplits(q.q.ListSplitsByAthlete(ctx, sql.NullInt32{Int32: athleteID, Valid: true}))
+}
+
+func (q sqlcQueries) CountResultsSince(ctx context.Context, since time.Time) (int64, error) {
+	return q.q.CountResultsCreatedSince(ctx, since)
+}
+
+func (q sqlcQueries) SetPersonalRecords(ctx context.Context, athleteID int32, records []PersonalRecord) error {
+	if err := q.q.DeletePersonalRecords(ctx, athleteID); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if err := q.q.CreatePersonalRecord(ctx, dbsqlc.CreatePersonalRecordParams{
+			AthleteID: athleteID,
+			DistanceM: rec.DistanceM,
+			CourseID:  rec.CourseID,
+			ResultID:  rec.ResultID,
+			TimeMs:    rec.Time,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q sqlcQueries) SetPRFlags(ctx context.Context, athleteID int32, flags []PRFlags) error {
+	if err := q.q.ClearResultPRFlags(ctx, sql.NullInt32{Int32: athleteID, Valid: true}); err != nil {
+		return err
+	}
+	for _, f := range flags {
+		if err := q.q.SetResultPRFlags(ctx, dbsqlc.SetResultPRFlagsParams{
+			IsPr:       f.PR,
+			IsCoursePr: f.CoursePR,
+			ID:         f.ResultID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q sqlcQueries) PersonalRecords(ctx context.Context, athleteID int32) ([]PersonalRecord, error) {
+	rows, err := q.q.ListPersonalRecords(ctx, athleteID)
+	return convertRows(rows, err, func(r dbsqlc.ListPersonalRecordsRow) PersonalRecord {
+		return PersonalRecord{
+			AthleteID:  athleteID,
+			DistanceM:  r.DistanceM,
+			CourseID:   r.CourseID,
+			CourseName: r.CourseName.String,
+			ResultID:   r.ResultID,
+			Time:       r.TimeMs,
+			MeetID:     r.MeetID,
+			MeetName:   r.MeetName,
+			Date:       r.Date,
+		}
+	})
+}
+
+func (q sqlcQueries) CreateUser(ctx context.Context, user User) (int32, error) {
+	return insertedID(q.q.CreateUser(ctx, dbsqlc.CreateUserParams{
+		Username:     user.Username,
+		PasswordHash: user.PasswordHash,
+		Role:         dbsqlc.UsersRole(user.Role),
+	}))
+}
+
+func (q sqlcQueries) UserByUsername(ctx context.Context, username string) (User, error) {
+	row, err := q.q.GetUserByUsername(ctx, username)
+	return User{ID: row.ID, Username: row.Username, PasswordHash: row.PasswordHash, Role: string(row.Role)}, err
+}
+
+func (q sqlcQueries) Users(ctx context.Context) ([]User, error) {
+	rows, err := q.q.ListUsers(ctx)
+	return convertRows(rows, err, func(r dbsqlc.ListUsersRow) User {
+		return User{ID: r.ID, Username: r.Username, Role: string(r.Role)}
+	})
+}
+
+func (q sqlcQueries) DeleteUser(ctx context.Context, id int32) (bool, error) {
+	n, err := q.q.DeleteUser(ctx, id)
+	return n > 0, err
+}
+
+func (q sqlcQueries) LinkAthlete(ctx context.Context, userID, athleteID int32) error {
+	return q.q.LinkUserAthlete(ctx, dbsqlc.LinkUserAthleteParams{UserID: userID, AthleteID: athleteID})
+}
+
+func (q sqlcQueries) LinkedAthleteIDs(ctx context.Context, userID int32) ([]int32, error) {
+	return q.q.ListUserAthleteIDs(ctx, userID)
+}
+
+func (q sqlcQueries) CreateSession(ctx context.Context, tokenHash string, userID int32, expires time.Time) error {
+	return q.q.CreateSession(ctx, dbsqlc.CreateSessionParams{TokenHash: tokenHash, UserID: userID, ExpiresAt: expires})
+}
+
+func (q sqlcQueries) SessionUser(ctx context.Context, tokenHash string, now time.Time) (User, error) {
+	row, err := q.q.GetSessionUser(ctx, dbsqlc.GetSessionUserParams{TokenHash: tokenHash, ExpiresAt: now})
+	return User{ID: row.ID, Username: row.Username, Role: string(row.Role)}, err
+}
+
+func (q sqlcQueries) DeleteSession(ctx context.Context, tokenHash string) error {
+	return q.q.DeleteSession(ctx, tokenHash)
+}
+
+func (q sqlcQueries) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	return q.q.DeleteExpiredSessions(ctx, now)
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sqlitesqlc "jones-county-xc/backend/db/sqlite/sqlc"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// OpenSQLite opens the SQLite database at path, creating an empty one if
+// it does not exist yet; package migrate creates its tables. ":memory:" is
+// a database that lasts only as long as the returned pool.
+//
+// The pool holds a single connection. SQLite runs one writer at a time
+// anyway, and with one connection a transaction never waits on a lock held
+// by another, and a :memory: database is not lost with an idle connection.
+func OpenSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetConnMaxIdleTime(0)
+	sqlDB.SetConnMaxLifetime(0)
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return sqlDB, nil
+}
+
+// NewSQLite returns a Store backed by sqlDB, a SQLite database opened with
+// OpenSQLite.
+func NewSQLite(sqlDB *sql.DB) Store {
+	return sqliteStore{sqlcQueries: sqliteQueriesOn(sqlDB), db: sqlDB}
+}
+
+type sqliteStore struct {
+	sqlcQueries
+	db       *sql.DB
+	observer QueryObserver
+}
+
+func (s sqliteStore) observe(observe QueryObserver) Store {
+	s.observer = observe
+	s.sqlcQueries = sqliteQueriesOn(observedBy(s.db, observe))
+	return s
+}
+
+func (s sqliteStore) BeginTx(ctx context.Context) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sqliteTx{sqlcQueries: sqliteQueriesOn(observedBy(tx, s.observer)), tx: tx}, nil
+}
+
+type sqliteTx struct {
+	sqlcQueries
+	tx *sql.Tx
+}
+
+func (t sqliteTx) Commit() error   { return t.tx.Commit() }
+func (t sqliteTx) Rollback() error { return t.tx.Rollback() }
+
+// sqliteConn runs the generated queries on a *sql.DB or *sql.Tx. SQLite
+// keeps times as text and compares them as text, so it writes every time
+// in UTC, where text order is time order. And it reports constraint
+// violations with this package's errors, as Violates does MySQL's.
+type sqliteConn struct {
+	db sqlitesqlc.DBTX
+}
+
+func (c sqliteConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	result, err := c.db.ExecContext(ctx, query, inUTC(args)...)
+	return result, sqliteError(query, err)
+}
+
+func (c sqliteConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(ctx, query)
+}
+
+func (c sqliteConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.db.QueryContext(ctx, query, inUTC(args)...)
+}
+
+func (c sqliteConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return c.db.QueryRowContext(ctx, query, inUTC(args)...)
+}
+
+func inUTC(args []any) []any {
+	for i, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			args[i] = t.UTC()
+		}
+	}
+	return args
+}
+
+// sqliteError wraps a constraint violation in the matching store error.
+// SQLite has one code for both ways of breaking a foreign key; a DELETE
+// breaks it by removing a row something references, and any other write
+// by referencing a row that is not there.
+func sqliteError(query string, err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		return fmt.Errorf("%w: %v", ErrDuplicate, err)
+	case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		// Generated queries start with a "-- name:" comment line
+		_, statement, _ := strings.Cut(query, "\n")
+		if strings.HasPrefix(statement, "DELETE") {
+			return fmt.Errorf("%w: %v", ErrReferenced, err)
+		}
+		return fmt.Errorf("%w: %v", ErrNoReferencedRow, err)
+	}
+	return err
+}
@@ -0,0 +1,461 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	dbsqlc "jones-county-xc/backend/db/sqlc"
+	sqlitesqlc "jones-county-xc/backend/db/sqlite/sqlc"
+	"jones-county-xc/backend/racetime"
+)
+
+// sqliteQueries answers the MySQL target's queries with the SQLite
+// target's generated code. The two targets' rows and parameters have the
+// same fields in the same order, so each converts to the other directly.
+type sqliteQueries struct {
+	q *sqlitesqlc.Queries
+}
+
+// sqliteQueriesOn runs the queries on db, a *sql.DB or *sql.Tx.
+func sqliteQueriesOn(db sqlitesqlc.DBTX) sqlcQueries {
+	return sqlcQueries{sqliteQueries{sqlitesqlc.New(sqliteConn{db})}}
+}
+
+func convertRows[S, D any](rows []S, err error, convert func(S) D) ([]D, error) {
+	if err != nil || rows == nil {
+		return nil, err
+	}
+	converted := make([]D, len(rows))
+	for i, row := range rows {
+		converted[i] = convert(row)
+	}
+	return converted, nil
+}
+
+func (q sqliteQueries) ClearResultPRFlags(ctx context.Context, athleteID sql.NullInt32) error {
+	return q.q.ClearResultPRFlags(ctx, athleteID)
+}
+
+func (q sqliteQueries) CountCourseReferences(ctx context.Context, arg dbsqlc.CountCourseReferencesParams) (int32, error) {
+	// SQLite binds the repeated id once, so it takes no params struct
+	refs, err := q.q.CountCourseReferences(ctx, arg.ID)
+	return int32(refs), err
+}
+
+func (q sqliteQueries) CountAthletes(ctx context.Context) (int64, error) {
+	return q.q.CountAthletes(ctx)
+}
+
+func (q sqliteQueries) CountResultsByMeet(ctx context.Context, meetID int32) (int64, error) {
+	return q.q.CountResultsByMeet(ctx, meetID)
+}
+
+func (q sqliteQueries) CountResultsByRace(ctx context.Context, raceID int32) (int64, error) {
+	return q.q.CountResultsByRace(ctx, raceID)
+}
+
+func (q sqliteQueries) CountResultsCreatedSince(ctx context.Context, createdAt time.Time) (int64, error) {
+	return q.q.CountResultsCreatedSince(ctx, createdAt)
+}
+
+func (q sqliteQueries) CountTeamReferences(ctx context.Context, arg dbsqlc.CountTeamReferencesParams) (int32, error) {
+	// SQLite binds the repeated id once, so it takes no params struct
+	refs, err := q.q.CountTeamReferences(ctx, arg.ID)
+	return int32(refs), err
+}
+
+func (q sqliteQueries) CreateAthlete(ctx context.Context, arg dbsqlc.CreateAthleteParams) (sql.Result, error) {
+	return q.q.CreateAthlete(ctx, sqlitesqlc.CreateAthleteParams(arg))
+}
+
+func (q sqliteQueries) CreateCourse(ctx context.Context, arg dbsqlc.CreateCourseParams) (sql.Result, error) {
+	return q.q.CreateCourse(ctx, sqlitesqlc.CreateCourseParams(arg))
+}
+
+func (q sqliteQueries) CreateMeet(ctx context.Context, arg dbsqlc.CreateMeetParams) (sql.Result, error) {
+	return q.q.CreateMeet(ctx, sqlitesqlc.CreateMeetParams(arg))
+}
+
+func (q sqliteQueries) CreatePersonalRecord(ctx context.Context, arg dbsqlc.CreatePersonalRecordParams) error {
+	return q.q.CreatePersonalRecord(ctx, sqlitesqlc.CreatePersonalRecordParams(arg))
+}
+
+func (q sqliteQueries) CreateRace(ctx context.Context, arg dbsqlc.CreateRaceParams) (sql.Result, error) {
+	return q.q.CreateRace(ctx, sqlitesqlc.CreateRaceParams(arg))
+}
+
+func (q sqliteQueries) CreateResult(ctx context.Context, arg dbsqlc.CreateResultParams) (sql.Result, error) {
+	return q.q.CreateResult(ctx, sqlitesqlc.CreateResultParams(arg))
+}
+
+func (q sqliteQueries) CreateResultSplit(ctx context.Context, arg dbsqlc.CreateResultSplitParams) error {
+	return q.q.CreateResultSplit(ctx, sqlitesqlc.CreateResultSplitParams(arg))
+}
+
+func (q sqliteQueries) CreateSeason(ctx context.Context, arg dbsqlc.CreateSeasonParams) (sql.Result, error) {
+	return q.q.CreateSeason(ctx, sqlitesqlc.CreateSeasonParams(arg))
+}
+
+func (q sqliteQueries) CreateSession(ctx context.Context, arg dbsqlc.CreateSessionParams) error {
+	return q.q.CreateSession(ctx, sqlitesqlc.CreateSessionParams(arg))
+}
+
+func (q sqliteQueries) CreateTeam(ctx context.Context, arg dbsqlc.CreateTeamParams) (sql.Result, error) {
+	return q.q.CreateTeam(ctx, sqlitesqlc.CreateTeamParams(arg))
+}
+
+func (q sqliteQueries) CreateUser(ctx context.Context, arg dbsqlc.CreateUserParams) (sql.Result, error) {
+	return q.q.CreateUser(ctx, sqlitesqlc.CreateUserParams(arg))
+}
+
+func (q sqliteQueries) DeleteAthlete(ctx context.Context, id int32) (int64, error) {
+	return q.q.DeleteAthlete(ctx, id)
+}
+
+func (q sqliteQueries) DeleteCourse(ctx context.Context, id int32) error {
+	return q.q.DeleteCourse(ctx, id)
+}
+
+func (q sqliteQueries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error {
+	return q.q.DeleteExpiredSessions(ctx, expiresAt)
+}
+
+func (q sqliteQueries) DeleteMeet(ctx context.Context, id int32) error {
+	return q.q.DeleteMeet(ctx, id)
+}
+
+func (q sqliteQueries) DeletePersonalRecords(ctx context.Context, athleteID int32) error {
+	return q.q.DeletePersonalRecords(ctx, athleteID)
+}
+
+func (q sqliteQueries) DeleteRace(ctx context.Context, id int32) error {
+	return q.q.DeleteRace(ctx, id)
+}
+
+func (q sqliteQueries) DeleteResult(ctx context.Context, id int32) error {
+	return q.q.DeleteResult(ctx, id)
+}
+
+func (q sqliteQueries) DeleteResultSplits(ctx context.Context, resultID int32) error {
+	return q.q.DeleteResultSplits(ctx, resultID)
+}
+
+func (q sqliteQueries) DeleteResultsByRace(ctx context.Context, raceID int32) error {
+	return q.q.DeleteResultsByRace(ctx, raceID)
+}
+
+func (q sqliteQueries) DeleteRosterEntry(ctx context.Context, arg dbsqlc.DeleteRosterEntryParams) (int64, error) {
+	return q.q.DeleteRosterEntry(ctx, sqlitesqlc.DeleteRosterEntryParams(arg))
+}
+
+func (q sqliteQueries) DeleteSession(ctx context.Context, tokenHash string) error {
+	return q.q.DeleteSession(ctx, tokenHash)
+}
+
+func (q sqliteQueries) DeleteTeam(ctx context.Context, id int32) error {
+	return q.q.DeleteTeam(ctx, id)
+}
+
+func (q sqliteQueries) DeleteUser(ctx context.Context, id int32) (int64, error) {
+	return q.q.DeleteUser(ctx, id)
+}
+
+func (q sqliteQueries) GetAthleteByID(ctx context.Context, id int32) (dbsqlc.GetAthleteByIDRow, error) {
+	row, err := q.q.GetAthleteByID(ctx, id)
+	return dbsqlc.GetAthleteByIDRow(row), err
+}
+
+func (q sqliteQueries) GetAthleteManualPR(ctx context.Context, id int32) (racetime.NullDuration, error) {
+	return q.q.GetAthleteManualPR(ctx, id)
+}
+
+func (q sqliteQueries) GetCourseByID(ctx context.Context, id int32) (dbsqlc.GetCourseByIDRow, error) {
+	row, err := q.q.GetCourseByID(ctx, id)
+	return dbsqlc.GetCourseByIDRow(row), err
+}
+
+func (q sqliteQueries) GetHomeTeam(ctx context.Context) (dbsqlc.GetHomeTeamRow, error) {
+	row, err := q.q.GetHomeTeam(ctx)
+	return dbsqlc.GetHomeTeamRow(row), err
+}
+
+func (q sqliteQueries) GetMeetByID(ctx context.Context, id int32) (dbsqlc.GetMeetByIDRow, error) {
+	row, err := q.q.GetMeetByID(ctx, id)
+	return dbsqlc.GetMeetByIDRow(row), err
+}
+
+func (q sqliteQueries) GetRaceByID(ctx context.Context, id int32) (dbsqlc.Race, error) {
+	row, err := q.q.GetRaceByID(ctx, id)
+	return dbsqlc.Race(row), err
+}
+
+func (q sqliteQueries) GetResultByID(ctx context.Context, id int32) (dbsqlc.GetResultByIDRow, error) {
+	row, err := q.q.GetResultByID(ctx, id)
+	return dbsqlc.GetResultByIDRow(row), err
+}
+
+func (q sqliteQueries) GetSeasonByYear(ctx context.Context, year int32) (dbsqlc.Season, error) {
+	row, err := q.q.GetSeasonByYear(ctx, year)
+	return dbsqlc.Season(row), err
+}
+
+func (q sqliteQueries) GetSessionUser(ctx context.Context, arg dbsqlc.GetSessionUserParams) (dbsqlc.GetSessionUserRow, error) {
+	row, err := q.q.GetSessionUser(ctx, sqlitesqlc.GetSessionUserParams(arg))
+	return dbsqlc.GetSessionUserRow(row), err
+}
+
+func (q sqliteQueries) GetTeamByID(ctx context.Context, id int32) (dbsqlc.GetTeamByIDRow, error) {
+	row, err := q.q.GetTeamByID(ctx, id)
+	return dbsqlc.GetTeamByIDRow(row), err
+}
+
+func (q sqliteQueries) GetUserByUsername(ctx context.Context, username string) (dbsqlc.GetUserByUsernameRow, error) {
+	row, err := q.q.GetUserByUsername(ctx, username)
+	return dbsqlc.GetUserByUsernameRow(row), err
+}
+
+func (q sqliteQueries) LinkUserAthlete(ctx context.Context, arg dbsqlc.LinkUserAthleteParams) error {
+	return q.q.LinkUserAthlete(ctx, sqlitesqlc.LinkUserAthleteParams(arg))
+}
+
+func (q sqliteQueries) ListAthleteHistory(ctx context.Context, arg dbsqlc.ListAthleteHistoryParams) ([]dbsqlc.ListAthleteHistoryRow, error) {
+	rows, err := q.q.ListAthleteHistory(ctx, sqlitesqlc.ListAthleteHistoryParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListAthleteHistoryRow) dbsqlc.ListAthleteHistoryRow {
+		return dbsqlc.ListAthleteHistoryRow(r)
+	})
+}
+
+func (q sqliteQueries) ListAthleteProgression(ctx context.Context, athleteID sql.NullInt32) ([]dbsqlc.ListAthleteProgressionRow, error) {
+	rows, err := q.q.ListAthleteProgression(ctx, athleteID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListAthleteProgressionRow) dbsqlc.ListAthleteProgressionRow {
+		return dbsqlc.ListAthleteProgressionRow(r)
+	})
+}
+
+func (q sqliteQueries) ListAthleteTeamsIn(ctx context.Context, ids []int32) ([]dbsqlc.ListAthleteTeamsInRow, error) {
+	rows, err := q.q.ListAthleteTeamsIn(ctx, ids)
+	return convertRows(rows, err, func(r sqlitesqlc.ListAthleteTeamsInRow) dbsqlc.ListAthleteTeamsInRow {
+		return dbsqlc.ListAthleteTeamsInRow(r)
+	})
+}
+
+func (q sqliteQueries) ListAthletes(ctx context.Context, arg dbsqlc.ListAthletesParams) ([]dbsqlc.ListAthletesRow, error) {
+	rows, err := q.q.ListAthletes(ctx, sqlitesqlc.ListAthletesParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListAthletesRow) dbsqlc.ListAthletesRow { return dbsqlc.ListAthletesRow(r) })
+}
+
+func (q sqliteQueries) ListAthletesAllSeasons(ctx context.Context, teamID int32) ([]dbsqlc.ListAthletesAllSeasonsRow, error) {
+	rows, err := q.q.ListAthletesAllSeasons(ctx, teamID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListAthletesAllSeasonsRow) dbsqlc.ListAthletesAllSeasonsRow {
+		return dbsqlc.ListAthletesAllSeasonsRow(r)
+	})
+}
+
+func (q sqliteQueries) ListCourseResults(ctx context.Context, arg dbsqlc.ListCourseResultsParams) ([]dbsqlc.ListCourseResultsRow, error) {
+	rows, err := q.q.ListCourseResults(ctx, arg.CourseID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListCourseResultsRow) dbsqlc.ListCourseResultsRow {
+		return dbsqlc.ListCourseResultsRow(r)
+	})
+}
+
+func (q sqliteQueries) ListCourses(ctx context.Context) ([]dbsqlc.ListCoursesRow, error) {
+	rows, err := q.q.ListCourses(ctx)
+	return convertRows(rows, err, func(r sqlitesqlc.ListCoursesRow) dbsqlc.ListCoursesRow { return dbsqlc.ListCoursesRow(r) })
+}
+
+func (q sqliteQueries) ListFastestAthletes(ctx context.Context) ([]dbsqlc.ListFastestAthletesRow, error) {
+	rows, err := q.q.ListFastestAthletes(ctx)
+	return convertRows(rows, err, func(r sqlitesqlc.ListFastestAthletesRow) dbsqlc.ListFastestAthletesRow {
+		return dbsqlc.ListFastestAthletesRow(r)
+	})
+}
+
+func (q sqliteQueries) ListFastestAthletesInSeason(ctx context.Context, seasonID int32) ([]dbsqlc.ListFastestAthletesInSeasonRow, error) {
+	rows, err := q.q.ListFastestAthletesInSeason(ctx, seasonID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListFastestAthletesInSeasonRow) dbsqlc.ListFastestAthletesInSeasonRow {
+		return dbsqlc.ListFastestAthletesInSeasonRow(r)
+	})
+}
+
+func (q sqliteQueries) ListFastestTimes(ctx context.Context, arg dbsqlc.ListFastestTimesParams) ([]dbsqlc.ListFastestTimesRow, error) {
+	rows, err := q.q.ListFastestTimes(ctx, sqlitesqlc.ListFastestTimesParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListFastestTimesRow) dbsqlc.ListFastestTimesRow {
+		return dbsqlc.ListFastestTimesRow(r)
+	})
+}
+
+func (q sqliteQueries) ListLatestMeetResults(ctx context.Context, arg dbsqlc.ListLatestMeetResultsParams) ([]dbsqlc.ListLatestMeetResultsRow, error) {
+	rows, err := q.q.ListLatestMeetResults(ctx, sqlitesqlc.ListLatestMeetResultsParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListLatestMeetResultsRow) dbsqlc.ListLatestMeetResultsRow {
+		return dbsqlc.ListLatestMeetResultsRow(r)
+	})
+}
+
+func (q sqliteQueries) ListMeetAthleteIDs(ctx context.Context, meetID int32) ([]sql.NullInt32, error) {
+	return q.q.ListMeetAthleteIDs(ctx, meetID)
+}
+
+func (q sqliteQueries) ListMeets(ctx context.Context, arg dbsqlc.ListMeetsParams) ([]dbsqlc.ListMeetsRow, error) {
+	rows, err := q.q.ListMeets(ctx, sqlitesqlc.ListMeetsParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListMeetsRow) dbsqlc.ListMeetsRow { return dbsqlc.ListMeetsRow(r) })
+}
+
+func (q sqliteQueries) ListPersonalRecords(ctx context.Context, athleteID int32) ([]dbsqlc.ListPersonalRecordsRow, error) {
+	rows, err := q.q.ListPersonalRecords(ctx, athleteID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListPersonalRecordsRow) dbsqlc.ListPersonalRecordsRow {
+		return dbsqlc.ListPersonalRecordsRow{
+			DistanceM:  r.DistanceM,
+			CourseID:   r.CourseID,
+			CourseName: sql.NullString{String: r.CourseName, Valid: r.CourseID.Valid},
+			TimeMs:     r.TimeMs,
+			ResultID:   r.ResultID,
+			MeetID:     r.MeetID,
+			MeetName:   r.MeetName,
+			Date:       r.Date,
+		}
+	})
+}
+
+func (q sqliteQueries) ListRaceAthleteIDs(ctx context.Context, raceID int32) ([]sql.NullInt32, error) {
+	return q.q.ListRaceAthleteIDs(ctx, raceID)
+}
+
+func (q sqliteQueries) ListRaceFinishers(ctx context.Context, raceID int32) ([]dbsqlc.ListRaceFinishersRow, error) {
+	rows, err := q.q.ListRaceFinishers(ctx, raceID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListRaceFinishersRow) dbsqlc.ListRaceFinishersRow {
+		return dbsqlc.ListRaceFinishersRow(r)
+	})
+}
+
+func (q sqliteQueries) ListRacesByMeet(ctx context.Context, meetID int32) ([]dbsqlc.Race, error) {
+	rows, err := q.q.ListRacesByMeet(ctx, meetID)
+	return convertRows(rows, err, func(r sqlitesqlc.Race) dbsqlc.Race { return dbsqlc.Race(r) })
+}
+
+func (q sqliteQueries) ListResultsBetween(ctx context.Context, arg dbsqlc.ListResultsBetweenParams) ([]dbsqlc.ListResultsBetweenRow, error) {
+	rows, err := q.q.ListResultsBetween(ctx, sqlitesqlc.ListResultsBetweenParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListResultsBetweenRow) dbsqlc.ListResultsBetweenRow {
+		return dbsqlc.ListResultsBetweenRow(r)
+	})
+}
+
+func (q sqliteQueries) ListResultsByMeet(ctx context.Context, meetID int32) ([]dbsqlc.ListResultsByMeetRow, error) {
+	rows, err := q.q.ListResultsByMeet(ctx, meetID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListResultsByMeetRow) dbsqlc.ListResultsByMeetRow {
+		return dbsqlc.ListResultsByMeetRow(r)
+	})
+}
+
+func (q sqliteQueries) ListResultsByMeetAndTeam(ctx context.Context, arg dbsqlc.ListResultsByMeetAndTeamParams) ([]dbsqlc.ListResultsByMeetAndTeamRow, error) {
+	rows, err := q.q.ListResultsByMeetAndTeam(ctx, sqlitesqlc.ListResultsByMeetAndTeamParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListResultsByMeetAndTeamRow) dbsqlc.ListResultsByMeetAndTeamRow {
+		return dbsqlc.ListResultsByMeetAndTeamRow(r)
+	})
+}
+
+func (q sqliteQueries) ListResultsByRace(ctx context.Context, raceID int32) ([]dbsqlc.ListResultsByRaceRow, error) {
+	rows, err := q.q.ListResultsByRace(ctx, raceID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListResultsByRaceRow) dbsqlc.ListResultsByRaceRow {
+		return dbsqlc.ListResultsByRaceRow(r)
+	})
+}
+
+func (q sqliteQueries) ListResultsByRaceAndTeam(ctx context.Context, arg dbsqlc.ListResultsByRaceAndTeamParams) ([]dbsqlc.ListResultsByRaceAndTeamRow, error) {
+	rows, err := q.q.ListResultsByRaceAndTeam(ctx, sqlitesqlc.ListResultsByRaceAndTeamParams(arg))
+	return convertRows(rows, err, func(r sqlitesqlc.ListResultsByRaceAndTeamRow) dbsqlc.ListResultsByRaceAndTeamRow {
+		return dbsqlc.ListResultsByRaceAndTeamRow(r)
+	})
+}
+
+func (q sqliteQueries) ListRoster(ctx context.Context, seasonID int32) ([]dbsqlc.ListRosterRow, error) {
+	rows, err := q.q.ListRoster(ctx, seasonID)
+	return convertRows(rows, err, func(r sqlitesqlc.ListRosterRow) dbsqlc.ListRosterRow { return dbsqlc.ListRosterRow(r) })
+}
+
+func (q sqliteQueries) ListSeasons(ctx context.Context) ([]dbsqlc.Season, error) {
+	rows, err := q.q.ListSeasons(ctx)
+	return convertRows(rows, err, func(r sqlitesqlc.Season) dbsqlc.Season { return dbsqlc.Season(r) })
+}
+
+func (q sqliteQueries) ListSplitsByAthlete(ctx context.Context, athleteID sql.NullInt32) ([]dbsqlc.ResultSplit, error) {
+	rows, err := q.q.ListSplitsByAthlete(ctx, athleteID)
+	return convertRows(rows, err, func(r sqlitesqlc.ResultSplit) dbsqlc.ResultSplit { return dbsqlc.ResultSplit(r) })
+}
+
+func (q sqliteQueries) ListSplitsByMeet(ctx context.Context, meetID int32) ([]dbsqlc.ResultSplit, error) {
+	rows, err := q.q.ListSplitsByMeet(ctx, meetID)
+	return convertRows(rows, err, func(r sqlitesqlc.ResultSplit) dbsqlc.ResultSplit { return dbsqlc.ResultSplit(r) })
+}
+
+func (q sqliteQueries) ListSplitsByRace(ctx context.Context, raceID int32) ([]dbsqlc.ResultSplit, error) {
+	rows, err := q.q.ListSplitsByRace(ctx, raceID)
+	return convertRows(rows, err, func(r sqlitesqlc.ResultSplit) dbsqlc.ResultSplit { return dbsqlc.ResultSplit(r) })
+}
+
+func (q sqliteQueries) ListSplitsByResult(ctx context.Context, resultID int32) ([]dbsqlc.ResultSplit, error) {
+	rows, err := q.q.ListSplitsByResult(ctx, resultID)
+	return convertRows(rows, err, func(r sqlitesqlc.ResultSplit) dbsqlc.ResultSplit { return dbsqlc.ResultSplit(r) })
+}
+
+func (q sqliteQueries) ListTeamIDsIn(ctx context.Context, ids []int32) ([]int32, error) {
+	return q.q.ListTeamIDsIn(ctx, ids)
+}
+
+func (q sqliteQueries) ListTeams(ctx context.Context) ([]dbsqlc.ListTeamsRow, error) {
+	rows, err := q.q.ListTeams(ctx)
+	return convertRows(rows, err, func(r sqlitesqlc.ListTeamsRow) dbsqlc.ListTeamsRow { return dbsqlc.ListTeamsRow(r) })
+}
+
+func (q sqliteQueries) ListUserAthleteIDs(ctx context.Context, userID int32) ([]int32, error) {
+	return q.q.ListUserAthleteIDs(ctx, userID)
+}
+
+func (q sqliteQueries) ListUsers(ctx context.Context) ([]dbsqlc.ListUsersRow, error) {
+	rows, err := q.q.ListUsers(ctx)
+	return convertRows(rows, err, func(r sqlitesqlc.ListUsersRow) dbsqlc.ListUsersRow { return dbsqlc.ListUsersRow(r) })
+}
+
+func (q sqliteQueries) SetAthletePR(ctx context.Context, arg dbsqlc.SetAthletePRParams) error {
+	return q.q.SetAthletePR(ctx, sqlitesqlc.SetAthletePRParams(arg))
+}
+
+func (q sqliteQueries) SetResultPRFlags(ctx context.Context, arg dbsqlc.SetResultPRFlagsParams) error {
+	return q.q.SetResultPRFlags(ctx, sqlitesqlc.SetResultPRFlagsParams(arg))
+}
+
+func (q sqliteQueries) UpdateAthlete(ctx context.Context, arg dbsqlc.UpdateAthleteParams) error {
+	return q.q.UpdateAthlete(ctx, sqlitesqlc.UpdateAthleteParams(arg))
+}
+
+func (q sqliteQueries) UpdateCourse(ctx context.Context, arg dbsqlc.UpdateCourseParams) error {
+	return q.q.UpdateCourse(ctx, sqlitesqlc.UpdateCourseParams(arg))
+}
+
+func (q sqliteQueries) UpdateMeet(ctx context.Context, arg dbsqlc.UpdateMeetParams) error {
+	return q.q.UpdateMeet(ctx, sqlitesqlc.UpdateMeetParams(arg))
+}
+
+func (q sqliteQueries) UpdateRace(ctx context.Context, arg dbsqlc.UpdateRaceParams) error {
+	return q.q.UpdateRace(ctx, sqlitesqlc.UpdateRaceParams(arg))
+}
+
+func (q sqliteQueries) UpdateResult(ctx context.Context, arg dbsqlc.UpdateResultParams) error {
+	return q.q.UpdateResult(ctx, sqlitesqlc.UpdateResultParams(arg))
+}
+
+func (q sqliteQueries) UpdateRosterGrade(ctx context.Context, arg dbsqlc.UpdateRosterGradeParams) error {
+	return q.q.UpdateRosterGrade(ctx, sqlitesqlc.UpdateRosterGradeParams(arg))
+}
+
+func (q sqliteQueries) UpdateSeason(ctx context.Context, arg dbsqlc.UpdateSeasonParams) error {
+	return q.q.UpdateSeason(ctx, sqlitesqlc.UpdateSeasonParams(arg))
+}
+
+func (q sqliteQueries) UpdateTeam(ctx context.Context, arg dbsqlc.UpdateTeamParams) error {
+	return q.q.UpdateTeam(ctx, sqlitesqlc.UpdateTeamParams(arg))
+}
+
+func (q sqliteQueries) UpsertRosterEntry(ctx context.Context, arg dbsqlc.UpsertRosterEntryParams) error {
+	return q.q.UpsertRosterEntry(ctx, sqlitesqlc.UpsertRosterEntryParams(arg))
+}
@@ -0,0 +1,234 @@
+// Package store is where the API keeps its data: the teams, athletes,
+// meets and results below, and the interfaces that read and write them.
+// Store is implemented three times: by MySQL and by SQLite, each through
+// its own sqlc-generated queries, and in process memory for tests and demos
+// that should not need a database at all. Open picks one from a database
+// URL.
+//
+// Every implementation answers the queries in db/query.sql, and
+// sqlcQueries turns their rows into this package's types, so the generated
+// code stays behind this package and the stores cannot drift apart.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"jones-county-xc/backend/racetime"
+)
+
+// Teams is our own school and the opponents whose runners finish in our
+// races.
+type Teams interface {
+	// Teams lists the home team first, then the rest by name.
+	Teams(ctx context.Context) ([]Team, error)
+	Team(ctx context.Context, id int32) (Team, error)
+	HomeTeam(ctx context.Context) (Team, error)
+	CreateTeam(ctx context.Context, team Team) (int32, error)
+	// UpdateTeam renames a team; IsHome cannot change.
+	UpdateTeam(ctx context.Context, team Team) error
+	DeleteTeam(ctx context.Context, id int32) error
+	// TeamInUse reports whether any athlete or result names the team.
+	TeamInUse(ctx context.Context, id int32) (bool, error)
+	// KnownTeamIDs returns those of ids that are teams.
+	KnownTeamIDs(ctx context.Context, ids []int32) ([]int32, error)
+}
+
+// Athletes is the runners we keep records for.
+type Athletes interface {
+	// TeamRoster lists a team's athletes on a season's roster, with the
+	// grade each is in that season.
+	TeamRoster(ctx context.Context, teamID, seasonID int32) ([]Athlete, error)
+	// TeamAthletes lists everyone who has ever run for a team.
+	TeamAthletes(ctx context.Context, teamID int32) ([]Athlete, error)
+	Athlete(ctx context.Context, id int32) (Athlete, error)
+	CreateAthlete(ctx context.Context, athlete Athlete) (int32, error)
+	UpdateAthlete(ctx context.Context, athlete Athlete) error
+	// DeleteAthlete reports whether there was an athlete to delete.
+	DeleteAthlete(ctx context.Context, id int32) (bool, error)
+	// AthleteTeams maps each of ids that is an athlete to their team.
+	AthleteTeams(ctx context.Context, ids []int32) (map[int32]int32, error)
+	AthleteManualPR(ctx context.Context, id int32) (racetime.NullDuration, error)
+	SetAthletePR(ctx context.Context, id int32, pr racetime.NullDuration) error
+	// FastestAthletes lists the five best 5K PRs ever, and
+	// FastestAthletesInSeason those on a season's roster.
+	FastestAthletes(ctx context.Context) ([]Athlete, error)
+	FastestAthletesInSeason(ctx context.Context, seasonID int32) ([]Athlete, error)
+	// AthleteRaces lists every finish of an athlete's, oldest first, and
+	// AthleteRacesBetween those at meets between two dates.
+	AthleteRaces(ctx context.Context, athleteID int32) ([]AthleteRace, error)
+	AthleteRacesBetween(ctx context.Context, athleteID int32, start, end time.Time) ([]AthleteRace, error)
+	CountAthletes(ctx context.Context) (int64, error)
+}
+
+// Seasons is the school years and who was on the roster in each.
+type Seasons interface {
+	// Seasons lists the newest first.
+	Seasons(ctx context.Context) ([]Season, error)
+	Season(ctx context.Context, year int32) (Season, error)
+	CreateSeason(ctx context.Context, season Season) (int32, error)
+	RenameSeason(ctx context.Context, id int32, name string) error
+	Roster(ctx context.Context, seasonID int32) ([]RosterEntry, error)
+	// PutOnRoster adds an athlete to a roster, or moves them to the
+	// entry's grade when they are already on it.
+	PutOnRoster(ctx context.Context, entry RosterEntry) error
+	// SetRosterGrade changes an athlete's grade on a roster, and does
+	// nothing when they are not on it.
+	SetRosterGrade(ctx context.Context, entry RosterEntry) error
+	// TakeOffRoster reports whether the athlete was on the roster.
+	TakeOffRoster(ctx context.Context, seasonID, athleteID int32) (bool, error)
+}
+
+// Courses is the venues meets are run on.
+type Courses interface {
+	Courses(ctx context.Context) ([]Course, error)
+	Course(ctx context.Context, id int32) (Course, error)
+	CreateCourse(ctx context.Context, course Course) (int32, error)
+	UpdateCourse(ctx context.Context, course Course) error
+	DeleteCourse(ctx context.Context, id int32) error
+	// CourseInUse reports whether any meet or race is run on the course.
+	CourseInUse(ctx context.Context, id int32) (bool, error)
+	// CourseResults lists every finish on a course, fastest first within
+	// each gender and distance.
+	CourseResults(ctx context.Context, courseID int32) ([]CourseResult, error)
+}
+
+// Meets is the calendar.
+type Meets interface {
+	// Meets lists the meets between two dates in date order.
+	Meets(ctx context.Context, start, end time.Time) ([]Meet, error)
+	Meet(ctx context.Context, id int32) (Meet, error)
+	CreateMeet(ctx context.Context, meet Meet) (int32, error)
+	UpdateMeet(ctx context.Context, meet Meet) error
+	DeleteMeet(ctx context.Context, id int32) error
+	MeetHasResults(ctx context.Context, id int32) (bool, error)
+	// MeetAthleteIDs lists the athletes with a finish at the meet.
+	MeetAthleteIDs(ctx context.Context, id int32) ([]int32, error)
+}
+
+// Races is the gender and division races run at each meet.
+type Races interface {
+	// MeetRaces lists a meet's races by start time, unscheduled ones last.
+	MeetRaces(ctx context.Context, meetID int32) ([]Race, error)
+	Race(ctx context.Context, id int32) (Race, error)
+	CreateRace(ctx context.Context, race Race) (int32, error)
+	UpdateRace(ctx context.Context, race Race) error
+	DeleteRace(ctx context.Context, id int32) error
+	RaceHasResults(ctx context.Context, id int32) (bool, error)
+	// RaceAthleteIDs lists the athletes with a finish in the race.
+	RaceAthleteIDs(ctx context.Context, id int32) ([]int32, error)
+	// RaceFinishers lists a race's finish list in place order.
+	RaceFinishers(ctx context.Context, id int32) ([]Finisher, error)
+}
+
+// Results is every finish, ours and opponents', with its splits. The lists
+// are in place order within each race.
+type Results interface {
+	Result(ctx context.Context, id int32) (Result, error)
+	CreateResult(ctx context.Context, result Result) (int32, error)
+	UpdateResult(ctx context.Context, result Result) error
+	DeleteResult(ctx context.Context, id int32) error
+	DeleteRaceResults(ctx context.Context, raceID int32) error
+	ResultsBetween(ctx context.Context, start, end time.Time) ([]Result, error)
+	MeetResults(ctx context.Context, meetID int32) ([]Result, error)
+	MeetTeamResults(ctx context.Context, meetID, teamID int32) ([]Result, error)
+	RaceResults(ctx context.Context, raceID int32) ([]Result, error)
+	RaceTeamResults(ctx context.Context, raceID, teamID int32) ([]Result, error)
+	// FastestTimes lists our athletes' ten fastest times at a distance at
+	// meets between two dates.
+	FastestTimes(ctx context.Context, start, end time.Time, distanceM int32) ([]Finish, error)
+	// LatestMeetResults lists our athletes' finishes at the most recent
+	// meet between two dates.
+	LatestMeetResults(ctx context.Context, start, end time.Time) ([]Finish, error)
+	// SetSplits replaces a result's splits, numbering them from 1 in the
+	// order given. Run it in a transaction.
+	SetSplits(ctx context.Context, resultID int32, splits []Split) error
+	ResultSplits(ctx context.Context, resultID int32) ([]Split, error)
+	MeetSplits(ctx context.Context, meetID int32) ([]Split, error)
+	RaceSplits(ctx context.Context, raceID int32) ([]Split, error)
+	AthleteSplits(ctx context.Context, athleteID int32) ([]Split, error)
+	CountResultsSince(ctx context.Context, since time.Time) (int64, error)
+}
+
+// Records is the personal records derived from each athlete's results.
+type Records interface {
+	// SetPersonalRecords replaces an athlete's records, and SetPRFlags
+	// flags the results that set them, clearing the rest. Run them in a
+	// transaction.
+	SetPersonalRecords(ctx context.Context, athleteID int32, records []PersonalRecord) error
+	SetPRFlags(ctx context.Context, athleteID int32, flags []PRFlags) error
+	PersonalRecords(ctx context.Context, athleteID int32) ([]PersonalRecord, error)
+}
+
+// Users is the logins, the athletes each may view, and their sessions.
+type Users interface {
+	CreateUser(ctx context.Context, user User) (int32, error)
+	UserByUsername(ctx context.Context, username string) (User, error)
+	// Users lists every login by username.
+	Users(ctx context.Context) ([]User, error)
+	// DeleteUser reports whether there was a user to delete.
+	DeleteUser(ctx context.Context, id int32) (bool, error)
+	LinkAthlete(ctx context.Context, userID, athleteID int32) error
+	LinkedAthleteIDs(ctx context.Context, userID int32) ([]int32, error)
+	CreateSession(ctx context.Context, tokenHash string, userID int32, expires time.Time) error
+	// SessionUser finds whose session a token is, if it has not expired
+	// by now.
+	SessionUser(ctx context.Context, tokenHash string, now time.Time) (User, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+}
+
+// Queries is every read and write, inside a transaction or out.
+type Queries interface {
+	Teams
+	Athletes
+	Seasons
+	Courses
+	Meets
+	Races
+	Results
+	Records
+	Users
+}
+
+// Store is a Queries that can also run several writes as one transaction.
+type Store interface {
+	Queries
+	BeginTx(ctx context.Context) (Tx, error)
+}
+
+// Tx is the queries run inside one transaction. Rollback after Commit is a
+// no-op, so callers defer it straight after BeginTx.
+type Tx interface {
+	Queries
+	Commit() error
+	Rollback() error
+}
+
+// Constraint violations, as reported by Violates. Reads that find no row
+// return sql.ErrNoRows from every store.
+var (
+	ErrDuplicate       = errors.New("store: duplicate entry")
+	ErrReferenced      = errors.New("store: row is still referenced")
+	ErrNoReferencedRow = errors.New("store: referenced row does not exist")
+)
+
+// MySQL server error numbers for each constraint violation.
+var mysqlErrNumbers = map[error]uint16{
+	ErrDuplicate:       1062, // ER_DUP_ENTRY: unique key violated
+	ErrReferenced:      1451, // ER_ROW_IS_REFERENCED_2: DELETE blocked by a foreign key
+	ErrNoReferencedRow: 1452, // ER_NO_REFERENCED_ROW_2: foreign key points nowhere
+}
+
+// Violates reports whether err is a write rejected for breaking constraint,
+// one of ErrDuplicate, ErrReferenced or ErrNoReferencedRow, whichever store
+// it came from.
+func Violates(err, constraint error) bool {
+	if errors.Is(err, constraint) {
+		return true
+	}
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrNumbers[constraint]
+}
@@ -0,0 +1,572 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"jones-county-xc/backend/racetime"
+	"jones-county-xc/backend/store"
+	"jones-county-xc/backend/store/storetest"
+)
+
+// testStore runs the behaviour every Store must share against a fresh store
+// from newStore for each test.
+func testStore(t *testing.T, newStore func(t *testing.T) store.Store) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s store.Store)
+	}{
+		{"HomeTeam", testHomeTeam},
+		{"UniqueKeys", testUniqueKeys},
+		{"ForeignKeys", testForeignKeys},
+		{"DeleteReferenced", testDeleteReferenced},
+		{"DeleteCascades", testDeleteCascades},
+		{"Ordering", testOrdering},
+		{"Joins", testJoins},
+		{"Counts", testCounts},
+		{"Observe", testObserve},
+		{"Sessions", testSessions},
+		{"Transactions", testTransactions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newStore(t))
+		})
+	}
+}
+
+func TestStores(t *testing.T) {
+	storetest.Run(t, testStore)
+}
+
+func TestViolates(t *testing.T) {
+	tests := []struct {
+		err, constraint error
+		want            bool
+	}{
+		{store.ErrDuplicate, store.ErrDuplicate, true},
+		{fmt.Errorf("create team: %w", store.ErrReferenced), store.ErrReferenced, true},
+		{store.ErrDuplicate, store.ErrReferenced, false},
+		{&mysql.MySQLError{Number: 1062}, store.ErrDuplicate, true},
+		{&mysql.MySQLError{Number: 1451}, store.ErrReferenced, true},
+		{&mysql.MySQLError{Number: 1452}, store.ErrNoReferencedRow, true},
+		{&mysql.MySQLError{Number: 1452}, store.ErrDuplicate, false},
+		{sql.ErrNoRows, store.ErrDuplicate, false},
+		{nil, store.ErrDuplicate, false},
+	}
+	for _, tt := range tests {
+		if got := store.Violates(tt.err, tt.constraint); got != tt.want {
+			t.Errorf("Violates(%v, %v) = %v, want %v", tt.err, tt.constraint, got, tt.want)
+		}
+	}
+}
+
+// fixture is one meet with a race, an athlete on the home team and an
+// opponent team.
+type fixture struct {
+	home, opponent, athlete, course, meet, race int32
+}
+
+func newFixture(t *testing.T, s store.Store) fixture {
+	t.Helper()
+	ctx := context.Background()
+	home, err := s.HomeTeam(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := fixture{home: home.ID}
+	f.opponent = insertID(t)(s.CreateTeam(ctx, store.Team{Name: "Rival High"}))
+	f.athlete = insertID(t)(s.CreateAthlete(ctx, store.Athlete{TeamID: f.home, Name: "Sam Runner", GraduationYear: 2027}))
+	f.course = insertID(t)(s.CreateCourse(ctx, store.Course{Name: "City Park", DistanceM: 5000}))
+	f.meet = insertID(t)(s.CreateMeet(ctx, store.Meet{Name: "Invitational", Date: day("2025-09-13"), CourseID: valid(f.course)}))
+	f.race = insertID(t)(s.CreateRace(ctx, store.Race{MeetID: f.meet, Gender: store.GenderGirls, Division: store.DivisionVarsity, DistanceM: 5000}))
+	return f
+}
+
+// insertID returns the id a create assigned, failing the test on error.
+func insertID(t *testing.T) func(int32, error) int32 {
+	return func(id int32, err error) int32 {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+}
+
+func (f fixture) finish(t *testing.T, s store.Queries, athleteID int32, place int32, ms int64) int32 {
+	t.Helper()
+	result := store.Result{TeamID: f.home, RaceID: f.race, Time: racetime.Duration(ms), Place: place}
+	if athleteID != 0 {
+		result.AthleteID = valid(athleteID)
+	} else {
+		result.TeamID, result.RunnerName = f.opponent, fmt.Sprintf("Rival %d", place)
+	}
+	return insertID(t)(s.CreateResult(context.Background(), result))
+}
+
+func valid(id int32) sql.NullInt32 { return sql.NullInt32{Int32: id, Valid: true} }
+
+func day(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func wantViolation(t *testing.T, what string, err, constraint error) {
+	t.Helper()
+	if !store.Violates(err, constraint) {
+		t.Errorf("%s: err = %v, want %v", what, err, constraint)
+	}
+}
+
+func testHomeTeam(t *testing.T, s store.Store) {
+	home, err := s.HomeTeam(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if home.Name != "Jones County" || home.ShortName != "JC" || !home.IsHome {
+		t.Errorf("home team = %+v", home)
+	}
+	if _, err := s.Team(context.Background(), home.ID+100); err != sql.ErrNoRows {
+		t.Errorf("missing team: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func testUniqueKeys(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	f := newFixture(t, s)
+
+	_, err := s.CreateTeam(ctx, store.Team{Name: "rival high"})
+	wantViolation(t, "team name in another case", err, store.ErrDuplicate)
+	_, err = s.CreateCourse(ctx, store.Course{Name: "City Park", DistanceM: 4000})
+	wantViolation(t, "course name", err, store.ErrDuplicate)
+	_, err = s.CreateRace(ctx, store.Race{MeetID: f.meet, Gender: store.GenderGirls, Division: store.DivisionVarsity, DistanceM: 5000})
+	wantViolation(t, "second girls varsity race", err, store.ErrDuplicate)
+
+	f.finish(t, s, f.athlete, 1, 1110000)
+	_, err = s.CreateResult(ctx, store.Result{AthleteID: valid(f.athlete), TeamID: f.home, RaceID: f.race, Time: 1120000, Place: 2})
+	wantViolation(t, "athlete twice in a race", err, store.ErrDuplicate)
+	_, err = s.CreateResult(ctx, store.Result{TeamID: f.opponent, RunnerName: "Tie", RaceID: f.race, Time: 1110000, Place: 1})
+	wantViolation(t, "two runners in one place", err, store.ErrDuplicate)
+	// Opponents have no athlete id, and any number of them may run
+	f.finish(t, s, 0, 2, 1120000)
+	f.finish(t, s, 0, 3, 1130000)
+
+	_, err = s.CreateSeason(ctx, store.Season{Year: 2025, Name: "2025"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CreateSeason(ctx, store.Season{Year: 2025, Name: "again"})
+	wantViolation(t, "season year", err, store.ErrDuplicate)
+
+	_, err = s.CreateUser(ctx, store.User{Username: "coach", Role: "coach"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CreateUser(ctx, store.User{Username: "Coach", Role: "coach"})
+	wantViolation(t, "username", err, store.ErrDuplicate)
+	if u, err := s.UserByUsername(ctx, "COACH"); err != nil || u.Username != "coach" {
+		t.Errorf("UserByUsername(COACH) = %+v, %v", u, err)
+	}
+
+	err = s.UpdateTeam(ctx, store.Team{ID: f.opponent, Name: "Jones County"})
+	wantViolation(t, "renaming a team to a taken name", err, store.ErrDuplicate)
+	if err := s.UpdateTeam(ctx, store.Team{ID: f.opponent, Name: "Rival High", ShortName: "RH"}); err != nil {
+		t.Errorf("keeping a team's own name: %v", err)
+	}
+}
+
+func testForeignKeys(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	f := newFixture(t, s)
+	const missing = 999
+
+	_, err := s.CreateAthlete(ctx, store.Athlete{TeamID: missing, Name: "Nobody", GraduationYear: 2027})
+	wantViolation(t, "athlete on a missing team", err, store.ErrNoReferencedRow)
+	_, err = s.CreateMeet(ctx, store.Meet{Name: "Nowhere", Date: day("2025-09-20"), CourseID: valid(missing)})
+	wantViolation(t, "meet on a missing course", err, store.ErrNoReferencedRow)
+	_, err = s.CreateRace(ctx, store.Race{MeetID: missing, Gender: store.GenderBoys, Division: store.DivisionJV, DistanceM: 5000})
+	wantViolation(t, "race at a missing meet", err, store.ErrNoReferencedRow)
+	_, err = s.CreateResult(ctx, store.Result{AthleteID: valid(missing), TeamID: f.home, RaceID: f.race, Time: 1, Place: 1})
+	wantViolation(t, "result for a missing athlete", err, store.ErrNoReferencedRow)
+	err = s.SetSplits(ctx, missing, []store.Split{{DistanceM: 1609, Elapsed: 1}})
+	wantViolation(t, "split of a missing result", err, store.ErrNoReferencedRow)
+	user := insertID(t)(s.CreateUser(ctx, store.User{Username: "mom", Role: "parent"}))
+	err = s.LinkAthlete(ctx, user, missing)
+	wantViolation(t, "link to a missing athlete", err, store.ErrNoReferencedRow)
+
+	// A meet with no course is fine; NULL references nothing
+	if _, err := s.CreateMeet(ctx, store.Meet{Name: "Time Trial", Date: day("2025-08-30")}); err != nil {
+		t.Errorf("meet with no course: %v", err)
+	}
+}
+
+func testDeleteReferenced(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	f := newFixture(t, s)
+	result := f.finish(t, s, f.athlete, 1, 1110000)
+
+	wantViolation(t, "delete a team with athletes", s.DeleteTeam(ctx, f.home), store.ErrReferenced)
+	wantViolation(t, "delete a course with meets", s.DeleteCourse(ctx, f.course), store.ErrReferenced)
+	_, err := s.DeleteAthlete(ctx, f.athlete)
+	wantViolation(t, "delete an athlete with results", err, store.ErrReferenced)
+	wantViolation(t, "delete a race with results", s.DeleteRace(ctx, f.race), store.ErrReferenced)
+	wantViolation(t, "delete a meet whose race has results", s.DeleteMeet(ctx, f.meet), store.ErrReferenced)
+
+	if used, err := s.TeamInUse(ctx, f.home); err != nil || !used {
+		t.Errorf("TeamInUse(home) = %v, %v; want true", used, err)
+	}
+	if used, err := s.CourseInUse(ctx, f.course); err != nil || !used {
+		t.Errorf("CourseInUse = %v, %v; want true", used, err)
+	}
+	if has, err := s.MeetHasResults(ctx, f.meet); err != nil || !has {
+		t.Errorf("MeetHasResults = %v, %v; want true", has, err)
+	}
+
+	if err := s.DeleteResult(ctx, result); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteMeet(ctx, f.meet); err != nil {
+		t.Fatalf("delete a meet with no results: %v", err)
+	}
+	if _, err := s.Race(ctx, f.race); err != sql.ErrNoRows {
+		t.Errorf("race outlived its meet: err = %v", err)
+	}
+	if used, err := s.TeamInUse(ctx, f.opponent); err != nil || used {
+		t.Errorf("TeamInUse(opponent) = %v, %v; want false", used, err)
+	}
+	if err := s.DeleteTeam(ctx, f.opponent); err != nil {
+		t.Errorf("delete an unused team: %v", err)
+	}
+}
+
+func testDeleteCascades(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	f := newFixture(t, s)
+	result := f.finish(t, s, f.athlete, 1, 1110000)
+	if err := s.SetSplits(ctx, result, []store.Split{{DistanceM: 1609, Elapsed: 350000}, {DistanceM: 3218, Elapsed: 710000}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetPersonalRecords(ctx, f.athlete, []store.PersonalRecord{{DistanceM: 5000, ResultID: result, Time: 1110000}}); err != nil {
+		t.Fatal(err)
+	}
+	user := insertID(t)(s.CreateUser(ctx, store.User{Username: "mom", Role: "parent"}))
+	if err := s.LinkAthlete(ctx, user, f.athlete); err != nil {
+		t.Fatal(err)
+	}
+
+	splits, err := s.ResultSplits(ctx, result)
+	if err != nil || len(splits) != 2 || splits[1].Index != 2 || splits[1].Elapsed != 710000 {
+		t.Fatalf("ResultSplits = %+v, %v; want 2 splits, numbered from 1", splits, err)
+	}
+	if records, err := s.PersonalRecords(ctx, f.athlete); err != nil || len(records) != 1 || records[0].MeetName != "Invitational" {
+		t.Fatalf("PersonalRecords = %+v, %v; want the 5K at the Invitational", records, err)
+	}
+	if err := s.DeleteRaceResults(ctx, f.race); err != nil {
+		t.Fatal(err)
+	}
+	if splits, _ := s.RaceSplits(ctx, f.race); len(splits) != 0 {
+		t.Errorf("splits outlived their result: %v", splits)
+	}
+	if records, _ := s.PersonalRecords(ctx, f.athlete); len(records) != 0 {
+		t.Errorf("records outlived their result: %v", records)
+	}
+
+	if found, err := s.DeleteAthlete(ctx, f.athlete); err != nil || !found {
+		t.Fatalf("DeleteAthlete = %v, %v; want found", found, err)
+	}
+	if ids, _ := s.LinkedAthleteIDs(ctx, user); len(ids) != 0 {
+		t.Errorf("user still linked to deleted athlete: %v", ids)
+	}
+	if found, err := s.DeleteAthlete(ctx, f.athlete); err != nil || found {
+		t.Errorf("DeleteAthlete again = %v, %v; want not found", found, err)
+	}
+}
+
+func testOrdering(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	f := newFixture(t, s)
+
+	// Scheduled races by start time, then unscheduled ones
+	late := insertID(t)(s.CreateRace(ctx, store.Race{MeetID: f.meet, Gender: store.GenderBoys, Division: store.DivisionVarsity, DistanceM: 5000, StartTime: "10:15:00"}))
+	early := insertID(t)(s.CreateRace(ctx, store.Race{MeetID: f.meet, Gender: store.GenderGirls, Division: store.DivisionJV, DistanceM: 4000, StartTime: "09:00:00"}))
+	races, err := s.MeetRaces(ctx, f.meet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int32
+	for _, r := range races {
+		ids = append(ids, r.ID)
+	}
+	if want := []int32{early, late, f.race}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("race order = %v, want %v", ids, want)
+	}
+
+	// Finish lists by place, whatever order they were entered in
+	f.finish(t, s, 0, 3, 1130000)
+	f.finish(t, s, f.athlete, 1, 1110000)
+	f.finish(t, s, 0, 2, 1120000)
+	finishers, err := s.RaceFinishers(ctx, f.race)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range finishers {
+		names = append(names, r.RunnerName+"/"+r.TeamName)
+	}
+	if want := "[Sam Runner/Jones County Rival 2/Rival High Rival 3/Rival High]"; fmt.Sprint(names) != want {
+		t.Errorf("finishers = %v, want %v", names, want)
+	}
+
+	// Teams: home first, then by name
+	if _, err := s.CreateTeam(ctx, store.Team{Name: "Abbott Academy"}); err != nil {
+		t.Fatal(err)
+	}
+	teams, err := s.Teams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names = nil
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	if want := "[Jones County Abbott Academy Rival High]"; fmt.Sprint(names) != want {
+		t.Errorf("teams = %v, want %v", names, want)
+	}
+}
+
+// testObserve checks that an observed store reports its queries by name,
+// those in transactions included.
+func testObserve(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	var names []string
+	s = store.Observe(s, func(name string, elapsed time.Duration, err error) {
+		if elapsed < 0 || err != nil {
+			t.Errorf("%s took %v and failed with %v", name, elapsed, err)
+		}
+		names = append(names, name)
+	})
+	if _, err := s.Teams(ctx); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := s.BeginTx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.HomeTeam(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"ListTeams", "GetHomeTeam"}
+	if _, ok := s.(*store.Memory); ok {
+		want = nil
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("observed %q, want %q", names, want)
+	}
+}
+
+// testCounts checks the counts behind the server's metrics.
+func testCounts(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	before := time.Now().Add(-time.Minute)
+	f := newFixture(t, s)
+	f.finish(t, s, f.athlete, 1, 1110000)
+	f.finish(t, s, 0, 2, 1120000)
+
+	if n, err := s.CountAthletes(ctx); err != nil || n != 1 {
+		t.Errorf("CountAthletes = %d, %v; want 1", n, err)
+	}
+	if n, err := s.CountResultsSince(ctx, before); err != nil || n != 2 {
+		t.Errorf("CountResultsSince(a minute ago) = %d, %v; want 2", n, err)
+	}
+	if n, err := s.CountResultsSince(ctx, time.Now().Add(time.Minute)); err != nil || n != 0 {
+		t.Errorf("CountResultsSince(a minute from now) = %d, %v; want 0", n, err)
+	}
+}
+
+func testJoins(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	f := newFixture(t, s)
+	result := f.finish(t, s, f.athlete, 1, 1110000)
+	f.finish(t, s, 0, 2, 1120000)
+
+	row, err := s.Result(ctx, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.RunnerName != "Sam Runner" || row.MeetID != f.meet {
+		t.Errorf("Result = %+v, want Sam Runner at meet %d", row, f.meet)
+	}
+
+	// The race names no course, so it is on the meet's
+	course, err := s.CourseResults(ctx, f.course)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(course) != 2 || course[0].Time != 1110000 || course[0].MeetName != "Invitational" {
+		t.Errorf("CourseResults = %+v", course)
+	}
+	races, err := s.AthleteRaces(ctx, f.athlete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(races) != 1 || races[0].CourseID != f.course || races[0].DistanceM != 5000 || races[0].Gender != store.GenderGirls {
+		t.Errorf("AthleteRaces = %+v", races)
+	}
+
+	// Fastest and latest only count our own athletes
+	start, end := day("2025-07-01"), day("2026-06-30")
+	fastest, err := s.FastestTimes(ctx, start, end, 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fastest) != 1 || fastest[0].AthleteName != "Sam Runner" {
+		t.Errorf("FastestTimes = %+v", fastest)
+	}
+	latest, err := s.LatestMeetResults(ctx, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 1 || latest[0].MeetName != "Invitational" {
+		t.Errorf("LatestMeetResults = %+v", latest)
+	}
+	between, err := s.ResultsBetween(ctx, day("2026-07-01"), day("2027-06-30"))
+	if err != nil || len(between) != 0 {
+		t.Errorf("ResultsBetween next season = %v, %v; want none", between, err)
+	}
+	history, err := s.AthleteRacesBetween(ctx, f.athlete, start, end)
+	if err != nil || len(history) != 1 || history[0].MeetID != f.meet || history[0].CourseID != f.course {
+		t.Errorf("AthleteRacesBetween = %+v, %v; want the Invitational on its course", history, err)
+	}
+
+	seasonID := insertID(t)(s.CreateSeason(ctx, store.Season{Year: 2025, Name: "2025", Start: start, End: end}))
+	if err := s.PutOnRoster(ctx, store.RosterEntry{SeasonID: seasonID, AthleteID: f.athlete, Grade: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutOnRoster(ctx, store.RosterEntry{SeasonID: seasonID, AthleteID: f.athlete, Grade: 11}); err != nil {
+		t.Fatal(err)
+	}
+	athletes, err := s.TeamRoster(ctx, f.home, seasonID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(athletes) != 1 || athletes[0].Grade != 11 {
+		t.Errorf("TeamRoster = %+v, want Sam in grade 11", athletes)
+	}
+}
+
+func testSessions(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	user := insertID(t)(s.CreateUser(ctx, store.User{Username: "coach", Role: "coach"}))
+	for hash, expires := range map[string]time.Time{"fresh": now.Add(time.Hour), "stale": now.Add(-time.Hour)} {
+		if err := s.CreateSession(ctx, hash, user, expires); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	row, err := s.SessionUser(ctx, "fresh", now)
+	if err != nil || row.Username != "coach" || row.Role != "coach" {
+		t.Errorf("fresh session = %+v, %v", row, err)
+	}
+	if _, err := s.SessionUser(ctx, "stale", now); err != sql.ErrNoRows {
+		t.Errorf("stale session: err = %v, want sql.ErrNoRows", err)
+	}
+	if found, err := s.DeleteUser(ctx, user); err != nil || !found {
+		t.Fatalf("DeleteUser = %v, %v; want found", found, err)
+	}
+	if _, err := s.SessionUser(ctx, "fresh", now); err != sql.ErrNoRows {
+		t.Errorf("session outlived its user: err = %v", err)
+	}
+}
+
+func testTransactions(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	create := func(tx store.Tx, name string) {
+		t.Helper()
+		if _, err := tx.CreateTeam(ctx, store.Team{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	teamCount := func() int {
+		t.Helper()
+		teams, err := s.Teams(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(teams)
+	}
+
+	tx, err := s.BeginTx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	create(tx, "Rolled Back")
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if n := teamCount(); n != 1 {
+		t.Errorf("after rollback there are %d teams, want 1", n)
+	}
+
+	tx, err = s.BeginTx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	create(tx, "Committed")
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	tx.Rollback()
+	if n := teamCount(); n != 2 {
+		t.Errorf("after commit there are %d teams, want 2", n)
+	}
+
+	// Transactions run one at a time, and each sees the last one's writes
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tx, err := s.BeginTx(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer tx.Rollback()
+			teams, err := tx.Teams(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err := tx.CreateTeam(ctx, store.Team{Name: fmt.Sprintf("Team %d of %d", i, len(teams))}); err != nil {
+				errs <- err
+				return
+			}
+			errs <- tx.Commit()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil && !errors.Is(err, store.ErrDuplicate) {
+			t.Error(err)
+		}
+	}
+	if n := teamCount(); n != 2+cap(errs) {
+		t.Errorf("after concurrent commits there are %d teams, want %d", n, 2+cap(errs))
+	}
+}
@@ -0,0 +1,115 @@
+// Package storetest runs tests against every kind of store: Memory, SQLite
+// in memory, and MySQL when TEST_MYSQL_DSN names a database the tests may
+// empty, such as
+//
+//	TEST_MYSQL_DSN='root@tcp(127.0.0.1:3306)/xc_test?parseTime=true' go test -p 1 ./...
+//
+// with -p 1, since every package's tests empty the same database.
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"jones-county-xc/backend/migrate"
+	"jones-county-xc/backend/store"
+)
+
+// Run runs test once per kind of store, as a subtest named for it. Each
+// newStore call in a subtest gives back a fresh store holding only the
+// home team, as a new database does.
+func Run(t *testing.T, test func(t *testing.T, newStore func(t *testing.T) store.Store)) {
+	t.Run("Memory", func(t *testing.T) {
+		test(t, func(t *testing.T) store.Store { return store.NewMemory() })
+	})
+	t.Run("SQLite", func(t *testing.T) {
+		test(t, newSQLite)
+	})
+	t.Run("MySQL", func(t *testing.T) {
+		dsn := os.Getenv("TEST_MYSQL_DSN")
+		if dsn == "" {
+			t.Skip("TEST_MYSQL_DSN is not set")
+		}
+		test(t, func(t *testing.T) store.Store { return newMySQL(t, dsn) })
+	})
+}
+
+func newSQLite(t *testing.T) store.Store {
+	t.Helper()
+	sqlDB, err := store.OpenSQLite(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	migrateUp(t, sqlDB)
+	return store.NewSQLite(sqlDB)
+}
+
+// migrateUp runs every migration on sqlDB, so the tests cover each of them
+// as well as the schema they end at.
+func migrateUp(t *testing.T, sqlDB *sql.DB) {
+	t.Helper()
+	m, err := migrate.New(sqlDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newMySQL drops every table in the database dsn names and migrates it
+// afresh.
+func newMySQL(t *testing.T, dsn string) store.Store {
+	t.Helper()
+	ctx := context.Background()
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ParseTime = true
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB := sql.OpenDB(connector)
+	t.Cleanup(func() { sqlDB.Close() })
+	// The tables refer to one another, so a single connection drops them
+	// all with the foreign keys turned off.
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	rows, err := conn.QueryContext(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	statements := []string{"SET FOREIGN_KEY_CHECKS = 0"}
+	for _, table := range tables {
+		statements = append(statements, "DROP TABLE `"+table+"`")
+	}
+	statements = append(statements, "SET FOREIGN_KEY_CHECKS = 1")
+	for _, statement := range statements {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conn.Close()
+	migrateUp(t, sqlDB)
+	return store.NewMySQL(sqlDB)
+}
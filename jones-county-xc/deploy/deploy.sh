@@ -51,6 +51,16 @@ echo "=== Restarting backend service ==="
 $SSH_CMD "sudo systemctl restart jonescountyxc"
 echo "Backend restarted"
 
+# Wait for the backend to answer with its database connected and migrated
+echo "=== Waiting for the backend to be ready ==="
+if ! $SSH_CMD 'for i in $(seq 1 30); do curl -fsS http://127.0.0.1:8080/api/health/ready && exit 0; sleep 1; done; exit 1'; then
+    echo "Backend is not ready:"
+    $SSH_CMD "curl -sS http://127.0.0.1:8080/api/health/ready; sudo journalctl -u jonescountyxc -n 20 --no-pager" || true
+    exit 1
+fi
+echo ""
+echo "Backend ready"
+
 # Verify
 echo "=== Verifying deployment ==="
 $SSH_CMD "sudo systemctl status jonescountyxc --no-pager -l" || true
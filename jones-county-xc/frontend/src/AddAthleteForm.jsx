@@ -1,6 +1,7 @@
 import { useRef, useState } from 'react'
 import { useMutation, useQueryClient } from '@tanstack/react-query'
 import { Button } from '@/components/ui/button'
+import { errorMessage } from '@/lib/utils'
 
 function AddAthleteForm() {
   const [errors, setErrors] = useState({})
@@ -16,8 +17,7 @@ function AddAthleteForm() {
         body: JSON.stringify(athlete),
       })
       if (!res.ok) {
-        const text = await res.text()
-        throw new Error(text || 'Failed to add athlete')
+        throw new Error(await errorMessage(res, 'Failed to add athlete'))
       }
       return res.json()
     },
@@ -9,6 +9,7 @@ import {
   AlertDialogHeader,
   AlertDialogTitle,
 } from '@/components/ui/alert-dialog'
+import { errorMessage } from '@/lib/utils'
 
 function DeleteAthleteDialog({ athlete, open, onOpenChange }) {
   const queryClient = useQueryClient()
@@ -19,8 +20,7 @@ function DeleteAthleteDialog({ athlete, open, onOpenChange }) {
         method: 'DELETE',
       })
       if (!res.ok) {
-        const text = await res.text()
-        throw new Error(text || 'Failed to delete athlete')
+        throw new Error(await errorMessage(res, 'Failed to delete athlete'))
       }
       return res.json()
     },
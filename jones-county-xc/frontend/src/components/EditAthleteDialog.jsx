@@ -9,6 +9,7 @@ import {
   DialogHeader,
   DialogTitle,
 } from '@/components/ui/dialog'
+import { errorMessage } from '@/lib/utils'
 
 function EditAthleteDialog({ athlete, open, onOpenChange }) {
   const [errors, setErrors] = useState({})
@@ -35,8 +36,7 @@ function EditAthleteDialog({ athlete, open, onOpenChange }) {
         body: JSON.stringify(data),
       })
       if (!res.ok) {
-        const text = await res.text()
-        throw new Error(text || 'Failed to update athlete')
+        throw new Error(await errorMessage(res, 'Failed to update athlete'))
       }
       return res.json()
     },
@@ -29,7 +29,7 @@ function NavItem({ to, label, onClick }) {
 
 function Navigation() {
   const [menuOpen, setMenuOpen] = useState(false)
-  const { isAuthenticated, logout } = useAuth()
+  const { isAuthenticated, isStaff, logout } = useAuth()
 
   function closeMenu() {
     setMenuOpen(false)
@@ -42,7 +42,7 @@ function Navigation() {
           {links.map((link) => (
             <NavItem key={link.to} {...link} />
           ))}
-          {isAuthenticated && <NavItem to="/admin" label="Admin" />}
+          {isStaff && <NavItem to="/admin" label="Admin" />}
         </div>
 
         <div className="hidden md:flex items-center gap-2">
@@ -78,7 +78,7 @@ function Navigation() {
           {links.map((link) => (
             <NavItem key={link.to} {...link} onClick={closeMenu} />
           ))}
-          {isAuthenticated && <NavItem to="/admin" label="Admin" onClick={closeMenu} />}
+          {isStaff && <NavItem to="/admin" label="Admin" onClick={closeMenu} />}
           <div className="border-t border-gray-200 pt-2 mt-1">
             {isAuthenticated ? (
               <Button variant="outline" size="sm" className="w-full" onClick={() => { logout(); closeMenu() }}>
@@ -2,7 +2,11 @@ import { Navigate, Outlet } from 'react-router'
 import { useAuth } from '@/contexts/AuthContext'
 
 function ProtectedRoute() {
-  const { isAuthenticated } = useAuth()
+  const { isAuthenticated, loading } = useAuth()
+
+  if (loading) {
+    return null
+  }
 
   if (!isAuthenticated) {
     return <Navigate to="/login" replace />
@@ -1,22 +1,42 @@
-import { createContext, useContext, useState } from 'react'
+import { createContext, useContext, useEffect, useState } from 'react'
 
 const AuthContext = createContext(null)
 
 export function AuthProvider({ children }) {
   const [user, setUser] = useState(null)
+  const [loading, setLoading] = useState(true)
 
-  function login(username, password) {
-    if (!username || !password) return false
-    setUser({ username })
+  // Restore an existing session from the HTTP-only cookie on page load
+  useEffect(() => {
+    fetch('/api/auth/me')
+      .then((res) => (res.ok ? res.json() : null))
+      .then(setUser)
+      .catch(() => setUser(null))
+      .finally(() => setLoading(false))
+  }, [])
+
+  async function login(username, password) {
+    const res = await fetch('/api/auth/login', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ username, password }),
+    })
+    if (!res.ok) return false
+    setUser(await res.json())
     return true
   }
 
-  function logout() {
+  async function logout() {
+    await fetch('/api/auth/logout', { method: 'POST' })
     setUser(null)
   }
 
+  // Head and assistant coaches get the admin dashboard; athlete and parent
+  // accounts only use the public pages
+  const isStaff = user?.role === 'admin' || user?.role === 'coach'
+
   return (
-    <AuthContext.Provider value={{ user, isAuthenticated: !!user, login, logout }}>
+    <AuthContext.Provider value={{ user, loading, isAuthenticated: !!user, isStaff, login, logout }}>
       {children}
     </AuthContext.Provider>
   )
@@ -4,3 +4,14 @@ import { twMerge } from "tailwind-merge"
 export function cn(...inputs) {
   return twMerge(clsx(inputs));
 }
+
+// errorMessage reads the message from an API error response, whose body is
+// {"error": {"code", "message", "fields"}}, falling back when it has none.
+export async function errorMessage(res, fallback) {
+  try {
+    const body = await res.json()
+    return body?.error?.message || fallback
+  } catch {
+    return fallback
+  }
+}
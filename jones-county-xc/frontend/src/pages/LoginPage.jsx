@@ -8,7 +8,7 @@ function LoginPage() {
   const { login } = useAuth()
   const navigate = useNavigate()
 
-  function handleSubmit(e) {
+  async function handleSubmit(e) {
     e.preventDefault()
     setError('')
     const username = e.target.username.value.trim()
@@ -19,11 +19,11 @@ function LoginPage() {
       return
     }
 
-    const success = login(username, password)
+    const success = await login(username, password)
     if (success) {
       navigate('/admin', { replace: true })
     } else {
-      setError('Login failed')
+      setError('Invalid username or password')
     }
   }
 